@@ -173,14 +173,14 @@ type AOMsg interface {
 
 //go:generate ./cmdno.sh aocmds AOCmd ao uint8 AOMsg newAOMsg
 
-func writeAOMsg(w io.Writer, msg AOMsg) error {
+func writeAOMsg(w io.Writer, msg AOMsg, ver uint16) error {
 	if _, err := w.Write([]byte{msg.aoCmdNo()}); err != nil {
 		return err
 	}
-	return serialize(w, msg)
+	return serialize(w, msg, ver)
 }
 
-func readAOMsg(r io.Reader) (AOMsg, error) {
+func readAOMsg(r io.Reader, ver uint16) (AOMsg, error) {
 	buf := make([]byte, 1)
 	if _, err := io.ReadFull(r, buf); err != nil {
 		return nil, err
@@ -190,7 +190,7 @@ func readAOMsg(r io.Reader) (AOMsg, error) {
 		return nil, fmt.Errorf("unsupported ao msg: %d", buf[0])
 	}
 	msg := newCmd()
-	return msg, deserialize(r, msg)
+	return msg, deserialize(r, msg, ver)
 }
 
 type IDAOMsg struct {
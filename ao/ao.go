@@ -0,0 +1,313 @@
+// Package ao tracks the state of the active objects (AOs)
+// a client can see from the Cmds a server sends.
+package ao
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anon55555/mt"
+)
+
+// An AO is the state of an active object.
+type AO struct {
+	ID mt.AOID
+
+	// For players.
+	Name     string
+	IsPlayer bool
+
+	Props mt.AOProps
+
+	// Pos is the last AOPos received.
+	// If the AO is attached, it is ignored by the client.
+	Pos mt.AOPos
+	// PosTime is when Pos was received.
+	PosTime time.Time
+	// from is where the AO was at PosTime.
+	from mt.Pos
+
+	HP         uint16
+	Armor      []mt.Group
+	TextureMod mt.Texture
+	Sprite     mt.AOSprite
+	Anim       mt.AOAnim
+	Bones      map[string]mt.AOBonePos
+	Phys       mt.AOPhysOverride
+
+	// Attach.ParentID is 0 if the AO is not attached.
+	Attach mt.AOAttach
+}
+
+// PosAt returns where the AO is at t,
+// interpolating from where it was to Pos if Pos.Interpolate is set
+// and the AO is not physical (Props.CollideWithNodes),
+// and moving it according to Pos.Vel and Pos.Acc
+// like the client does.
+// Collisions of physical AOs are not simulated.
+func (ao *AO) PosAt(t time.Time) mt.Pos {
+	dt := float32(t.Sub(ao.PosTime).Seconds())
+	if dt < 0 {
+		dt = 0
+	}
+
+	pos := ao.Pos.Pos
+	if ao.Pos.Interpolate && !ao.Props.CollideWithNodes {
+		ratio := float32(1)
+		if ao.Pos.UpdateInterval > 0.001 {
+			ratio = dt / ao.Pos.UpdateInterval
+		}
+		if ratio > 1.5 {
+			ratio = 1.5
+		}
+		if ao.Pos.End && ratio > 1 {
+			ratio = 1
+		}
+		pos = ao.from.Add(pos.From(ao.from).Mul(ratio))
+	}
+
+	return pos.Add(ao.Pos.Vel.Mul(dt).Add(ao.Pos.Acc.Mul(dt * dt / 2)))
+}
+
+func (ao *AO) clone() *AO {
+	c := *ao
+	c.Bones = make(map[string]mt.AOBonePos, len(ao.Bones))
+	for bone, pos := range ao.Bones {
+		c.Bones[bone] = pos
+	}
+	return &c
+}
+
+// An AOTracker keeps track of the AOs a client can see.
+// It is safe for concurrent use.
+// The zero value is an AOTracker that tracks no AOs.
+//
+// The callbacks, if not nil, are called with a copy of the AO
+// after it has changed, without holding any locks.
+// They must not be changed once the AOTracker is used.
+type AOTracker struct {
+	// OnSpawn is called when an AO is added,
+	// after the AOMsgs in its AOInitData have been applied.
+	OnSpawn func(*AO)
+
+	// OnDespawn is called when an AO is removed.
+	OnDespawn func(*AO)
+
+	// OnChange is called when msg has been applied to an AO.
+	// It is also called for the AOs attached to a removed AO,
+	// which are detached, with a nil msg.
+	OnChange func(ao *AO, msg mt.AOMsg)
+
+	mu       sync.RWMutex
+	aos      map[mt.AOID]*AO
+	children map[mt.AOID]map[mt.AOID]struct{}
+}
+
+// Apply applies cmd to t.
+// Cmds other than ToCltAORmAdd and ToCltAOMsgs are ignored,
+// as are AOMsgs for AOs that are not tracked
+// and, like the client does, additions of AOs that are already tracked.
+func (t *AOTracker) Apply(cmd mt.Cmd) {
+	var calls []func()
+	now := time.Now()
+
+	t.mu.Lock()
+	switch cmd := cmd.(type) {
+	case *mt.ToCltAORmAdd:
+		for _, id := range cmd.Remove {
+			calls = t.remove(calls, id)
+		}
+		for _, add := range cmd.Add {
+			if _, ok := t.aos[add.ID]; ok {
+				continue
+			}
+			calls = t.add(calls, add.ID, add.InitData, now)
+		}
+	case *mt.ToCltAOMsgs:
+		for _, msg := range cmd.Msgs {
+			ao, ok := t.aos[msg.ID]
+			if !ok {
+				continue
+			}
+			t.apply(ao, msg.Msg, now)
+			if t.OnChange != nil {
+				ao, msg := ao.clone(), msg.Msg
+				calls = append(calls, func() { t.OnChange(ao, msg) })
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	for _, f := range calls {
+		f()
+	}
+}
+
+func (t *AOTracker) add(calls []func(), id mt.AOID, init mt.AOInitData, now time.Time) []func() {
+	ao := &AO{
+		ID:       id,
+		Name:     init.Name,
+		IsPlayer: init.IsPlayer,
+		Pos:      mt.AOPos{Pos: init.Pos, Rot: init.Rot},
+		PosTime:  now,
+		from:     init.Pos,
+		HP:       init.HP,
+		Bones:    make(map[string]mt.AOBonePos),
+	}
+	for _, msg := range init.Msgs {
+		t.apply(ao, msg, now)
+	}
+
+	if t.aos == nil {
+		t.aos = make(map[mt.AOID]*AO)
+	}
+	t.aos[id] = ao
+
+	if t.OnSpawn != nil {
+		ao := ao.clone()
+		calls = append(calls, func() { t.OnSpawn(ao) })
+	}
+	return calls
+}
+
+func (t *AOTracker) remove(calls []func(), id mt.AOID) []func() {
+	ao, ok := t.aos[id]
+	if !ok {
+		return calls
+	}
+
+	t.attach(ao, 0)
+	for parent := range t.children {
+		t.rmChild(parent, id) // In case it was spawned as an infant.
+	}
+	delete(t.aos, id)
+	if t.OnDespawn != nil {
+		ao := ao.clone()
+		calls = append(calls, func() { t.OnDespawn(ao) })
+	}
+
+	// Like the client, detach the children.
+	for _, child := range t.sortedChildren(id) {
+		c, ok := t.aos[child]
+		if !ok || c.Attach.ParentID != id {
+			continue
+		}
+		c.Attach = mt.AOAttach{}
+		if t.OnChange != nil {
+			c := c.clone()
+			calls = append(calls, func() { t.OnChange(c, nil) })
+		}
+	}
+	delete(t.children, id)
+
+	return calls
+}
+
+func (t *AOTracker) apply(ao *AO, msg mt.AOMsg, now time.Time) {
+	switch msg := msg.(type) {
+	case *mt.AOCmdProps:
+		ao.Props = msg.Props
+	case *mt.AOCmdPos:
+		ao.from = ao.PosAt(now)
+		ao.Pos = msg.Pos
+		ao.PosTime = now
+	case *mt.AOCmdTextureMod:
+		ao.TextureMod = msg.Mod
+	case *mt.AOCmdSprite:
+		ao.Sprite = msg.Sprite
+	case *mt.AOCmdHP:
+		ao.HP = msg.HP
+	case *mt.AOCmdArmorGroups:
+		ao.Armor = msg.Armor
+	case *mt.AOCmdAnim:
+		ao.Anim = msg.Anim
+	case *mt.AOCmdAnimSpeed:
+		ao.Anim.Speed = msg.Speed
+	case *mt.AOCmdBonePos:
+		ao.Bones[msg.Bone] = msg.Pos
+	case *mt.AOCmdAttach:
+		t.attach(ao, msg.Attach.ParentID)
+		ao.Attach = msg.Attach
+	case *mt.AOCmdPhysOverride:
+		ao.Phys = msg.Phys
+	case *mt.AOCmdSpawnInfant:
+		// Like the client, only record the child.
+		// It is attached by its own AOCmdAttach.
+		t.addChild(ao.ID, msg.ID)
+	}
+}
+
+// attach moves ao to the children of parent in the attachment graph.
+// The parent does not have to be tracked yet.
+func (t *AOTracker) attach(ao *AO, parent mt.AOID) {
+	if old := ao.Attach.ParentID; old != 0 {
+		t.rmChild(old, ao.ID)
+	}
+	ao.Attach.ParentID = parent
+
+	if parent != 0 {
+		t.addChild(parent, ao.ID)
+	}
+}
+
+func (t *AOTracker) addChild(parent, child mt.AOID) {
+	if t.children == nil {
+		t.children = make(map[mt.AOID]map[mt.AOID]struct{})
+	}
+	if t.children[parent] == nil {
+		t.children[parent] = make(map[mt.AOID]struct{})
+	}
+	t.children[parent][child] = struct{}{}
+}
+
+func (t *AOTracker) rmChild(parent, child mt.AOID) {
+	delete(t.children[parent], child)
+	if len(t.children[parent]) == 0 {
+		delete(t.children, parent)
+	}
+}
+
+func (t *AOTracker) sortedChildren(id mt.AOID) []mt.AOID {
+	ids := make([]mt.AOID, 0, len(t.children[id]))
+	for child := range t.children[id] {
+		ids = append(ids, child)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// AO returns a copy of the AO with the given ID.
+// ok is false if it is not tracked.
+func (t *AOTracker) AO(id mt.AOID) (ao *AO, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if ao, ok := t.aos[id]; ok {
+		return ao.clone(), true
+	}
+	return nil, false
+}
+
+// AOs returns copies of all tracked AOs, sorted by ID.
+func (t *AOTracker) AOs() []*AO {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	aos := make([]*AO, 0, len(t.aos))
+	for _, ao := range t.aos {
+		aos = append(aos, ao.clone())
+	}
+	sort.Slice(aos, func(i, j int) bool { return aos[i].ID < aos[j].ID })
+	return aos
+}
+
+// Children returns the IDs of the tracked AOs attached to the AO with the given ID
+// and of the AOs it spawned as infants, which may not be tracked yet,
+// sorted by ID.
+func (t *AOTracker) Children(id mt.AOID) []mt.AOID {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.sortedChildren(id)
+}
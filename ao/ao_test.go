@@ -0,0 +1,154 @@
+package ao
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/anon55555/mt"
+)
+
+func TestAOTracker(t *testing.T) {
+	var evs []string
+	tr := &AOTracker{
+		OnSpawn:   func(ao *AO) { evs = append(evs, "spawn "+ao.Name) },
+		OnDespawn: func(ao *AO) { evs = append(evs, "despawn "+ao.Name) },
+		OnChange: func(ao *AO, msg mt.AOMsg) {
+			evs = append(evs, fmt.Sprintf("change %s %T", ao.Name, msg))
+		},
+	}
+
+	tr.Apply(&mt.ToCltAORmAdd{Add: []mt.AOAdd{
+		{ID: 1, InitData: mt.AOInitData{Name: "parent", HP: 20}},
+		{ID: 2, InitData: mt.AOInitData{
+			Name: "child",
+			Msgs: []mt.AOMsg{
+				&mt.AOCmdAttach{Attach: mt.AOAttach{ParentID: 1, Bone: "head"}},
+				&mt.AOCmdProps{Props: mt.AOProps{Visual: "mesh"}},
+			},
+		}},
+	}})
+	tr.Apply(&mt.ToCltAOMsgs{Msgs: []mt.IDAOMsg{
+		{ID: 1, Msg: &mt.AOCmdHP{HP: 15}},
+		{ID: 1, Msg: &mt.AOCmdAnim{Anim: mt.AOAnim{Frames: [2]int32{1, 10}, Speed: 30}}},
+		{ID: 1, Msg: &mt.AOCmdAnimSpeed{Speed: 60}},
+		{ID: 1, Msg: &mt.AOCmdBonePos{Bone: "arm", Pos: mt.AOBonePos{Pos: mt.Vec{1, 2, 3}}}},
+		{ID: 3, Msg: &mt.AOCmdHP{}},
+	}})
+
+	if got := tr.Children(1); !reflect.DeepEqual(got, []mt.AOID{2}) {
+		t.Errorf("children: got %v, want [2]", got)
+	}
+	child, ok := tr.AO(2)
+	if !ok || child.Props.Visual != "mesh" || child.Attach.Bone != "head" {
+		t.Errorf("child: %+v", child)
+	}
+	parent, _ := tr.AO(1)
+	if parent.HP != 15 || parent.Anim.Speed != 60 || parent.Bones["arm"].Pos != (mt.Vec{1, 2, 3}) {
+		t.Errorf("parent: %+v", parent)
+	}
+
+	// Copies are not affected by later changes.
+	parent.Bones["arm"] = mt.AOBonePos{}
+	if p, _ := tr.AO(1); p.Bones["arm"].Pos != (mt.Vec{1, 2, 3}) {
+		t.Error("copy shares Bones")
+	}
+
+	// Adding a tracked AO again is ignored.
+	tr.Apply(&mt.ToCltAORmAdd{Add: []mt.AOAdd{{ID: 1, InitData: mt.AOInitData{Name: "again"}}}})
+	if p, _ := tr.AO(1); p.Name != "parent" || p.HP != 15 {
+		t.Errorf("re-added AO replaced: %+v", p)
+	}
+
+	tr.Apply(&mt.ToCltAORmAdd{Remove: []mt.AOID{1}})
+	if _, ok := tr.AO(1); ok {
+		t.Error("removed AO is tracked")
+	}
+	if child, _ := tr.AO(2); child.Attach.ParentID != 0 {
+		t.Error("child of removed AO is attached")
+	}
+	if len(tr.AOs()) != 1 {
+		t.Errorf("%d AOs, want 1", len(tr.AOs()))
+	}
+
+	want := []string{
+		"spawn parent",
+		"spawn child",
+		"change parent *mt.AOCmdHP",
+		"change parent *mt.AOCmdAnim",
+		"change parent *mt.AOCmdAnimSpeed",
+		"change parent *mt.AOCmdBonePos",
+		"despawn parent",
+		"change child <nil>",
+	}
+	if !reflect.DeepEqual(evs, want) {
+		t.Errorf("got events %q, want %q", evs, want)
+	}
+}
+
+func TestSpawnInfant(t *testing.T) {
+	var tr AOTracker
+	tr.Apply(&mt.ToCltAORmAdd{Add: []mt.AOAdd{{ID: 1}}})
+	tr.Apply(&mt.ToCltAOMsgs{Msgs: []mt.IDAOMsg{
+		{ID: 1, Msg: &mt.AOCmdSpawnInfant{ID: 2}},
+	}})
+	if got := tr.Children(1); !reflect.DeepEqual(got, []mt.AOID{2}) {
+		t.Errorf("children: got %v, want [2]", got)
+	}
+
+	tr.Apply(&mt.ToCltAORmAdd{Add: []mt.AOAdd{{ID: 2}}})
+	if child, _ := tr.AO(2); child.Attach.ParentID != 0 {
+		t.Error("infant attached without AOCmdAttach")
+	}
+
+	tr.Apply(&mt.ToCltAORmAdd{Remove: []mt.AOID{2}})
+	if got := tr.Children(1); len(got) != 0 {
+		t.Errorf("children after removing infant: %v", got)
+	}
+
+	// The infant does not have to be tracked when the parent is removed.
+	tr.Apply(&mt.ToCltAOMsgs{Msgs: []mt.IDAOMsg{
+		{ID: 1, Msg: &mt.AOCmdSpawnInfant{ID: 3}},
+	}})
+	tr.Apply(&mt.ToCltAORmAdd{Remove: []mt.AOID{1}})
+	if got := tr.Children(1); len(got) != 0 {
+		t.Errorf("children after removing parent: %v", got)
+	}
+}
+
+func TestPosAt(t *testing.T) {
+	now := time.Now()
+	ao := &AO{
+		Pos: mt.AOPos{
+			Pos:            mt.Pos{10, 0, 0},
+			Vel:            mt.Vec{0, 2, 0},
+			Interpolate:    true,
+			UpdateInterval: 1,
+		},
+		PosTime: now,
+	}
+
+	for _, tc := range []struct {
+		dt   time.Duration
+		end  bool
+		want mt.Pos
+	}{
+		{0, false, mt.Pos{0, 0, 0}},
+		{time.Second / 2, false, mt.Pos{5, 1, 0}},
+		{time.Second, false, mt.Pos{10, 2, 0}},
+		{3 * time.Second, false, mt.Pos{15, 6, 0}},
+		{3 * time.Second, true, mt.Pos{10, 6, 0}},
+	} {
+		ao.Pos.End = tc.end
+		if got := ao.PosAt(now.Add(tc.dt)); got != tc.want {
+			t.Errorf("%v, end %v: got %v, want %v", tc.dt, tc.end, got, tc.want)
+		}
+	}
+
+	// Physical AOs are not interpolated.
+	ao.Props.CollideWithNodes = true
+	if got, want := ao.PosAt(now.Add(time.Second/2)), (mt.Pos{10, 1, 0}); got != want {
+		t.Errorf("physical: got %v, want %v", got, want)
+	}
+}
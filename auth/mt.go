@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/anon55555/mt"
+)
+
+// ErrMethod is returned by ServeSRP if the client
+// uses a different auth method than the stored password requires.
+var ErrMethod = errors.New("wrong auth method")
+
+// FirstSRP returns the ToSrvFirstSRP that sets the password
+// of a new player if the server offers mt.FirstSRP.
+func FirstSRP(name, pass string) (*mt.ToSrvFirstSRP, error) {
+	salt, verifier, err := NewVerifier(name, pass)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mt.ToSrvFirstSRP{
+		Salt:        salt,
+		Verifier:    verifier,
+		EmptyPasswd: pass == "",
+	}, nil
+}
+
+// NewLogin returns a Client that logs in using method,
+// which must be mt.SRP or mt.LegacyPasswd,
+// and the ToSrvSRPBytesA that starts the exchange.
+// Sudo mode, i.e. ToCltAcceptSudoMode and ToCltDenySudoMode,
+// is not handled by this package.
+func NewLogin(name, pass string, method mt.AuthMethods) (*Client, *mt.ToSrvSRPBytesA, error) {
+	if method == mt.LegacyPasswd {
+		pass = LegacyPasswd(name, pass)
+	}
+
+	c, err := NewClient(name, pass)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c, &mt.ToSrvSRPBytesA{
+		A:      c.A(),
+		NoSHA1: method != mt.LegacyPasswd,
+	}, nil
+}
+
+// BytesM returns the ToSrvSRPBytesM answering cmd.
+func (c *Client) BytesM(cmd *mt.ToCltSRPBytesSaltB) (*mt.ToSrvSRPBytesM, error) {
+	M, err := c.Proof(cmd.Salt, cmd.B)
+	if err != nil {
+		return nil, err
+	}
+	return &mt.ToSrvSRPBytesM{M: M}, nil
+}
+
+// ServeSRP returns a Server answering cmd for the player name
+// whose password is stored as passwd, either an encoded verifier
+// or a legacy password, and the ToCltSRPBytesSaltB to send.
+// The client's ToSrvSRPBytesM is then checked with Server.Verify.
+func ServeSRP(name, passwd string, cmd *mt.ToSrvSRPBytesA) (*Server, *mt.ToCltSRPBytesSaltB, error) {
+	var salt, verifier []byte
+	if IsVerifier(passwd) {
+		if !cmd.NoSHA1 {
+			return nil, nil, ErrMethod
+		}
+
+		var err error
+		if salt, verifier, err = DecodeVerifier(passwd); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if cmd.NoSHA1 {
+			return nil, nil, ErrMethod
+		}
+
+		var err error
+		if salt, verifier, err = NewVerifier(name, passwd); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	s, err := NewServer(name, salt, verifier, cmd.A)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s, &mt.ToCltSRPBytesSaltB{Salt: s.Salt(), B: s.B()}, nil
+}
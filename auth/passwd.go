@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrVerifier is returned by DecodeVerifier if the string is malformed.
+var ErrVerifier = errors.New("invalid encoded SRP verifier")
+
+// EncodeVerifier encodes salt and verifier
+// the way they are stored in auth.txt and the auth database.
+func EncodeVerifier(salt, verifier []byte) string {
+	return "#1#" + base64.StdEncoding.EncodeToString(salt) +
+		"#" + base64.StdEncoding.EncodeToString(verifier)
+}
+
+// DecodeVerifier decodes a salt and verifier encoded by EncodeVerifier.
+func DecodeVerifier(s string) (salt, verifier []byte, err error) {
+	f := strings.Split(s, "#")
+	if len(f) != 4 || f[0] != "" || f[1] != "1" {
+		return nil, nil, ErrVerifier
+	}
+
+	if salt, err = base64.StdEncoding.DecodeString(f[2]); err != nil {
+		return nil, nil, ErrVerifier
+	}
+	if verifier, err = base64.StdEncoding.DecodeString(f[3]); err != nil {
+		return nil, nil, ErrVerifier
+	}
+	return salt, verifier, nil
+}
+
+// IsVerifier reports whether a password stored in auth.txt
+// is an encoded SRP verifier rather than a legacy password.
+func IsVerifier(passwd string) bool {
+	return strings.HasPrefix(passwd, "#1#")
+}
+
+// LegacyPasswd returns the legacy password of name and pass,
+// which old servers stored instead of an SRP verifier.
+// Servers offering mt.LegacyPasswd use it as the SRP password.
+func LegacyPasswd(name, pass string) string {
+	if pass == "" {
+		return ""
+	}
+
+	sum := sha1.Sum([]byte(name + pass))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
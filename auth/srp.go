@@ -0,0 +1,247 @@
+// Package auth implements Minetest's authentication:
+// SRP-6a with SHA-256 and the 2048-bit group of RFC 5054,
+// the verifier encoding used in auth.txt and legacy passwords.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"math/big"
+	"strings"
+)
+
+// SaltLen is the length of salts generated by NewVerifier.
+const SaltLen = 16
+
+// ErrSRP is returned if the peer sent invalid SRP parameters.
+var ErrSRP = errors.New("invalid SRP parameters")
+
+// A group is an SRP group with its hash function.
+type group struct {
+	N, g *big.Int
+	hash func() hash.Hash
+}
+
+// ng2048 is the group used by Minetest.
+var ng2048 = group{
+	N: mustHex("AC6BDB41324A9A9BF166DE5E1389582FAF72B6651987EE07FC3192943DB56050" +
+		"A37329CBB4A099ED8193E0757767A13DD52312AB4B03310DCD7F48A9DA04FD50" +
+		"E8083969EDB767B0CF6095179A163AB3661A05FBD5FAAAE82918A9962F0B93B8" +
+		"55F97993EC975EEAA80D740ADBF4FF747359D041D5C33EA71D281E446B14773B" +
+		"CA97B43A23FB801676BD207A436C6481F1D2B9078717461A5B9D32E688F87748" +
+		"544523B524B0D57D5EA77A2775D2ECFA032CFBDBF52FB3786160279004E57AE6" +
+		"AF874E7303CE53299CCC041C7BC308D82A5698F3A8D0C38271AE35F8E9DBFBB6" +
+		"94B5C803D89F7AE435DE236D525F54759B65E372FCD68EF20FA7111F9E4AFF73"),
+	g:    big.NewInt(2),
+	hash: sha256.New,
+}
+
+func mustHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+	return n
+}
+
+func (grp *group) h(data ...[]byte) []byte {
+	h := grp.hash()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func (grp *group) hInt(data ...[]byte) *big.Int {
+	return new(big.Int).SetBytes(grp.h(data...))
+}
+
+// pad returns n as a big-endian byte slice as long as N.
+func (grp *group) pad(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, (grp.N.BitLen()+7)/8))
+}
+
+// k returns the multiplier parameter H(N | PAD(g)).
+func (grp *group) k() *big.Int {
+	return grp.hInt(grp.pad(grp.N), grp.pad(grp.g))
+}
+
+// x returns the private key derived from the password.
+// Minetest uses the lowercase name here.
+func (grp *group) x(name, pass string, salt []byte) *big.Int {
+	return grp.hInt(salt, grp.h([]byte(strings.ToLower(name)+":"+pass)))
+}
+
+// m returns the client's proof.
+// Minetest uses the name as given here.
+func (grp *group) m(name string, salt []byte, A, B *big.Int, K []byte) []byte {
+	hN, hg := grp.h(grp.N.Bytes()), grp.h(grp.g.Bytes())
+	for i := range hN {
+		hN[i] ^= hg[i]
+	}
+	return grp.h(hN, grp.h([]byte(name)), salt, A.Bytes(), B.Bytes(), K)
+}
+
+func (grp *group) verifier(name, pass string, salt []byte) []byte {
+	return new(big.Int).Exp(grp.g, grp.x(name, pass, salt), grp.N).Bytes()
+}
+
+// randExp returns a random private exponent.
+func randExp() (*big.Int, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// NewVerifier returns a random salt and the SRP verifier for name and pass.
+func NewVerifier(name, pass string) (salt, verifier []byte, err error) {
+	salt = make([]byte, SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	return salt, MakeVerifier(name, pass, salt), nil
+}
+
+// MakeVerifier returns the SRP verifier for name and pass with salt.
+func MakeVerifier(name, pass string, salt []byte) []byte {
+	return ng2048.verifier(name, pass, salt)
+}
+
+// A Client is the client side of an SRP exchange.
+type Client struct {
+	grp  *group
+	name string
+	pass string
+
+	a, pubA *big.Int
+	key     []byte
+}
+
+// NewClient returns a Client that authenticates as name with pass.
+func NewClient(name, pass string) (*Client, error) {
+	a, err := randExp()
+	if err != nil {
+		return nil, err
+	}
+	return newClient(&ng2048, name, pass, a), nil
+}
+
+func newClient(grp *group, name, pass string, a *big.Int) *Client {
+	return &Client{
+		grp:  grp,
+		name: name,
+		pass: pass,
+
+		a:    a,
+		pubA: new(big.Int).Exp(grp.g, a, grp.N),
+	}
+}
+
+// A returns the client's public ephemeral value.
+func (c *Client) A() []byte { return c.pubA.Bytes() }
+
+// Proof returns the client's proof M for the salt and
+// the server's public ephemeral value B.
+func (c *Client) Proof(salt, B []byte) ([]byte, error) {
+	grp := c.grp
+
+	b := new(big.Int).SetBytes(B)
+	if new(big.Int).Mod(b, grp.N).Sign() == 0 {
+		return nil, ErrSRP
+	}
+
+	u := grp.hInt(grp.pad(c.pubA), grp.pad(b))
+	if u.Sign() == 0 {
+		return nil, ErrSRP
+	}
+
+	x := grp.x(c.name, c.pass, salt)
+
+	// S = (B - k * g^x) ^ (a + u * x) % N
+	S := new(big.Int).Exp(grp.g, x, grp.N)
+	S.Mul(S, grp.k())
+	S.Sub(b, S)
+	S.Mod(S, grp.N)
+	S.Exp(S, new(big.Int).Add(c.a, new(big.Int).Mul(u, x)), grp.N)
+
+	c.key = grp.h(S.Bytes())
+	return grp.m(c.name, salt, c.pubA, b, c.key), nil
+}
+
+// Key returns the session key after Proof succeeded.
+func (c *Client) Key() []byte { return c.key }
+
+// A Server is the server side of an SRP exchange.
+type Server struct {
+	grp      *group
+	name     string
+	salt     []byte
+	verifier *big.Int
+
+	pubA, b, pubB *big.Int
+	key           []byte
+}
+
+// NewServer returns a Server that authenticates the client
+// with the public ephemeral value A as name
+// using the salt and verifier stored for name.
+func NewServer(name string, salt, verifier, A []byte) (*Server, error) {
+	b, err := randExp()
+	if err != nil {
+		return nil, err
+	}
+	return newServer(&ng2048, name, salt, verifier, A, b)
+}
+
+func newServer(grp *group, name string, salt, verifier, A []byte, b *big.Int) (*Server, error) {
+	s := &Server{
+		grp:      grp,
+		name:     name,
+		salt:     salt,
+		verifier: new(big.Int).SetBytes(verifier),
+
+		pubA: new(big.Int).SetBytes(A),
+		b:    b,
+	}
+
+	if new(big.Int).Mod(s.pubA, grp.N).Sign() == 0 {
+		return nil, ErrSRP
+	}
+
+	// B = k * v + g^b % N
+	s.pubB = new(big.Int).Mul(grp.k(), s.verifier)
+	s.pubB.Add(s.pubB, new(big.Int).Exp(grp.g, b, grp.N))
+	s.pubB.Mod(s.pubB, grp.N)
+
+	u := grp.hInt(grp.pad(s.pubA), grp.pad(s.pubB))
+
+	// S = (A * v^u) ^ b % N
+	S := new(big.Int).Exp(s.verifier, u, grp.N)
+	S.Mul(S, s.pubA)
+	S.Exp(S, b, grp.N)
+
+	s.key = grp.h(S.Bytes())
+	return s, nil
+}
+
+// Salt returns the salt to send to the client.
+func (s *Server) Salt() []byte { return s.salt }
+
+// B returns the server's public ephemeral value.
+func (s *Server) B() []byte { return s.pubB.Bytes() }
+
+// Verify reports whether the client's proof M is valid,
+// i.e. whether the client knows the password.
+func (s *Server) Verify(M []byte) bool {
+	want := s.grp.m(s.name, s.salt, s.pubA, s.pubB, s.key)
+	return subtle.ConstantTimeCompare(M, want) == 1
+}
+
+// Key returns the session key.
+// It is only shared with the client if Verify succeeded.
+func (s *Server) Key() []byte { return s.key }
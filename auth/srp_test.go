@@ -0,0 +1,300 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anon55555/mt"
+)
+
+// rfc5054 is the 1024-bit group with SHA-1 from RFC 5054's test vectors.
+var rfc5054 = group{
+	N: mustHex("EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576" +
+		"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1" +
+		"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC" +
+		"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3"),
+	g:    big.NewInt(2),
+	hash: sha1.New,
+}
+
+var (
+	testA = mustHex("60975527035CF2AD1989806F0407210BC81EDC04E2762A56AFD529DDDA2D4393")
+	testB = mustHex("E487CB59D31AC550471E81F00F6928E01DDA08E974A004F49E61F5D105284D20")
+)
+
+type srpVector struct {
+	grp        *group
+	name, pass string
+	salt       string
+
+	k, x, v, A, B, u, S, K, M string
+}
+
+var srpVectors = []srpVector{
+	{
+		// From RFC 5054, appendix B.
+		// K and M are not given there and were computed separately.
+		grp:  &rfc5054,
+		name: "alice",
+		pass: "password123",
+		salt: "beb25379d1a8581eb5a727673a2441ee",
+
+		k: "7556aa045aef2cdd07abaf0f665c3e818913186f",
+		x: "94b7555aabe9127cc58ccf4993db6cf84d16c124",
+		v: "7e273de8696ffc4f4e337d05b4b375beb0dde1569e8fa00a9886d8129bada1f1" +
+			"822223ca1a605b530e379ba4729fdc59f105b4787e5186f5c671085a1447b52a" +
+			"48cf1970b4fb6f8400bbf4cebfbb168152e08ab5ea53d15c1aff87b2b9da6e04" +
+			"e058ad51cc72bfc9033b564e26480d78e955a5e29e7ab245db2be315e2099afb",
+		A: "61d5e490f6f1b79547b0704c436f523dd0e560f0c64115bb72557ec44352e890" +
+			"3211c04692272d8b2d1a5358a2cf1b6e0bfcf99f921530ec8e39356179eae45e" +
+			"42ba92aeaced825171e1e8b9af6d9c03e1327f44be087ef06530e69f66615261" +
+			"eef54073ca11cf5858f0edfdfe15efeab349ef5d76988a3672fac47b0769447b",
+		B: "bd0c61512c692c0cb6d041fa01bb152d4916a1e77af46ae105393011baf38964" +
+			"dc46a0670dd125b95a981652236f99d9b681cbf87837ec996c6da04453728610" +
+			"d0c6ddb58b318885d7d82c7f8deb75ce7bd4fbaa37089e6f9c6059f388838e7a" +
+			"00030b331eb76840910440b1b27aaeaeeb4012b7d7665238a8e3fb004b117b58",
+		u: "ce38b9593487da98554ed47d70a7ae5f462ef019",
+		S: "b0dc82babcf30674ae450c0287745e7990a3381f63b387aaf271a10d233861e3" +
+			"59b48220f7c4693c9ae12b0a6f67809f0876e2d013800d6c41bb59b6d5979b5c" +
+			"00a172b4a2a5903a0bdcaf8a709585eb2afafa8f3499b200210dcc1f10eb3394" +
+			"3cd67fc88a2f39a4be5bec4ec0a3212dc346d7e474b29ede8a469ffeca686e5a",
+		K: "017eefa1cefc5c2e626e21598987f31e0f1b11bb",
+		M: "3f3bc67169ea71302599cf1b0f5d408b7b65d347",
+	},
+	{
+		// Minetest's group. These values were computed by this package,
+		// not by Minetest, so they only guard against regressions
+		// and don't show compatibility with Minetest.
+		// The mixed case name checks that only x uses the lowercase name.
+		grp:  &ng2048,
+		name: "Singleplayer",
+		pass: "hunter2",
+		salt: "000102030405060708090a0b0c0d0e0f",
+
+		k: "5b9e8ef059c6b32ea59fc1d322d37f04aa30bae5aa9003b8321e21ddb04e300",
+		x: "9924ca58e612e1782869f141919a77309652fde791627c51592eaa8da8aa4c4c",
+		v: "4caeb5a591e27d6cd28a84438d67057a77851428d4fe3a23edfda2c31804a71d" +
+			"7df6102ad0d8538641a35b821163f2b4cf9f4026ca5ad1a7e50d1729f9a50b29" +
+			"6ad0a4def02dd996bdf8b1519c7bf748e72d7f736dfbc604878486e1a19cc049" +
+			"2d3cf257a471e9f665e85114c532473ce57564cb37eea832dd28e9c9edae8c1c" +
+			"6aadc46a90e21f3078936843562c4004b5f75f7a06f7cecf7e0b35681fe387a6" +
+			"d7d216e41c20bcf5f1438fef73114f106e3b7ae779e9b5d58fd88c6148e28e8b" +
+			"ede571584aa996c81b97197b7a978a09b412d3e09f190c347d45544705d2b5c9" +
+			"a9b1b2a3c91408ddd85df7fd669e8b67dc21277696af37c00a4f1ba51059ec65",
+		A: "4b700f8d48e69c9aae40c684ac7c7c03121e2b7602eb4c3514804ccada0ed401" +
+			"9193a351ecc65a6f854ede91eb096e721b22d701c7adc64e9cedacd75f2e26bb" +
+			"2f5e45dd53dc8dbeafffe82aa49fca0573444691212537a73cf80e2503925820" +
+			"5a7edf4749b30adaf25877c62fcd09d6613598bcd4baf2a9727a53706a278148" +
+			"992b2abb23ad5d512d269e16ca11bc0895b5a3b5ec4721cde40a8c39c796e94f" +
+			"0be86dbbeb33da7037018983921aba3f5053195d5ac1da4e567e3c0e75d9e060" +
+			"9f92e850657b2be4771f415b9cacc5c1ecedc30133bf6474f5022c6519d78076" +
+			"0ca4d8d3b966b034bd73877c1b3b33f474b9c3c5299a1968f3e6cd3bfe84445a",
+		B: "2fa6fe06869601c2875b2a5cee6f22aea9540829be784012a2b5b34f0faed4ff" +
+			"8a9a6a214878c2f437e3b5561285fcfb9b7e8e8b8d5168b7065600081c93743e" +
+			"dad02fe586836ee0cb88035cd944eeec8d60cb04a38f8a57472fbfa020f2a32b" +
+			"21f17c2678a67449b7a62feef4343e8ddcc5f79ecdf501e0b6c2b0ee0f23d2b5" +
+			"a1c216b2b6d225ab99fdb350ea6e87e314c430e5b4aaa6a9ce7702b46505c477" +
+			"d6b5700004fd5ba3400ab18caf891d184e2fcc48f88d1bea2b016de5961271a5" +
+			"40afd7676871a452ae03475e0290267cf01bc53a665644886de0b063ebb75dcc" +
+			"3f1802e77eab296dc230bdb908ccc3104b5c3e86be0b1b269cd4d0532800cdaf",
+		u: "4d1a9c8d0b0f5a2ef5557a814c5df48117642c6089405bcd0da70f5b9552ab1c",
+		S: "1c0bd25e6f075fd19feedacee9cc7e6601b2b8b3f7dac6a4d261f7e0960bf8dd" +
+			"09d9327dbdc24605a9b1f235be35aca204aaf3ec915c433cca6a23f043cf1b72" +
+			"5e23d43319e90f3f176ae74bd6c5ff4fa7da8584e5430e3c2f57e3d0d6a03b8a" +
+			"98decd0648551a2d17ff3592492ae7dc0de61d29db1dd6490a75caeebed76f37" +
+			"53b19131df4b16312a4c1a2b8213ce18f243760b3bab6d351cea10a7266a18d2" +
+			"0befd1ced44e3abaf71d6a51e75cc780601c72eadf919a2286b6038326a7ece5" +
+			"255ea7960c47726b24cd64ef3fd980d21060bf2eb0f54bfb2eeb69d653a797b2" +
+			"16f44f1cd2d7631d653492aed9973324d4f3b9990105dab5a397db91d756f8fd",
+		K: "dcb724d85c03c8a3a70aef2249e906afc069f4496b47678fff9657adf7eaa8b0",
+		M: "f8c2861b092df880a609136c166cc236bc7876038550133dde81ee4e38fe12ae",
+	},
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestSRPVectors(t *testing.T) {
+	for _, vec := range srpVectors {
+		grp := vec.grp
+		salt := mustDecodeHex(vec.salt)
+
+		checkInt := func(what string, got *big.Int, want string) {
+			t.Helper()
+			if got.Cmp(mustHex(want)) != 0 {
+				t.Errorf("%s: %s: got %x, want %s", vec.name, what, got, want)
+			}
+		}
+		checkBytes := func(what string, got []byte, want string) {
+			t.Helper()
+			if !bytes.Equal(got, mustDecodeHex(want)) {
+				t.Errorf("%s: %s: got %x, want %s", vec.name, what, got, want)
+			}
+		}
+
+		checkInt("k", grp.k(), vec.k)
+		checkInt("x", grp.x(vec.name, vec.pass, salt), vec.x)
+		verifier := grp.verifier(vec.name, vec.pass, salt)
+		checkBytes("v", verifier, vec.v)
+
+		c := newClient(grp, vec.name, vec.pass, testA)
+		checkBytes("A", c.A(), vec.A)
+
+		s, err := newServer(grp, vec.name, salt, verifier, c.A(), testB)
+		if err != nil {
+			t.Fatalf("%s: %v", vec.name, err)
+		}
+		checkBytes("B", s.B(), vec.B)
+		checkInt("u", grp.hInt(grp.pad(c.pubA), grp.pad(s.pubB)), vec.u)
+		checkBytes("K", s.Key(), vec.K)
+
+		M, err := c.Proof(salt, s.B())
+		if err != nil {
+			t.Fatalf("%s: %v", vec.name, err)
+		}
+		checkBytes("M", M, vec.M)
+		checkBytes("client K", c.Key(), vec.K)
+
+		// K = H(S)
+		checkBytes("H(S)", grp.h(mustDecodeHex(vec.S)), vec.K)
+
+		if !s.Verify(M) {
+			t.Errorf("%s: server rejected valid proof", vec.name)
+		}
+	}
+}
+
+func TestSRPWrongPasswd(t *testing.T) {
+	salt, verifier, err := NewVerifier("Singleplayer", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pass := range []string{"hunter3", "Hunter2", ""} {
+		c, err := NewClient("Singleplayer", pass)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewServer("Singleplayer", salt, verifier, c.A())
+		if err != nil {
+			t.Fatal(err)
+		}
+		M, err := c.Proof(s.Salt(), s.B())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Verify(M) {
+			t.Errorf("server accepted password %q", pass)
+		}
+	}
+}
+
+func TestSRPInvalid(t *testing.T) {
+	c, err := NewClient("Singleplayer", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Proof(nil, ng2048.N.Bytes()); err != ErrSRP {
+		t.Errorf("B = N: got %v, want ErrSRP", err)
+	}
+	if _, err := NewServer("Singleplayer", nil, []byte{1}, nil); err != ErrSRP {
+		t.Errorf("A = 0: got %v, want ErrSRP", err)
+	}
+}
+
+func TestVerifierEncoding(t *testing.T) {
+	salt, verifier := []byte("salt"), []byte("verifier")
+	enc := EncodeVerifier(salt, verifier)
+	if want := "#1#c2FsdA==#dmVyaWZpZXI="; enc != want {
+		t.Errorf("got %q, want %q", enc, want)
+	}
+	if !IsVerifier(enc) {
+		t.Errorf("IsVerifier(%q) = false", enc)
+	}
+
+	gotSalt, gotVerifier, err := DecodeVerifier(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotSalt, salt) || !bytes.Equal(gotVerifier, verifier) {
+		t.Errorf("got %q, %q", gotSalt, gotVerifier)
+	}
+
+	for _, s := range []string{"", "#2#c2FsdA==#dmVyaWZpZXI=", "#1#c2FsdA==", "#1#!#!"} {
+		if _, _, err := DecodeVerifier(s); err != ErrVerifier {
+			t.Errorf("DecodeVerifier(%q): got %v, want ErrVerifier", s, err)
+		}
+	}
+}
+
+func TestLegacyPasswd(t *testing.T) {
+	if got, want := LegacyPasswd("Singleplayer", "hunter2"), "/HY5GdSKXUHjEr3n9zRRwEZl8tU="; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := LegacyPasswd("Singleplayer", ""); got != "" {
+		t.Errorf("empty password: got %q, want \"\"", got)
+	}
+}
+
+func login(t *testing.T, name, pass, stored string, method mt.AuthMethods) bool {
+	t.Helper()
+
+	c, bytesA, err := NewLogin(name, pass, method)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, saltB, err := ServeSRP(name, stored, bytesA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bytesM, err := c.BytesM(saltB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.Verify(bytesM.M) && bytes.Equal(c.Key(), s.Key())
+}
+
+func TestLogin(t *testing.T) {
+	first, err := FirstSRP("Singleplayer", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.EmptyPasswd {
+		t.Error("EmptyPasswd set for non-empty password")
+	}
+	stored := EncodeVerifier(first.Salt, first.Verifier)
+
+	if !login(t, "Singleplayer", "hunter2", stored, mt.SRP) {
+		t.Error("SRP: valid login rejected")
+	}
+	if login(t, "Singleplayer", "hunter3", stored, mt.SRP) {
+		t.Error("SRP: invalid login accepted")
+	}
+
+	legacy := LegacyPasswd("Singleplayer", "hunter2")
+	if !login(t, "Singleplayer", "hunter2", legacy, mt.LegacyPasswd) {
+		t.Error("legacy: valid login rejected")
+	}
+	if login(t, "Singleplayer", "hunter3", legacy, mt.LegacyPasswd) {
+		t.Error("legacy: invalid login accepted")
+	}
+	if !login(t, "Singleplayer", "", "", mt.LegacyPasswd) {
+		t.Error("legacy: empty password rejected")
+	}
+
+	_, bytesA, err := NewLogin("Singleplayer", "hunter2", mt.LegacyPasswd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ServeSRP("Singleplayer", stored, bytesA); err != ErrMethod {
+		t.Errorf("legacy login with verifier: got %v, want ErrMethod", err)
+	}
+}
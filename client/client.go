@@ -0,0 +1,395 @@
+// Package client implements the client side of joining a Minetest server.
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/anon55555/mt"
+	"github.com/anon55555/mt/auth"
+	"github.com/anon55555/mt/rudp"
+)
+
+// DefaultTimeout is the default time limit for joining,
+// after which Minetest gives up too.
+const DefaultTimeout = 10 * time.Second
+
+// initResend is how often ToSrvInit is sent until ToCltHello is received.
+const initResend = 500 * time.Millisecond
+
+// DefaultVersion is the ToSrvCltReady sent if Opts.Version is zero.
+var DefaultVersion = mt.ToSrvCltReady{
+	Major:    5,
+	Minor:    4,
+	Patch:    1,
+	Version:  "5.4.1",
+	Formspec: 4,
+}
+
+// Opts are optional parameters for Dial.
+type Opts struct {
+	// Config is used for the rudp.Conn.
+	Config rudp.Config
+
+	// Lang is sent in ToSrvInit2.
+	Lang string
+
+	// Timeout limits the time joining takes.
+	// If it is zero, DefaultTimeout is used.
+	Timeout time.Duration
+
+	// ReqMedia, if not nil, returns the names of the announced media files
+	// to request before joining. They are stored in Session.Media.
+	ReqMedia func(*mt.ToCltAnnounceMedia) []string
+
+	// Version is sent in ToSrvCltReady.
+	Version mt.ToSrvCltReady
+}
+
+// A Session is a Peer that has joined a server.
+// The fields hold what the server sent while joining.
+type Session struct {
+	mt.Peer
+
+	// Name is the player name as spelled by the server.
+	Name string
+
+	SerializeVer uint8
+
+	AcceptAuth mt.ToCltAcceptAuth
+
+	ItemDefs []mt.ItemDef
+	Aliases  map[string]string
+	NodeDefs []mt.NodeDef
+
+	AnnouncedMedia mt.ToCltAnnounceMedia
+	Media          map[string][]byte
+
+	Privs    map[string]bool
+	Movement mt.ToCltMovement
+	CSM      mt.ToCltCSMRestrictionFlags
+
+	items map[string]*mt.ItemDef
+	nodes map[mt.Content]*mt.NodeDef
+
+	mu      sync.Mutex
+	pending []mt.Pkt
+}
+
+// Dial connects to the server at addr and joins as user with pass.
+// opts may be nil.
+func Dial(addr, user, pass string, opts *Opts) (*Session, error) {
+	return DialContext(context.Background(), addr, user, pass, opts)
+}
+
+// DialContext is like Dial but gives up when ctx is done.
+func DialContext(ctx context.Context, addr, user, pass string, opts *Opts) (*Session, error) {
+	if opts == nil {
+		opts = &Opts{}
+	}
+
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return Join(ctx, mt.ConnectConfig(conn, opts.Config), user, pass, opts)
+}
+
+// Join is like DialContext but uses an existing Peer,
+// which is closed if joining fails.
+func Join(ctx context.Context, p mt.Peer, user, pass string, opts *Opts) (*Session, error) {
+	if opts == nil {
+		opts = &Opts{}
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := &Session{Peer: p, Name: user}
+	if err := s.join(ctx, pass, opts); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Session) join(ctx context.Context, pass string, opts *Opts) error {
+	if _, err := s.SendCmd(&mt.ToSrvNil{}); err != nil {
+		return err
+	}
+
+	init := &mt.ToSrvInit{
+		SerializeVer: mt.SerializeVer,
+		MinProtoVer:  mt.MinProtoVer,
+		MaxProtoVer:  mt.MaxProtoVer,
+		PlayerName:   s.Name,
+	}
+	var hello *mt.ToCltHello
+	for hello == nil {
+		if _, err := s.SendCmd(init); err != nil {
+			return err
+		}
+
+		rctx, cancel := context.WithTimeout(ctx, initResend)
+		err := s.await(rctx, func(cmd mt.Cmd) bool {
+			hello, _ = cmd.(*mt.ToCltHello)
+			return hello != nil
+		})
+		cancel()
+		if err != nil && !(errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil) {
+			return err
+		}
+	}
+
+	s.SerializeVer = hello.SerializeVer
+	if hello.Username != "" {
+		s.Name = hello.Username
+	}
+
+	if err := s.auth(ctx, hello.AuthMethods, pass); err != nil {
+		return err
+	}
+
+	if _, err := s.SendCmd(&mt.ToSrvInit2{Lang: opts.Lang}); err != nil {
+		return err
+	}
+
+	var itemDefs, nodeDefs, announced bool
+	if err := s.await(ctx, func(cmd mt.Cmd) bool {
+		switch cmd.(type) {
+		case *mt.ToCltItemDefs:
+			itemDefs = true
+		case *mt.ToCltNodeDefs:
+			nodeDefs = true
+		case *mt.ToCltAnnounceMedia:
+			announced = true
+		}
+		return itemDefs && nodeDefs && announced
+	}); err != nil {
+		return err
+	}
+
+	if err := s.reqMedia(ctx, opts.ReqMedia); err != nil {
+		return err
+	}
+
+	ready := opts.Version
+	if ready == (mt.ToSrvCltReady{}) {
+		ready = DefaultVersion
+	}
+	if _, err := s.SendCmd(&ready); err != nil {
+		return err
+	}
+
+	return s.await(ctx, func(cmd mt.Cmd) bool {
+		_, ok := cmd.(*mt.ToCltPrivs)
+		return ok
+	})
+}
+
+func (s *Session) auth(ctx context.Context, methods mt.AuthMethods, pass string) error {
+	switch {
+	case methods&mt.FirstSRP != 0:
+		cmd, err := auth.FirstSRP(s.Name, pass)
+		if err != nil {
+			return err
+		}
+		if _, err := s.SendCmd(cmd); err != nil {
+			return err
+		}
+	case methods&(mt.SRP|mt.LegacyPasswd) != 0:
+		method := mt.SRP
+		if methods&mt.SRP == 0 {
+			method = mt.LegacyPasswd
+		}
+
+		c, bytesA, err := auth.NewLogin(s.Name, pass, method)
+		if err != nil {
+			return err
+		}
+		if _, err := s.SendCmd(bytesA); err != nil {
+			return err
+		}
+
+		var saltB *mt.ToCltSRPBytesSaltB
+		if err := s.await(ctx, func(cmd mt.Cmd) bool {
+			saltB, _ = cmd.(*mt.ToCltSRPBytesSaltB)
+			return saltB != nil
+		}); err != nil {
+			return err
+		}
+
+		bytesM, err := c.BytesM(saltB)
+		if err != nil {
+			return err
+		}
+		if _, err := s.SendCmd(bytesM); err != nil {
+			return err
+		}
+	default:
+		return ErrAuthMethods
+	}
+
+	return s.await(ctx, func(cmd mt.Cmd) bool {
+		_, ok := cmd.(*mt.ToCltAcceptAuth)
+		return ok
+	})
+}
+
+func (s *Session) reqMedia(ctx context.Context, req func(*mt.ToCltAnnounceMedia) []string) error {
+	s.Media = make(map[string][]byte)
+	if req == nil {
+		return nil
+	}
+
+	names := req(&s.AnnouncedMedia)
+	if len(names) == 0 {
+		return nil
+	}
+	if _, err := s.SendCmd(&mt.ToSrvReqMedia{Filenames: names}); err != nil {
+		return err
+	}
+
+	n := 0
+	return s.await(ctx, func(cmd mt.Cmd) bool {
+		if cmd, ok := cmd.(*mt.ToCltMedia); ok {
+			n++
+			return n >= int(cmd.N)
+		}
+		return false
+	})
+}
+
+// await handles commands until done returns true.
+func (s *Session) await(ctx context.Context, done func(mt.Cmd) bool) error {
+	for {
+		cmd, err := s.next(ctx)
+		if err != nil {
+			return err
+		}
+		s.handle(cmd)
+		if done(cmd) {
+			return nil
+		}
+	}
+}
+
+// next returns the next command relevant to joining.
+// Other packets are queued for Recv.
+func (s *Session) next(ctx context.Context) (mt.Cmd, error) {
+	for {
+		pkt, err := s.Peer.RecvContext(ctx)
+		if err != nil {
+			var trailing rudp.TrailingDataError
+			if pkt.Cmd == nil || !errors.As(err, &trailing) {
+				if errors.Is(err, net.ErrClosed) {
+					if why := s.WhyClosed(); why != nil {
+						return nil, why
+					}
+				}
+				return nil, err
+			}
+		}
+
+		switch cmd := pkt.Cmd.(type) {
+		case *mt.ToCltKick:
+			return nil, &KickError{*cmd}
+		case *mt.ToCltLegacyKick:
+			return nil, &KickError{mt.ToCltKick{Reason: mt.Custom, Custom: cmd.Reason}}
+		case *mt.ToCltHello,
+			*mt.ToCltSRPBytesSaltB,
+			*mt.ToCltAcceptAuth,
+			*mt.ToCltItemDefs,
+			*mt.ToCltNodeDefs,
+			*mt.ToCltAnnounceMedia,
+			*mt.ToCltMedia,
+			*mt.ToCltPrivs,
+			*mt.ToCltMovement,
+			*mt.ToCltCSMRestrictionFlags:
+			return cmd, nil
+		}
+
+		s.mu.Lock()
+		s.pending = append(s.pending, pkt)
+		s.mu.Unlock()
+	}
+}
+
+// handle records cmd in the Session.
+func (s *Session) handle(cmd mt.Cmd) {
+	switch cmd := cmd.(type) {
+	case *mt.ToCltAcceptAuth:
+		s.AcceptAuth = *cmd
+	case *mt.ToCltItemDefs:
+		s.ItemDefs = cmd.Defs
+		s.Aliases = make(map[string]string)
+		s.items = make(map[string]*mt.ItemDef)
+		for _, a := range cmd.Aliases {
+			s.Aliases[a.Alias] = a.Orig
+		}
+		for i := range s.ItemDefs {
+			s.items[s.ItemDefs[i].Name] = &s.ItemDefs[i]
+		}
+	case *mt.ToCltNodeDefs:
+		s.NodeDefs = cmd.Defs
+		s.nodes = make(map[mt.Content]*mt.NodeDef)
+		for i := range s.NodeDefs {
+			s.nodes[s.NodeDefs[i].Param0] = &s.NodeDefs[i]
+		}
+	case *mt.ToCltAnnounceMedia:
+		s.AnnouncedMedia = *cmd
+	case *mt.ToCltMedia:
+		for _, f := range cmd.Files {
+			s.Media[f.Name] = f.Data
+		}
+	case *mt.ToCltPrivs:
+		s.Privs = make(map[string]bool)
+		for _, p := range cmd.Privs {
+			s.Privs[p] = true
+		}
+	case *mt.ToCltMovement:
+		s.Movement = *cmd
+	case *mt.ToCltCSMRestrictionFlags:
+		s.CSM = *cmd
+	}
+}
+
+// ItemDef returns the definition of the item name or nil.
+// Aliases are resolved.
+func (s *Session) ItemDef(name string) *mt.ItemDef {
+	if def, ok := s.items[name]; ok {
+		return def
+	}
+	return s.items[s.Aliases[name]]
+}
+
+// NodeDef returns the definition of the node c or nil.
+func (s *Session) NodeDef(c mt.Content) *mt.NodeDef {
+	return s.nodes[c]
+}
+
+func (s *Session) Recv() (mt.Pkt, error) {
+	return s.RecvContext(context.Background())
+}
+
+// RecvContext is like mt.Peer.RecvContext but first returns
+// the packets that were received while joining.
+func (s *Session) RecvContext(ctx context.Context) (mt.Pkt, error) {
+	s.mu.Lock()
+	if len(s.pending) > 0 {
+		pkt := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+		return pkt, nil
+	}
+	s.mu.Unlock()
+
+	return s.Peer.RecvContext(ctx)
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anon55555/mt"
+	"github.com/anon55555/mt/auth"
+	"github.com/anon55555/mt/rudp"
+)
+
+// fakeSrv accepts one client and joins it like a Minetest server would,
+// with a chat message sent in between.
+func fakeSrv(t *testing.T, l mt.Listener, passwd string) {
+	srv, err := l.Accept()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer srv.Close()
+
+	recv := func() mt.Cmd {
+		for {
+			pkt, err := srv.Recv()
+			if err != nil {
+				t.Error(err)
+				return nil
+			}
+			switch pkt.Cmd.(type) {
+			case *mt.ToSrvNil, *mt.ToSrvInit:
+				continue
+			}
+			return pkt.Cmd
+		}
+	}
+
+	srv.SendCmd(&mt.ToCltHello{
+		SerializeVer: mt.SerializeVer,
+		ProtoVer:     mt.MaxProtoVer,
+		AuthMethods:  mt.SRP,
+		Username:     "Singleplayer",
+	})
+
+	bytesA, ok := recv().(*mt.ToSrvSRPBytesA)
+	if !ok {
+		t.Error("expected ToSrvSRPBytesA")
+		return
+	}
+	s, saltB, err := auth.ServeSRP("Singleplayer", passwd, bytesA)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	srv.SendCmd(saltB)
+
+	bytesM, ok := recv().(*mt.ToSrvSRPBytesM)
+	if !ok {
+		t.Error("expected ToSrvSRPBytesM")
+		return
+	}
+	if !s.Verify(bytesM.M) {
+		ack, _ := srv.SendCmd(&mt.ToCltKick{Reason: mt.WrongPasswd})
+		<-ack
+		return
+	}
+	srv.SendCmd(&mt.ToCltAcceptAuth{MapSeed: 42})
+
+	if _, ok := recv().(*mt.ToSrvInit2); !ok {
+		t.Error("expected ToSrvInit2")
+		return
+	}
+	srv.SendCmd(&mt.ToCltItemDefs{
+		Defs:    []mt.ItemDef{{Name: "default:stone"}},
+		Aliases: []struct{ Alias, Orig string }{{"stone", "default:stone"}},
+	})
+	srv.SendCmd(&mt.ToCltNodeDefs{
+		Defs: []mt.NodeDef{{Param0: 1, Name: "default:stone"}},
+	})
+	srv.SendCmd(&mt.ToCltChatMsg{Text: "hi"})
+	srv.SendCmd(&mt.ToCltAnnounceMedia{})
+	srv.SendCmd(&mt.ToCltMovement{Gravity: 9.81})
+	srv.SendCmd(&mt.ToCltCSMRestrictionFlags{Flags: mt.NoCSMs})
+
+	if _, ok := recv().(*mt.ToSrvCltReady); !ok {
+		t.Error("expected ToSrvCltReady")
+		return
+	}
+	ack, _ := srv.SendCmd(&mt.ToCltPrivs{Privs: []string{"interact", "shout"}})
+	<-ack
+}
+
+func join(t *testing.T, pass string) (*Session, error) {
+	rl, dial := rudp.Pipe(rudp.Config{}, rudp.Config{}, rudp.PipeConfig{})
+	l := mt.Listener{Listener: rl}
+	defer l.Close()
+
+	salt, verifier, err := auth.NewVerifier("Singleplayer", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fakeSrv(t, l, auth.EncodeVerifier(salt, verifier))
+	}()
+	defer func() { <-done }()
+
+	return Join(context.Background(), mt.NewPeer(dial()), "singleplayer", pass, nil)
+}
+
+func TestJoin(t *testing.T) {
+	s, err := join(t, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if s.Name != "Singleplayer" {
+		t.Errorf("Name: got %q", s.Name)
+	}
+	if s.AcceptAuth.MapSeed != 42 {
+		t.Errorf("MapSeed: got %d", s.AcceptAuth.MapSeed)
+	}
+	if def := s.ItemDef("stone"); def == nil || def.Name != "default:stone" {
+		t.Errorf("ItemDef: got %v", def)
+	}
+	if def := s.NodeDef(1); def == nil || def.Name != "default:stone" {
+		t.Errorf("NodeDef: got %v", def)
+	}
+	if s.Movement.Gravity != 9.81 || s.CSM.Flags != mt.NoCSMs {
+		t.Errorf("got %v, %v", s.Movement, s.CSM)
+	}
+	if !s.Privs["interact"] || !s.Privs["shout"] || len(s.Privs) != 2 {
+		t.Errorf("Privs: got %v", s.Privs)
+	}
+
+	pkt, err := s.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := pkt.Cmd.(*mt.ToCltChatMsg); !ok || msg.Text != "hi" {
+		t.Errorf("got %#v, want queued chat message", pkt.Cmd)
+	}
+}
+
+func TestJoinWrongPasswd(t *testing.T) {
+	_, err := join(t, "hunter3")
+	if !errors.Is(err, ErrWrongPasswd) {
+		t.Fatalf("got %v, want ErrWrongPasswd", err)
+	}
+	if errors.Is(err, ErrCustom) {
+		t.Error("WrongPasswd kick matches ErrCustom")
+	}
+}
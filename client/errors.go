@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/anon55555/mt"
+)
+
+// ErrAuthMethods is returned by Dial if the server
+// offers no supported auth method.
+var ErrAuthMethods = errors.New("no supported auth method")
+
+// A KickError is returned by Dial if the server kicks the client.
+// It matches the Err variable of its reason, e.g.
+//
+//	errors.Is(err, ErrWrongPasswd)
+type KickError struct {
+	mt.ToCltKick
+}
+
+func (e *KickError) Error() string { return "kicked: " + e.ToCltKick.String() }
+
+func (e *KickError) Is(target error) bool {
+	t, ok := target.(*KickError)
+	return ok && *t == KickError{mt.ToCltKick{Reason: e.Reason}}
+}
+
+var (
+	ErrWrongPasswd       = &KickError{mt.ToCltKick{Reason: mt.WrongPasswd}}
+	ErrUnexpectedData    = &KickError{mt.ToCltKick{Reason: mt.UnexpectedData}}
+	ErrSrvIsSingleplayer = &KickError{mt.ToCltKick{Reason: mt.SrvIsSingleplayer}}
+	ErrUnsupportedVer    = &KickError{mt.ToCltKick{Reason: mt.UnsupportedVer}}
+	ErrBadNameChars      = &KickError{mt.ToCltKick{Reason: mt.BadNameChars}}
+	ErrBadName           = &KickError{mt.ToCltKick{Reason: mt.BadName}}
+	ErrTooManyClts       = &KickError{mt.ToCltKick{Reason: mt.TooManyClts}}
+	ErrEmptyPasswd       = &KickError{mt.ToCltKick{Reason: mt.EmptyPasswd}}
+	ErrAlreadyConnected  = &KickError{mt.ToCltKick{Reason: mt.AlreadyConnected}}
+	ErrSrvErr            = &KickError{mt.ToCltKick{Reason: mt.SrvErr}}
+	ErrCustom            = &KickError{mt.ToCltKick{Reason: mt.Custom}}
+	ErrShutdown          = &KickError{mt.ToCltKick{Reason: mt.Shutdown}}
+	ErrCrash             = &KickError{mt.ToCltKick{Reason: mt.Crash}}
+)
@@ -80,6 +80,7 @@ func (*ToSrvNodeMetaFields) cmd() {}
 func (*ToSrvInvFields) cmd()      {}
 func (*ToSrvReqMedia) cmd()       {}
 func (*ToSrvCltReady) cmd()       {}
+func (*ToSrvHaveMedia) cmd()      {}
 func (*ToSrvFirstSRP) cmd()       {}
 func (*ToSrvSRPBytesA) cmd()      {}
 func (*ToSrvSRPBytesM) cmd()      {}
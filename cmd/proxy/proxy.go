@@ -3,40 +3,82 @@ Proxy is a Minetest proxy server
 supporting multiple concurrent connections.
 
 Usage:
-	proxy dial:port listen:port
+	proxy [-http addr] [-rate bytes] [-pcap file] dial:port listen:port...
 where dial:port is the server address
-and listen:port is the address to listen on.
+and listen:port are the addresses to listen on,
+e.g. 0.0.0.0:30000 and [::]:30000 for IPv4 and IPv6.
+If -http is given, statistics are served at http://addr/debug/vars.
+If -rate is given, each connection sends at most that many bytes per second.
+If -pcap is given, all UDP packets are captured to that file.
 */
 package main
 
 import (
 	"errors"
+	"expvar"
+	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 
 	"github.com/anon55555/mt"
+	"github.com/anon55555/mt/rudp"
 )
 
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Fprintln(os.Stderr, "usage: proxy dial:port listen:port")
+	httpAddr := flag.String("http", "", "serve statistics on this address")
+	rate := flag.Int("rate", 0, "limit each connection to this many bytes per second")
+	pcap := flag.String("pcap", "", "capture UDP packets to this file")
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		fmt.Fprintln(os.Stderr, "usage: proxy [-http addr] [-rate bytes] [-pcap file] dial:port listen:port...")
 		os.Exit(1)
 	}
 
-	srvaddr, err := net.ResolveUDPAddr("udp", os.Args[1])
+	srvaddr, err := net.ResolveUDPAddr("udp", flag.Arg(0))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	lc, err := net.ListenPacket("udp", os.Args[2])
-	if err != nil {
-		log.Fatal(err)
+	var lcs []net.PacketConn
+	for _, addr := range flag.Args()[1:] {
+		lc, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer lc.Close()
+
+		lcs = append(lcs, lc)
+	}
+
+	cfg := rudp.Config{MaxBytesPerSec: *rate}
+
+	if *pcap != "" {
+		f, err := os.Create(*pcap)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		pw, err := rudp.NewPcapWriter(f)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Tap = pw.Tap
+	}
+
+	l := mt.ListenMulti(cfg, lcs...)
+
+	if *httpAddr != "" {
+		expvar.Publish("clts", l.Var())
+		go func() {
+			log.Fatal(http.ListenAndServe(*httpAddr, nil))
+		}()
 	}
-	defer lc.Close()
 
-	l := mt.Listen(lc)
 	for {
 		clt, err := l.Accept()
 		if err != nil {
@@ -51,7 +93,7 @@ func main() {
 			log.Print(err)
 			continue
 		}
-		srv := mt.Connect(conn)
+		srv := mt.ConnectConfig(conn, cfg)
 
 		go proxy(clt, srv)
 		go proxy(srv, clt)
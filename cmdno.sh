@@ -10,4 +10,11 @@
 	echo "var $6 = map[$4]func() $5{"
 	awk '{ print "\t"$1": func() '$5' { return new('$2'"$2") }," }' $1
 	echo }
+	if awk 'NF > 2 { found = 1 } END { exit !found }' $1; then
+		echo
+		echo "// $3CmdMinVer maps cmd numbers to the protocol versions that added them."
+		echo "var $3CmdMinVer = map[$4]uint16{"
+		awk 'NF > 2 { print "\t"$1": "$3"," }' $1
+		echo }
+	fi
 ) | gofmt >$1_cmdno.go
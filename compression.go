@@ -1,7 +1,14 @@
 package mt
 
+// CompressionModes are the compression modes a client supports (ToSrvInit)
+// or the server has chosen (ToCltHello).
+// Minetest does not negotiate compression and sends 0.
 type CompressionModes uint16
 
 const (
-	_ CompressionModes = 1 << iota
+	// ZstdCompression is zstd compression as implemented by package zstd
+	// and used by the //mt:zstd serialization directive.
+	// No Minetest version advertises it,
+	// so it must only be used between peers that both do.
+	ZstdCompression CompressionModes = 1 << iota
 )
@@ -0,0 +1,387 @@
+package mt
+
+import (
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/anon55555/mt/zstd"
+)
+
+// The versions of the MapBlk disk format supported by DiskMapBlk.
+const (
+	MinDiskMapBlkVer = 28 // Minetest 5.0 to 5.4
+	MaxDiskMapBlkVer = 29 // Minetest 5.5
+)
+
+// NoTimestamp is the Timestamp of a DiskMapBlk that has never been active.
+const NoTimestamp = 0xffffffff
+
+// A DiskMapBlk is a MapBlk as stored in a world database.
+// Unlike in ToCltBlkData, the Contents in Param0 are local to the MapBlk
+// and mapped to node names by NodeNames.
+type DiskMapBlk struct {
+	MapBlk
+
+	NodeNames map[Content]string
+
+	StaticObjs []StaticObj
+
+	// Timestamp is the game time in seconds at which
+	// the MapBlk was last active.
+	Timestamp uint32
+
+	NodeTimers map[uint16]NodeTimer
+}
+
+// A StaticObj is an active object stored in a MapBlk while it is inactive.
+type StaticObj struct {
+	Type uint8 // 7 for Lua entities.
+	Pos
+	Data string
+}
+
+// A NodeTimer is the timer of the node at an index in a MapBlk.
+type NodeTimer struct {
+	Timeout, Elapsed float32 // in seconds.
+}
+
+// NewDiskMapBlk converts blk to a DiskMapBlk.
+// names maps the Contents in blk to node names, e.g. as in ToCltNodeDefs.
+// Unknown, Air and Ignore need not be in names.
+// Contents without a name are named "unknown".
+func NewDiskMapBlk(blk MapBlk, names map[Content]string) *DiskMapBlk {
+	b := &DiskMapBlk{
+		MapBlk:    blk,
+		NodeNames: make(map[Content]string),
+		Timestamp: NoTimestamp,
+	}
+
+	// Like Minetest, number the Contents in order of appearance.
+	ids := make(map[Content]Content)
+	for i, c := range blk.Param0 {
+		id, ok := ids[c]
+		if !ok {
+			id = Content(len(ids))
+			ids[c] = id
+
+			name, ok := names[c]
+			if !ok {
+				name = builtinNodeName(c)
+			}
+			b.NodeNames[id] = name
+		}
+		b.Param0[i] = id
+	}
+
+	return b
+}
+
+// NetMapBlk converts b to a MapBlk as sent in ToCltBlkData.
+// ids maps node names to Contents, e.g. as in ToCltNodeDefs.
+// "unknown", "air" and "ignore" need not be in ids.
+// Nodes with a name that is not in ids become Unknown.
+// Private NodeMetaFields are left out.
+func (b *DiskMapBlk) NetMapBlk(ids map[string]Content) MapBlk {
+	blk := b.MapBlk
+
+	for i, id := range blk.Param0 {
+		name := b.NodeNames[id]
+		c, ok := ids[name]
+		if !ok {
+			c = builtinContent(name)
+		}
+		blk.Param0[i] = c
+	}
+
+	if b.NodeMetas != nil {
+		blk.NodeMetas = make(map[uint16]*NodeMeta, len(b.NodeMetas))
+		for i, nm := range b.NodeMetas {
+			pub := &NodeMeta{Inv: nm.Inv}
+			for _, f := range nm.Fields {
+				if !f.Private {
+					pub.Fields = append(pub.Fields, f)
+				}
+			}
+			blk.NodeMetas[i] = pub
+		}
+	}
+
+	return blk
+}
+
+func builtinNodeName(c Content) string {
+	switch c {
+	case Air:
+		return "air"
+	case Ignore:
+		return "ignore"
+	default:
+		return "unknown"
+	}
+}
+
+func builtinContent(name string) Content {
+	switch name {
+	case "air":
+		return Air
+	case "ignore":
+		return Ignore
+	default:
+		return Unknown
+	}
+}
+
+// Serialize writes b in the disk format version ver.
+func (b *DiskMapBlk) Serialize(w io.Writer, ver uint8) error {
+	if ver < MinDiskMapBlkVer || ver > MaxDiskMapBlkVer {
+		return fmt.Errorf("unsupported mapblk disk version: %d", ver)
+	}
+	if _, err := w.Write([]byte{ver}); err != nil {
+		return err
+	}
+
+	return pcall(func() {
+		if ver >= 29 {
+			// The rest of the MapBlk is compressed using zstd.
+			w := zstd.NewWriter(w)
+			b.serialize(w, ver)
+			chk(w.Close())
+		} else {
+			b.serialize(w, ver)
+		}
+	})
+}
+
+func (b *DiskMapBlk) serialize(w io.Writer, ver uint8) {
+	put := func(data interface{}) {
+		chk(binary.Write(w, be, data))
+	}
+	putStr := func(s string) {
+		if len(s) > math.MaxUint16 {
+			chk(ErrTooLong)
+		}
+		put(uint16(len(s)))
+		_, err := io.WriteString(w, s)
+		chk(err)
+	}
+	putF1000 := func(f float32) {
+		put(int32(f * 1000))
+	}
+	// Before version 29, the node data and NodeMetas
+	// are compressed individually using zlib.
+	compressed := func(f func(w io.Writer)) {
+		if ver >= 29 {
+			f(w)
+			return
+		}
+
+		zw := zlib.NewWriter(w)
+		f(zw)
+		chk(zw.Close())
+	}
+	putNodeNames := func() {
+		ids := make([]Content, 0, len(b.NodeNames))
+		for id := range b.NodeNames {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		if len(ids) > math.MaxUint16 {
+			chk(ErrTooLong)
+		}
+		put(uint8(0)) // version
+		put(uint16(len(ids)))
+		for _, id := range ids {
+			put(id)
+			putStr(b.NodeNames[id])
+		}
+	}
+
+	put(b.Flags)
+	put(b.LitFrom)
+	if ver >= 29 {
+		put(b.Timestamp)
+		putNodeNames()
+	}
+
+	put(uint8(2))     // Size of param0 in bytes.
+	put(uint8(1 + 1)) // Size of param1 and param2 combined, in bytes.
+	compressed(func(w io.Writer) {
+		chk(binary.Write(w, be, &b.Param0))
+		chk(binary.Write(w, be, &b.Param1))
+		chk(binary.Write(w, be, &b.Param2))
+	})
+
+	compressed(func(w io.Writer) {
+		writeNodeMetas(w, b.NodeMetas, MaxProtoVer)
+	})
+
+	if len(b.StaticObjs) > math.MaxUint16 {
+		chk(ErrTooLong)
+	}
+	put(uint8(0)) // version
+	put(uint16(len(b.StaticObjs)))
+	for _, obj := range b.StaticObjs {
+		put(obj.Type)
+		for _, f := range obj.Pos {
+			putF1000(f)
+		}
+		putStr(obj.Data)
+	}
+
+	if ver < 29 {
+		put(b.Timestamp)
+		putNodeNames()
+	}
+
+	idxs := make([]uint16, 0, len(b.NodeTimers))
+	for i := range b.NodeTimers {
+		idxs = append(idxs, i)
+	}
+	sort.Slice(idxs, func(i, j int) bool { return idxs[i] < idxs[j] })
+
+	put(uint8(2 + 4 + 4)) // Size of a NodeTimer in bytes.
+	put(uint16(len(idxs)))
+	for _, i := range idxs {
+		put(i)
+		putF1000(b.NodeTimers[i].Timeout)
+		putF1000(b.NodeTimers[i].Elapsed)
+	}
+}
+
+// Deserialize reads b in any supported disk format version.
+func (b *DiskMapBlk) Deserialize(r io.Reader) error {
+	buf := make([]byte, 1)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+	ver := buf[0]
+	if ver < MinDiskMapBlkVer || ver > MaxDiskMapBlkVer {
+		return fmt.Errorf("unsupported mapblk disk version: %d", ver)
+	}
+
+	*b = DiskMapBlk{}
+	return pcall(func() {
+		if ver >= 29 {
+			r, err := zstd.NewReader(r)
+			chk(err)
+			b.deserialize(r, ver)
+			chk(r.Close())
+		} else {
+			b.deserialize(r, ver)
+		}
+	})
+}
+
+func (b *DiskMapBlk) deserialize(r io.Reader, ver uint8) {
+	get := func(data interface{}) {
+		chk(binary.Read(r, be, data))
+	}
+	getStr := func() string {
+		var n uint16
+		get(&n)
+		buf := make([]byte, n)
+		_, err := io.ReadFull(r, buf)
+		chk(err)
+		return string(buf)
+	}
+	getF1000 := func() float32 {
+		var n int32
+		get(&n)
+		return float32(n) / 1000
+	}
+	compressed := func(f func(r io.Reader)) {
+		if ver >= 29 {
+			f(r)
+			return
+		}
+
+		zr, err := zlib.NewReader(byteReader{r})
+		chk(err)
+		f(zr)
+		// Read the checksum.
+		_, err = io.Copy(io.Discard, zr)
+		chk(err)
+		chk(zr.Close())
+	}
+	getNodeNames := func() {
+		var mapVer uint8
+		get(&mapVer)
+		if mapVer != 0 {
+			chk(fmt.Errorf("unsupported node name mapping version: %d", mapVer))
+		}
+
+		var n uint16
+		get(&n)
+		b.NodeNames = make(map[Content]string, n)
+		for ; n > 0; n-- {
+			var id Content
+			get(&id)
+			b.NodeNames[id] = getStr()
+		}
+	}
+
+	get(&b.Flags)
+	get(&b.LitFrom)
+	if ver >= 29 {
+		get(&b.Timestamp)
+		getNodeNames()
+	}
+
+	widths := make([]uint8, 2)
+	get(widths)
+	if widths[0] != 2 || widths[1] != 1+1 {
+		chk(fmt.Errorf("unsupported param widths: %d, %d", widths[0], widths[1]))
+	}
+	compressed(func(r io.Reader) {
+		chk(binary.Read(r, be, &b.Param0))
+		chk(binary.Read(r, be, &b.Param1))
+		chk(binary.Read(r, be, &b.Param2))
+	})
+
+	compressed(func(r io.Reader) {
+		b.NodeMetas = readNodeMetas(r, MaxProtoVer)
+	})
+
+	var objsVer uint8
+	get(&objsVer)
+	if objsVer != 0 {
+		chk(fmt.Errorf("unsupported static objects version: %d", objsVer))
+	}
+	var n uint16
+	get(&n)
+	b.StaticObjs = make([]StaticObj, n)
+	for i := range b.StaticObjs {
+		obj := &b.StaticObjs[i]
+		get(&obj.Type)
+		for j := range obj.Pos {
+			obj.Pos[j] = getF1000()
+		}
+		obj.Data = getStr()
+	}
+
+	if ver < 29 {
+		get(&b.Timestamp)
+		getNodeNames()
+	}
+
+	var size uint8
+	get(&size)
+	if size != 2+4+4 {
+		chk(fmt.Errorf("unsupported node timer size: %d", size))
+	}
+	get(&n)
+	b.NodeTimers = make(map[uint16]NodeTimer, n)
+	for ; n > 0; n-- {
+		var i uint16
+		get(&i)
+		b.NodeTimers[i] = NodeTimer{
+			Timeout: getF1000(),
+			Elapsed: getF1000(),
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package mt
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/anon55555/mt/zstd"
+)
+
+// fixtureBlk is the MapBlk in the fixtures:
+// a layer of stone with a chest on it, air above,
+// a dropped item and a running node timer.
+func fixtureBlk() *DiskMapBlk {
+	b := &DiskMapBlk{
+		NodeNames: map[Content]string{0: "default:stone", 1: "air", 2: "default:chest"},
+		StaticObjs: []StaticObj{
+			{Type: 7, Pos: Pos{12.5, 10, -3}, Data: "\x01\x00\x0b__builtin:item"},
+		},
+		Timestamp:  1234,
+		NodeTimers: map[uint16]NodeTimer{0x111: {Timeout: 5, Elapsed: 1.5}},
+	}
+	b.Flags = BlkIsUnderground
+	b.LitFrom = AlwaysLitFrom
+	for i := range b.Param0 {
+		if i>>4&0xf > 0 { // y > 0
+			b.Param0[i] = 1
+			b.Param1[i] = 15
+		}
+	}
+	b.Param0[0x111] = 2
+	b.Param1[0x111] = 0
+	b.Param2[0x111] = 3
+	b.NodeMetas = map[uint16]*NodeMeta{
+		0x111: {Fields: []NodeMetaField{
+			{Field: Field{Name: "infotext", Value: "Chest"}},
+			{Field: Field{Name: "owner", Value: "singleplayer"}, Private: true},
+		}},
+	}
+	return b
+}
+
+// fixture returns fixtureBlk encoded by hand
+// like Minetest's MapBlock::serialize does for disk.
+// For version 29, the part after the version byte is not compressed.
+func fixture(ver uint8) []byte {
+	b := new(bytes.Buffer)
+	put := func(data ...interface{}) {
+		for _, x := range data {
+			binary.Write(b, binary.BigEndian, x)
+		}
+	}
+	putStr := func(s string) {
+		put(uint16(len(s)))
+		b.WriteString(s)
+	}
+	compressed := func(f func()) {
+		if ver >= 29 {
+			f()
+			return
+		}
+
+		raw := b
+		b = new(bytes.Buffer)
+		f()
+		zw := zlib.NewWriter(raw)
+		zw.Write(b.Bytes())
+		zw.Close()
+		b = raw
+	}
+	names := func() {
+		put(uint8(0), uint16(3))
+		for i, name := range []string{"default:stone", "air", "default:chest"} {
+			put(uint16(i))
+			putStr(name)
+		}
+	}
+
+	put(ver, uint8(BlkIsUnderground), uint16(AlwaysLitFrom))
+	if ver >= 29 {
+		put(uint32(1234))
+		names()
+	}
+	put(uint8(2), uint8(2))
+
+	blk := fixtureBlk()
+	compressed(func() {
+		put(blk.Param0, blk.Param1, blk.Param2)
+	})
+	compressed(func() {
+		put(uint8(2), uint16(1), uint16(0x111))
+		put(uint32(2))
+		putStr("infotext")
+		put(uint32(len("Chest")))
+		b.WriteString("Chest")
+		put(uint8(0))
+		putStr("owner")
+		put(uint32(len("singleplayer")))
+		b.WriteString("singleplayer")
+		put(uint8(1))
+		b.WriteString("EndInventory\n")
+	})
+
+	put(uint8(0), uint16(1))
+	put(uint8(7), int32(12500), int32(10000), int32(-3000))
+	putStr("\x01\x00\x0b__builtin:item")
+
+	if ver < 29 {
+		put(uint32(1234))
+		names()
+	}
+
+	put(uint8(10), uint16(1))
+	put(uint16(0x111), int32(5000), int32(1500))
+
+	return b.Bytes()
+}
+
+func TestDiskMapBlkFixtures(t *testing.T) {
+	v29, err := os.ReadFile("testdata/diskmapblk29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The testdata was compressed using the zstd CLI.
+	zr, err := zstd.NewReader(bytes.NewReader(v29[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := new(bytes.Buffer)
+	if _, err := body.ReadFrom(zr); err != nil {
+		t.Fatal(err)
+	}
+	if want := fixture(29); v29[0] != 29 || !bytes.Equal(body.Bytes(), want[1:]) {
+		t.Fatal("testdata/diskmapblk29 does not contain fixture(29)")
+	}
+
+	for _, tc := range []struct {
+		ver  uint8
+		data []byte
+	}{
+		{28, fixture(28)},
+		{29, v29},
+	} {
+		var b DiskMapBlk
+		if err := b.Deserialize(bytes.NewReader(tc.data)); err != nil {
+			t.Fatalf("version %d: %v", tc.ver, err)
+		}
+		if !reflect.DeepEqual(&b, fixtureBlk()) {
+			t.Errorf("version %d: got %+v", tc.ver, b)
+		}
+
+		var out bytes.Buffer
+		if err := b.Serialize(&out, tc.ver); err != nil {
+			t.Fatalf("version %d: %v", tc.ver, err)
+		}
+		if tc.ver < 29 && !bytes.Equal(out.Bytes(), tc.data) {
+			t.Errorf("version %d: Serialize does not match the fixture", tc.ver)
+		}
+	}
+}
+
+func TestDiskMapBlk(t *testing.T) {
+	var blk MapBlk
+	for i := range blk.Param0 {
+		blk.Param0[i] = Air
+	}
+	blk.Param0[5] = 42
+	blk.Param0[6] = 7
+	blk.Param0[7] = 42
+	blk.NodeMetas = map[uint16]*NodeMeta{5: {Fields: []NodeMetaField{
+		{Field: Field{Name: "a", Value: "1"}},
+		{Field: Field{Name: "b", Value: "2"}, Private: true},
+	}}}
+
+	b := NewDiskMapBlk(blk, map[Content]string{42: "default:dirt"})
+	if want := map[Content]string{0: "air", 1: "default:dirt", 2: "unknown"}; !reflect.DeepEqual(b.NodeNames, want) {
+		t.Errorf("NodeNames: got %v, want %v", b.NodeNames, want)
+	}
+	if got := b.Param0[4:8]; !reflect.DeepEqual(got, []Content{0, 1, 2, 1}) {
+		t.Errorf("Param0: got %v", got)
+	}
+	if b.Timestamp != NoTimestamp {
+		t.Errorf("Timestamp is %d, want NoTimestamp", b.Timestamp)
+	}
+	b.StaticObjs = []StaticObj{{Type: 7, Pos: Pos{1, 2, 3}, Data: "x"}}
+	b.NodeTimers = map[uint16]NodeTimer{5: {Timeout: 2, Elapsed: 0.5}, 1: {Timeout: 1}}
+
+	for _, ver := range []uint8{28, 29} {
+		var buf bytes.Buffer
+		if err := b.Serialize(&buf, ver); err != nil {
+			t.Fatal(err)
+		}
+		buf.WriteString("next")
+
+		var got DiskMapBlk
+		if err := got.Deserialize(&buf); err != nil {
+			t.Fatalf("version %d: %v", ver, err)
+		}
+		if !reflect.DeepEqual(&got, b) {
+			t.Errorf("version %d: got %+v", ver, got)
+		}
+		if buf.String() != "next" {
+			t.Errorf("version %d: read past the MapBlk", ver)
+		}
+	}
+
+	net := b.NetMapBlk(map[string]Content{"default:dirt": 42})
+	if got := net.Param0[4:8]; !reflect.DeepEqual(got, []Content{Air, 42, Unknown, 42}) {
+		t.Errorf("NetMapBlk Param0: got %v", got)
+	}
+	if fields := net.NodeMetas[5].Fields; len(fields) != 1 || fields[0].Name != "a" {
+		t.Errorf("NetMapBlk NodeMeta fields: got %v", fields)
+	}
+	if len(b.NodeMetas[5].Fields) != 2 {
+		t.Error("NetMapBlk modified the DiskMapBlk")
+	}
+
+	if err := b.Serialize(new(bytes.Buffer), 27); err == nil {
+		t.Error("no error for unsupported version")
+	}
+}
+
+func TestWriteNodeMetas(t *testing.T) {
+	for _, metas := range []map[uint16]*NodeMeta{nil, {}} {
+		var b bytes.Buffer
+		if err := pcall(func() { writeNodeMetas(&b, metas, MaxProtoVer) }); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.Bytes(), []byte{0}) {
+			t.Errorf("%#v: got %x, want 00", metas, b.Bytes())
+		}
+	}
+}
@@ -78,6 +78,18 @@ func structPragma(c *ast.Comment, sp *[]func(), expr string, de bool) {
 				fmt.Println("chk(w.Close()) }")
 			})
 		}
+	case "zstd":
+		if de {
+			fmt.Println("{ r, err := zstd.NewReader(r); chk(err)")
+			*sp = append(*sp, func() {
+				fmt.Println("chk(r.Close()) }")
+			})
+		} else {
+			fmt.Println("{ w := zstd.NewWriter(w)")
+			*sp = append(*sp, func() {
+				fmt.Println("chk(w.Close()) }")
+			})
+		}
 	case "lenhdr":
 		if arg != "8" && arg != "16" && arg != "32" {
 			error(c.Pos(), "usage: //mt:lenhdr (8|16|32)")
@@ -126,7 +138,8 @@ func structPragma(c *ast.Comment, sp *[]func(), expr string, de bool) {
 		(*sp)[len(*sp)-1]()
 		*sp = (*sp)[:len(*sp)-1]
 	case "if":
-		fmt.Printf(strings.TrimPrefix(c.Text, "//mt:")+" {\n", expr)
+		// %s is replaced by the struct, ver is the protocol version.
+		fmt.Println(strings.ReplaceAll(strings.TrimPrefix(c.Text, "//mt:"), "%s", expr), "{")
 		*sp = append(*sp, func() {
 			fmt.Println("}")
 		})
@@ -248,9 +261,9 @@ func genSerialize(t types.Type, expr string, pos token.Pos, doc *ast.CommentGrou
 
 		fmt.Println("if err := pcall(func() {")
 		if de {
-			fmt.Println(expr + ".deserialize(r)")
+			fmt.Println(expr + ".deserialize(r, ver)")
 		} else {
-			fmt.Println(expr + ".serialize(w)")
+			fmt.Println(expr + ".serialize(w, ver)")
 		}
 		fmt.Println("}); err != nil",
 			`{`,
@@ -495,6 +508,7 @@ var typeNames = []string{
 	"ToSrvInvFields",
 	"ToSrvReqMedia",
 	"ToSrvCltReady",
+	"ToSrvHaveMedia",
 	"ToSrvFirstSRP",
 	"ToSrvSRPBytesA",
 	"ToSrvSRPBytesM",
@@ -630,9 +644,9 @@ func main() {
 	for i := 0; i < len(serialize); i++ {
 		for _, de := range []bool{false, true} {
 			t := serialize[i]
-			sig := "serialize(w io.Writer)"
+			sig := "serialize(w io.Writer, ver uint16)"
 			if de {
-				sig = "deserialize(r io.Reader)"
+				sig = "deserialize(r io.Reader, ver uint16)"
 			}
 			fmt.Println("\nfunc (obj *" + t.Obj().Name() + ") " + sig + " {")
 			pos := t.Obj().Pos()
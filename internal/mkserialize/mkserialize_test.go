@@ -0,0 +1,59 @@
+package main
+
+import (
+	"go/ast"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// pragma returns the code generated for the //mt: comment text
+// at the start and end of the fields it applies to.
+func pragma(t *testing.T, text string, de bool) (start, end string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	var sp []func()
+	structPragma(&ast.Comment{Text: text}, &sp, "", de)
+	os.Stdout.WriteString("\x00")
+	for i := len(sp) - 1; i >= 0; i-- {
+		sp[i]()
+	}
+	w.Close()
+
+	parts := strings.SplitN(<-out, "\x00", 2)
+	return parts[0], parts[1]
+}
+
+func TestCompressionPragmas(t *testing.T) {
+	for _, tc := range []struct {
+		text       string
+		de         bool
+		start, end string
+	}{
+		{"//mt:zlib", false, "{ w := zlib.NewWriter(w)\n", "chk(w.Close()) }\n"},
+		{"//mt:zlib", true, "{ r, err := zlib.NewReader(byteReader{r}); chk(err)\n", "chk(r.Close()) }\n"},
+		{"//mt:zstd", false, "{ w := zstd.NewWriter(w)\n", "chk(w.Close()) }\n"},
+		{"//mt:zstd", true, "{ r, err := zstd.NewReader(r); chk(err)\n", "chk(r.Close()) }\n"},
+	} {
+		start, end := pragma(t, tc.text, tc.de)
+		if start != tc.start || end != tc.end {
+			t.Errorf("%s, de %v: got %q ... %q, want %q ... %q",
+				tc.text, tc.de, start, end, tc.start, tc.end)
+		}
+	}
+}
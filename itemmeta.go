@@ -8,9 +8,9 @@ import (
 type ItemMeta string
 
 var sanitizer = strings.NewReplacer(
-	string(1), "",
-	string(2), "",
-	string(3), "",
+	"\x01", "",
+	"\x02", "",
+	"\x03", "",
 )
 
 func NewItemMeta(fields []Field) ItemMeta {
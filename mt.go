@@ -1,7 +1,16 @@
 // Package mt implements the high-level Minetest protocol.
-// This version is compatible with Minetest 5.4.1.
+// This version is compatible with Minetest 5.4.1
+// and the changes of protocol version 40 (Minetest 5.5).
 package mt
 
+// The serialization version and the protocol versions
+// implemented by this package.
+const (
+	SerializeVer = 28
+	MinProtoVer  = 39
+	MaxProtoVer  = 40
+)
+
 type Node struct {
 	Param0         Content
 	Param1, Param2 uint8
@@ -0,0 +1,153 @@
+package mt
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+
+	"github.com/anon55555/mt/rudp"
+)
+
+// A Handler handles Pkts.
+type Handler interface {
+	HandlePkt(Pkt)
+}
+
+// A HandlerFunc is a function used as a Handler.
+type HandlerFunc func(Pkt)
+
+func (f HandlerFunc) HandlePkt(pkt Pkt) { f(pkt) }
+
+// A Middleware wraps a Handler, e.g. to log, filter or rewrite Pkts
+// before passing them on to next.
+type Middleware func(next Handler) Handler
+
+// Filter returns a Middleware that drops Pkts for which keep returns false.
+func Filter(keep func(Pkt) bool) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(pkt Pkt) {
+			if keep(pkt) {
+				next.HandlePkt(pkt)
+			}
+		})
+	}
+}
+
+// A Mux is a Handler that dispatches Pkts
+// to the handler registered for the type of their Cmd.
+// The zero value is an empty Mux.
+type Mux struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type]reflect.Value
+	fallback Handler
+	mws      []Middleware
+	chain    Handler
+}
+
+var pktInfoType = reflect.TypeOf(rudp.PktInfo{})
+
+// Handle registers f as the handler of a command type.
+// f must be a function like
+//
+//	func(*ToCltChatMsg, rudp.PktInfo)
+//
+// Handle panics if f is not such a function
+// or a handler is already registered for the command type.
+func (m *Mux) Handle(f interface{}) {
+	v := reflect.ValueOf(f)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 2 || t.NumOut() != 0 ||
+		t.In(0).Kind() != reflect.Ptr || !t.In(0).Implements(reflect.TypeOf((*Cmd)(nil)).Elem()) ||
+		t.In(1) != pktInfoType {
+		panic(fmt.Sprintf("mt: invalid handler type: %T", f))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cmd := t.In(0)
+	if _, ok := m.handlers[cmd]; ok {
+		panic("mt: multiple handlers for " + cmd.String())
+	}
+	if m.handlers == nil {
+		m.handlers = make(map[reflect.Type]reflect.Value)
+	}
+	m.handlers[cmd] = v
+}
+
+// HandleFallback sets the handler of Pkts
+// whose command type has no handler.
+func (m *Mux) HandleFallback(h Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.fallback = h
+}
+
+// Use adds middleware that is applied to all Pkts before they are dispatched.
+// The first Middleware added sees the Pkts first.
+func (m *Mux) Use(mws ...Middleware) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mws = append(m.mws, mws...)
+
+	var h Handler = HandlerFunc(m.dispatch)
+	for i := len(m.mws) - 1; i >= 0; i-- {
+		h = m.mws[i](h)
+	}
+	m.chain = h
+}
+
+// HandlePkt passes pkt through the middleware
+// and then to the handler of its command type.
+func (m *Mux) HandlePkt(pkt Pkt) {
+	m.mu.RLock()
+	h := m.chain
+	m.mu.RUnlock()
+
+	if h == nil {
+		m.dispatch(pkt)
+		return
+	}
+	h.HandlePkt(pkt)
+}
+
+func (m *Mux) dispatch(pkt Pkt) {
+	m.mu.RLock()
+	f, ok := m.handlers[reflect.TypeOf(pkt.Cmd)]
+	fallback := m.fallback
+	m.mu.RUnlock()
+
+	if !ok {
+		if fallback != nil {
+			fallback.HandlePkt(pkt)
+		}
+		return
+	}
+	f.Call([]reflect.Value{reflect.ValueOf(pkt.Cmd), reflect.ValueOf(pkt.PktInfo)})
+}
+
+// Serve receives Pkts from p and handles them until p is closed.
+// p is usually a Peer.
+// Errors other than net.ErrClosed are passed to errs if it is not nil.
+func (m *Mux) Serve(p interface{ Recv() (Pkt, error) }, errs func(error)) error {
+	for {
+		pkt, err := p.Recv()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			if errs != nil {
+				errs(err)
+			}
+			if pkt.Cmd == nil {
+				continue
+			}
+		}
+
+		m.HandlePkt(pkt)
+	}
+}
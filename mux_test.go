@@ -0,0 +1,107 @@
+package mt
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/anon55555/mt/rudp"
+)
+
+func TestMux(t *testing.T) {
+	clt, srv := peers(t)
+
+	log := make(chan string, 100)
+	var m Mux
+	m.Handle(func(cmd *ToSrvChatMsg, _ rudp.PktInfo) {
+		log <- "chat " + cmd.Msg
+	})
+	m.Handle(func(cmd *ToSrvInit2, _ rudp.PktInfo) {
+		log <- "init2 " + cmd.Lang
+	})
+	m.HandleFallback(HandlerFunc(func(pkt Pkt) {
+		log <- fmt.Sprintf("fallback %T", pkt.Cmd)
+	}))
+
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(pkt Pkt) {
+				log <- name
+				next.HandlePkt(pkt)
+			})
+		}
+	}
+	m.Use(mw("a"), Filter(func(pkt Pkt) bool {
+		chat, ok := pkt.Cmd.(*ToSrvChatMsg)
+		return !ok || chat.Msg != "drop"
+	}))
+	m.Use(mw("b"))
+
+	done := make(chan error)
+	go func() {
+		done <- m.Serve(srv, func(err error) { t.Error(err) })
+	}()
+
+	for _, cmd := range []Cmd{
+		&ToSrvChatMsg{Msg: "hi"},
+		&ToSrvChatMsg{Msg: "drop"},
+		&ToSrvInit2{Lang: "en"},
+		&ToSrvRespawn{},
+	} {
+		// Use the same channel for all Pkts to receive them in order.
+		if _, err := clt.Send(Pkt{cmd, rudp.PktInfo{}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{
+		"a", "b", "chat hi",
+		"a",
+		"a", "b", "init2 en",
+		"a", "b", "fallback *mt.ToSrvRespawn",
+	}
+	var got []string
+	for range want {
+		got = append(got, <-log)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	srv.Close()
+	if err := <-done; !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Serve: got %v, want %v", err, net.ErrClosed)
+	}
+	if len(log) > 0 {
+		t.Errorf("unexpected %q", <-log)
+	}
+}
+
+func TestMuxHandlePanics(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		f    interface{}
+	}{
+		{"not func", 42},
+		{"arity", func(*ToSrvChatMsg) {}},
+		{"non-Cmd", func(*int, rudp.PktInfo) {}},
+		{"non-pointer", func(ToSrvChatMsg, rudp.PktInfo) {}},
+		{"PktInfo", func(*ToSrvChatMsg, int) {}},
+		{"result", func(*ToSrvChatMsg, rudp.PktInfo) error { return nil }},
+		{"duplicate", func(*ToSrvRespawn, rudp.PktInfo) {}},
+	} {
+		var m Mux
+		m.Handle(func(*ToSrvRespawn, rudp.PktInfo) {})
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Handle did not panic", tc.name)
+				}
+			}()
+			m.Handle(tc.f)
+		}()
+	}
+}
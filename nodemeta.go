@@ -1,5 +1,11 @@
 package mt
 
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
 type NodeMeta struct {
 	//mt:len32
 	Fields []NodeMetaField
@@ -25,3 +31,77 @@ func (nm *NodeMeta) Field(name string) *NodeMetaField {
 
 	return nil
 }
+
+// writeNodeMetas writes the NodeMetas of a MapBlk without compression.
+func writeNodeMetas(w io.Writer, metas map[uint16]*NodeMeta, ver uint16) {
+	// Like Minetest, write only a version of 0 if there are no NodeMetas.
+	if len(metas) == 0 {
+		_, err := w.Write([]byte{0})
+		chk(err)
+		return
+	}
+
+	buf := make([]byte, 3)
+	buf[0] = 2 // version
+	// len(map[uint16]...) always < math.MaxUint16
+	be.PutUint16(buf[1:], uint16(len(metas)))
+	_, err := w.Write(buf)
+	chk(err)
+
+	keys := make([]uint16, 0, len(metas))
+	for key := range metas {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		i2pos := func(i int) [3]int16 {
+			return Blkpos2Pos([3]int16{}, keys[i])
+		}
+
+		p, q := i2pos(i), i2pos(j)
+
+		for i := range p {
+			switch {
+			case p[i] < q[i]:
+				return true
+			case p[i] > q[i]:
+				return false
+			}
+		}
+
+		return false
+	})
+	for _, key := range keys {
+		be.PutUint16(buf, key)
+		_, err := w.Write(buf[:2])
+		chk(err)
+		chk(serialize(w, metas[key], ver))
+	}
+}
+
+// readNodeMetas reads what writeNodeMetas writes.
+func readNodeMetas(r io.Reader, ver uint16) map[uint16]*NodeMeta {
+	buf := make([]byte, 2)
+	_, err := io.ReadFull(r, buf[:1])
+	chk(err)
+
+	switch buf[0] {
+	case 0:
+		return nil
+	case 2:
+	default:
+		chk(fmt.Errorf("unsupported nodemetas version: %d", buf[0]))
+	}
+
+	_, err = io.ReadFull(r, buf)
+	chk(err)
+	n := be.Uint16(buf)
+	metas := make(map[uint16]*NodeMeta, n)
+	for ; n > 0; n-- {
+		_, err := io.ReadFull(r, buf)
+		chk(err)
+		nm := new(NodeMeta)
+		chk(deserialize(r, nm, ver))
+		metas[be.Uint16(buf)] = nm
+	}
+	return metas
+}
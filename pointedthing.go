@@ -24,7 +24,7 @@ type PointedAO struct {
 	ID AOID
 }
 
-func writePointedThing(w io.Writer, pt PointedThing) error {
+func writePointedThing(w io.Writer, pt PointedThing, ver uint16) error {
 	buf := make([]byte, 2)
 	buf[0] = 0
 	switch pt.(type) {
@@ -43,10 +43,10 @@ func writePointedThing(w io.Writer, pt PointedThing) error {
 	if pt == nil {
 		return nil
 	}
-	return serialize(w, pt)
+	return serialize(w, pt, ver)
 }
 
-func readPointedThing(r io.Reader) (PointedThing, error) {
+func readPointedThing(r io.Reader, ver uint16) (PointedThing, error) {
 	buf := make([]byte, 2)
 	if _, err := io.ReadFull(r, buf); err != nil {
 		return nil, err
@@ -65,5 +65,5 @@ func readPointedThing(r io.Reader) (PointedThing, error) {
 	case 3:
 		return nil, fmt.Errorf("invalid PointedThing type: %d", buf[1])
 	}
-	return pt, deserialize(r, pt)
+	return pt, deserialize(r, pt, ver)
 }
@@ -1,13 +1,21 @@
 package mt
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"sync/atomic"
 
 	"github.com/anon55555/mt/rudp"
 )
 
+// ErrProtoVer is returned when sending a Cmd
+// that the Peer's protocol version does not support.
+var ErrProtoVer = errors.New("cmd not supported by protocol version")
+
 // A Pkt is a deserialized rudp.Pkt.
 type Pkt struct {
 	Cmd
@@ -19,19 +27,73 @@ type Peer struct {
 	*rudp.Conn
 }
 
+// NewPeer returns a Peer wrapping c.
+// It is equivalent to Peer{c}.
+func NewPeer(c *rudp.Conn) Peer {
+	return Peer{c}
+}
+
+// protoVers holds the protocol versions set using Peer.SetProtoVer.
+// Entries are deleted when their Conn is closed.
+var protoVers sync.Map // map[*rudp.Conn]*uint32
+
+// ProtoVer returns the protocol version used for (de)serialization.
+// It is MinProtoVer until a ToCltHello is sent or received,
+// which sets it to ToCltHello.ProtoVer.
+// All Peers wrapping the same Conn share it.
+func (p Peer) ProtoVer() uint16 {
+	if v, ok := protoVers.Load(p.Conn); ok {
+		return uint16(atomic.LoadUint32(v.(*uint32)))
+	}
+	return MinProtoVer
+}
+
+// SetProtoVer sets the protocol version used for (de)serialization.
+func (p Peer) SetProtoVer(ver uint16) {
+	if v, ok := protoVers.Load(p.Conn); ok {
+		atomic.StoreUint32(v.(*uint32), uint32(ver))
+		return
+	}
+
+	x := uint32(ver)
+	if v, loaded := protoVers.LoadOrStore(p.Conn, &x); loaded {
+		atomic.StoreUint32(v.(*uint32), uint32(ver))
+		return
+	}
+	go func(c *rudp.Conn) {
+		<-c.Closed()
+		protoVers.Delete(c)
+	}(p.Conn)
+}
+
 func (p Peer) Send(pkt Pkt) (ack <-chan struct{}, err error) {
-	var cmdNo uint16
+	return p.SendContext(context.Background(), pkt)
+}
+
+// SendContext is like Send but uses rudp.Conn.SendContext.
+func (p Peer) SendContext(ctx context.Context, pkt Pkt) (ack <-chan struct{}, err error) {
+	var cmdNo, minVer uint16
 	if p.IsSrv() {
 		cmdNo = pkt.Cmd.(ToSrvCmd).toSrvCmdNo()
+		minVer = toSrvCmdMinVer[cmdNo]
 	} else {
 		cmdNo = pkt.Cmd.(ToCltCmd).toCltCmdNo()
+		if hello, ok := pkt.Cmd.(*ToCltHello); ok {
+			p.SetProtoVer(hello.ProtoVer)
+		}
 	}
 
 	if cmdNo == 0xffff {
 		return nil, p.Close()
 	}
 
+	ver := p.ProtoVer()
+	if ver < minVer {
+		return nil, fmt.Errorf("%T: %w", pkt.Cmd, ErrProtoVer)
+	}
+
 	r, w := io.Pipe()
+	defer r.Close()
 	go func() (err error) {
 		defer w.CloseWithError(err)
 
@@ -40,10 +102,10 @@ func (p Peer) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 		if _, err := w.Write(buf); err != nil {
 			return err
 		}
-		return serialize(w, pkt.Cmd)
+		return serialize(w, pkt.Cmd, ver)
 	}()
 
-	return p.Conn.Send(rudp.Pkt{r, pkt.PktInfo})
+	return p.Conn.SendContext(ctx, rudp.Pkt{r, pkt.PktInfo})
 }
 
 // SendCmd is equivalent to Send(Pkt{cmd, cmd.DefaultPktInfo()}).
@@ -52,7 +114,12 @@ func (p Peer) SendCmd(cmd Cmd) (ack <-chan struct{}, err error) {
 }
 
 func (p Peer) Recv() (_ Pkt, rerr error) {
-	pkt, err := p.Conn.Recv()
+	return p.RecvContext(context.Background())
+}
+
+// RecvContext is like Recv but uses rudp.Conn.RecvContext.
+func (p Peer) RecvContext(ctx context.Context) (_ Pkt, rerr error) {
+	pkt, err := p.Conn.RecvContext(ctx)
 	if err != nil {
 		return Pkt{}, err
 	}
@@ -63,21 +130,30 @@ func (p Peer) Recv() (_ Pkt, rerr error) {
 	}
 	cmdNo := be.Uint16(buf)
 
+	ver := p.ProtoVer()
+
 	var newCmd func() Cmd
 	if p.IsSrv() {
 		newCmd = newToCltCmd[cmdNo]
 	} else {
 		newCmd = newToSrvCmd[cmdNo]
+		if ver < toSrvCmdMinVer[cmdNo] {
+			newCmd = nil
+		}
 	}
 	if newCmd == nil {
 		return Pkt{}, fmt.Errorf("unknown cmd: %d", cmdNo)
 	}
 	cmd := newCmd()
 
-	if err := deserialize(pkt, cmd); err != nil {
+	if err := deserialize(pkt, cmd, ver); err != nil {
 		return Pkt{}, fmt.Errorf("%T: %w", cmd, err)
 	}
 
+	if hello, ok := cmd.(*ToCltHello); ok {
+		p.SetProtoVer(hello.ProtoVer)
+	}
+
 	extra, err := io.ReadAll(pkt)
 	if len(extra) > 0 {
 		err = fmt.Errorf("%T: %w", cmd, rudp.TrailingDataError(extra))
@@ -86,7 +162,11 @@ func (p Peer) Recv() (_ Pkt, rerr error) {
 }
 
 func Connect(conn net.Conn) Peer {
-	return Peer{rudp.Connect(conn)}
+	return NewPeer(rudp.Connect(conn))
+}
+
+func ConnectConfig(conn net.Conn, cfg rudp.Config) Peer {
+	return NewPeer(rudp.ConnectConfig(conn, cfg))
 }
 
 type Listener struct {
@@ -97,7 +177,23 @@ func Listen(conn net.PacketConn) Listener {
 	return Listener{rudp.Listen(conn)}
 }
 
+func ListenConfig(conn net.PacketConn, cfg rudp.Config) Listener {
+	return Listener{rudp.ListenConfig(conn, cfg)}
+}
+
+func ListenMulti(cfg rudp.Config, conns ...net.PacketConn) Listener {
+	return Listener{rudp.ListenMulti(cfg, conns...)}
+}
+
 func (l Listener) Accept() (Peer, error) {
-	rpeer, err := l.Listener.Accept()
-	return Peer{rpeer}, err
+	return l.AcceptContext(context.Background())
+}
+
+// AcceptContext is like Accept but uses rudp.Listener.AcceptContext.
+func (l Listener) AcceptContext(ctx context.Context) (Peer, error) {
+	rpeer, err := l.Listener.AcceptContext(ctx)
+	if err != nil {
+		return Peer{}, err
+	}
+	return NewPeer(rpeer), nil
 }
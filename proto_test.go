@@ -0,0 +1,140 @@
+package mt
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anon55555/mt/rudp"
+)
+
+func peers(t *testing.T) (clt, srv Peer) {
+	c, s, err := rudp.PipeConn(rudp.Config{}, rudp.PipeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		c.Close()
+		s.Close()
+	})
+	return NewPeer(c), NewPeer(s)
+}
+
+func TestMediaPushVer(t *testing.T) {
+	// Laid out like Minetest 5.5's Server::dynamicAddMedia writes them:
+	// raw_hash, filename and ephemeral,
+	// then the token for version 40 and the file data before.
+	hash := "\x90\x40\xa7\xd6\xcd\xf7\xa0\xd6\xca\xb1\x82\x38\x31\xc6\xce\xb7\xd0\x1a\xf9\x7f"
+	prefix := "\x00\x14" + hash + "\x00\x05a.png" + "\x01"
+	push := ToCltMediaPush{Filename: "a.png", ShouldCache: true}
+	copy(push.SHA1[:], hash)
+
+	for _, tc := range []struct {
+		ver  uint16
+		data string
+		want func(*ToCltMediaPush)
+	}{
+		{39, prefix + "\x00\x00\x00\x03png", func(p *ToCltMediaPush) { p.Data = []byte("png") }},
+		{40, prefix + "\x00\x00\x00\x2a", func(p *ToCltMediaPush) { p.CallbackToken = 42 }},
+	} {
+		want := push
+		tc.want(&want)
+
+		var got ToCltMediaPush
+		r := strings.NewReader(tc.data)
+		if err := deserialize(r, &got, tc.ver); err != nil {
+			t.Fatalf("ver %d: %v", tc.ver, err)
+		}
+		if r.Len() > 0 {
+			t.Errorf("ver %d: %d bytes of trailing data", tc.ver, r.Len())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ver %d: got %+v, want %+v", tc.ver, got, want)
+		}
+
+		var b bytes.Buffer
+		if err := serialize(&b, &want, tc.ver); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.data {
+			t.Errorf("ver %d: serialized to %q, want %q", tc.ver, b.Bytes(), tc.data)
+		}
+	}
+}
+
+func TestProtoVer(t *testing.T) {
+	clt, srv := peers(t)
+
+	if _, err := clt.SendCmd(&ToSrvHaveMedia{Tokens: []uint32{1}}); !errors.Is(err, ErrProtoVer) {
+		t.Errorf("sending ToSrvHaveMedia at ver %d: got %v, want %v", clt.ProtoVer(), err, ErrProtoVer)
+	}
+
+	// A client that does not check the version.
+	if _, err := clt.Conn.Send(rudp.Pkt{
+		Reader:  bytes.NewReader([]byte{0, 68, 1, 0, 0, 0, 1}),
+		PktInfo: (&ToSrvHaveMedia{}).DefaultPktInfo(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Recv(); err == nil || !strings.Contains(err.Error(), "unknown cmd") {
+		t.Errorf("receiving ToSrvHaveMedia at ver %d: got %v", srv.ProtoVer(), err)
+	}
+
+	if _, err := srv.SendCmd(&ToCltHello{ProtoVer: 40}); err != nil {
+		t.Fatal(err)
+	}
+	if v := srv.ProtoVer(); v != 40 {
+		t.Errorf("after sending ToCltHello: ProtoVer is %d, want 40", v)
+	}
+	if _, err := clt.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if v := clt.ProtoVer(); v != 40 {
+		t.Errorf("after receiving ToCltHello: ProtoVer is %d, want 40", v)
+	}
+
+	if _, err := clt.SendCmd(&ToSrvHaveMedia{Tokens: []uint32{1}}); err != nil {
+		t.Fatal(err)
+	}
+	pkt, err := srv.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&ToSrvHaveMedia{Tokens: []uint32{1}}); !reflect.DeepEqual(pkt.Cmd, want) {
+		t.Errorf("got %+v, want %+v", pkt.Cmd, want)
+	}
+}
+
+func TestPeerLiteral(t *testing.T) {
+	clt, _ := peers(t)
+
+	if v := (Peer{}).ProtoVer(); v != MinProtoVer {
+		t.Errorf("zero Peer: ProtoVer is %d, want %d", v, MinProtoVer)
+	}
+
+	p := Peer{clt.Conn}
+	p.SetProtoVer(40)
+	if v := p.ProtoVer(); v != 40 {
+		t.Errorf("ProtoVer is %d, want 40", v)
+	}
+	if v := clt.ProtoVer(); v != 40 {
+		t.Errorf("other Peer wrapping the same Conn: ProtoVer is %d, want 40", v)
+	}
+	if _, err := p.SendCmd(&ToSrvHaveMedia{}); err != nil {
+		t.Error(err)
+	}
+
+	clt.Close()
+	for i := 0; ; i++ {
+		if _, ok := protoVers.Load(clt.Conn); !ok {
+			break
+		}
+		if i == 100 {
+			t.Fatal("protocol version of closed Conn not deleted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
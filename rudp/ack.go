@@ -0,0 +1,95 @@
+package rudp
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// ack acknowledges the reliable packet sn received on ch.
+// If Config.AckDelay is set, the ack is delayed
+// and dropped if sn has already been acknowledged recently
+// or its ack is still delayed, which deduplicates acks
+// but still sends one for every distinct sn.
+func (c *Conn) ack(chNo Channel, sn seqnum) {
+	ch := &c.chans[chNo]
+	st := &c.stats.Chans[chNo]
+
+	ch.ackMu.Lock()
+	defer ch.ackMu.Unlock()
+
+	if c.cfg.AckDelay == 0 {
+		be.PutUint16(ch.ackBuf, uint16(sn))
+		ch.sendAck(context.Background())
+		return
+	}
+
+	if ch.acks[sn] || ch.acked[sn] && time.Since(ch.ackTime) < c.cfg.AckDelay {
+		inc(&st.DupAcksDropped)
+		return
+	}
+
+	ch.acks[sn] = true
+	if len(ch.acks) > 1 {
+		return
+	}
+
+	if ch.ackTimer == nil {
+		ch.ackTimer = time.AfterFunc(c.cfg.AckDelay, func() { c.flushAcks(chNo) })
+	} else {
+		ch.ackTimer.Reset(c.cfg.AckDelay)
+	}
+}
+
+// flushAcks sends the acks delayed by ack.
+func (c *Conn) flushAcks(chNo Channel) {
+	ch := &c.chans[chNo]
+
+	ch.ackMu.Lock()
+	defer ch.ackMu.Unlock()
+
+	if atomic.LoadUint32(&c.closing) == 1 {
+		return
+	}
+
+	// Remember the sent acks to drop acks of retransmissions
+	// that crossed them.
+	ch.acks, ch.acked = ch.acked, ch.acks
+	for sn := range ch.acks {
+		delete(ch.acks, sn)
+	}
+	ch.ackTime = time.Now()
+
+	for sn := range ch.acked {
+		be.PutUint16(ch.ackBuf, uint16(sn))
+		ch.sendAck(context.Background())
+	}
+}
+
+// stopAcks drops all delayed acks.
+func (c *Conn) stopAcks() {
+	for i := range c.chans {
+		ch := &c.chans[i]
+
+		ch.ackMu.Lock()
+		if ch.ackTimer != nil {
+			ch.ackTimer.Stop()
+		}
+		ch.ackMu.Unlock()
+	}
+}
+
+func (c *Conn) newAckBuf() {
+	for i := range c.chans {
+		ch := &c.chans[i]
+
+		ch.ackMu.Lock()
+		ch.sendAck = c.sendRaw(func(buf []byte) int {
+			buf[0] = uint8(rawCtl)
+			buf[1] = uint8(ctlAck)
+			ch.ackBuf = buf[2:4]
+			return 4
+		}, PktInfo{Channel: Channel(i), Unrel: true})
+		ch.ackMu.Unlock()
+	}
+}
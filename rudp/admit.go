@@ -0,0 +1,60 @@
+package rudp
+
+import (
+	"errors"
+	"net"
+)
+
+// errTooManyPending is returned by Listener.add
+// if MaxPending or MaxPendingPerIP is reached.
+var errTooManyPending = errors.New("too many pending conns")
+
+// admit reports whether a UDP packet from an unknown address
+// may create a new Conn.
+func (l *Listener) admit(addr net.Addr, pkt []byte) bool {
+	if len(pkt) < 8 || be.Uint32(pkt[0:4]) != protoID || Channel(pkt[6]) >= ChannelCount {
+		return false
+	}
+
+	return l.cfg.Admit == nil || l.cfg.Admit(addr, pkt)
+}
+
+// host returns the IP address of addr or,
+// if it has none, addr itself as a string.
+func host(addr net.Addr) string {
+	if addr, ok := addr.(*net.UDPAddr); ok {
+		return addr.IP.String()
+	}
+	return addr.String()
+}
+
+// pend counts clt as pending.
+// l.mu must be locked.
+func (l *Listener) pend(clt *udpClt) error {
+	h := host(clt.addr)
+	if l.pending >= l.cfg.MaxPending || l.pendingPerIP[h] >= l.cfg.MaxPendingPerIP {
+		return errTooManyPending
+	}
+
+	l.pending++
+	l.pendingPerIP[h]++
+	clt.pending = true
+	return nil
+}
+
+// unpend stops counting clt as pending.
+func (l *Listener) unpend(clt *udpClt) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !clt.pending {
+		return
+	}
+	clt.pending = false
+
+	h := host(clt.addr)
+	l.pending--
+	if l.pendingPerIP[h]--; l.pendingPerIP[h] == 0 {
+		delete(l.pendingPerIP, h)
+	}
+}
@@ -0,0 +1,174 @@
+package rudp
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rawPipe returns a Listener using lcfg and a function that returns
+// clients of it which send raw UDP packets.
+func rawPipe(t *testing.T, lcfg Config) (*Listener, func() *pipeClt) {
+	pn := newPipeNet(PipeConfig{})
+	l := ListenConfig(pn.srv, lcfg)
+	t.Cleanup(func() { l.Close() })
+	return l, pn.dial
+}
+
+// firstPkt is a valid first UDP packet from a client.
+func firstPkt() []byte {
+	pkt := udpPkt(0, ctlPkt(ctlPing))
+	be.PutUint16(pkt[4:6], uint16(PeerIDNil))
+	return pkt
+}
+
+// acceptWithin accepts a Conn from l
+// or returns nil if none is accepted within d.
+func acceptWithin(t *testing.T, l *Listener, d time.Duration) *Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	c, err := l.AcceptContext(ctx)
+	if err == context.DeadlineExceeded {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestAdmitMalformed(t *testing.T) {
+	var admit, calls int32
+	l, dial := rawPipe(t, Config{
+		Admit: func(addr net.Addr, pkt []byte) bool {
+			atomic.AddInt32(&calls, 1)
+			return atomic.LoadInt32(&admit) == 1
+		},
+	})
+
+	valid := firstPkt()
+	wrongID := append([]byte(nil), valid...)
+	wrongID[0] ^= 1
+	badChan := append([]byte(nil), valid...)
+	badChan[6] = uint8(ChannelCount)
+
+	for _, pkt := range [][]byte{valid[:7], wrongID, badChan} {
+		dial().Write(pkt)
+	}
+	if c := acceptWithin(t, l, 100*time.Millisecond); c != nil {
+		t.Fatal("accepted a Conn for a malformed packet")
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("Admit called %d times for malformed packets", n)
+	}
+
+	dial().Write(valid)
+	if c := acceptWithin(t, l, 100*time.Millisecond); c != nil {
+		t.Fatal("accepted a Conn that Admit rejected")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("Admit called %d times, want 1", n)
+	}
+
+	atomic.StoreInt32(&admit, 1)
+	dial().Write(valid)
+	if c := acceptWithin(t, l, testTimeout); c == nil {
+		t.Fatal("admitted Conn not accepted")
+	}
+}
+
+func TestMaxPending(t *testing.T) {
+	// Packets are processed in order,
+	// so the first three have been when Admit rejects the fourth.
+	processed := make(chan struct{})
+	var calls int32
+	l, dial := rawPipe(t, Config{
+		MaxPending: 2,
+		Admit: func(net.Addr, []byte) bool {
+			if atomic.AddInt32(&calls, 1) == 4 {
+				close(processed)
+				return false
+			}
+			return true
+		},
+	})
+
+	clts := []*pipeClt{dial(), dial(), dial(), dial()}
+	for _, c := range clts {
+		c.Write(firstPkt())
+	}
+	<-processed
+
+	for i := 0; i < 2; i++ {
+		if c := acceptWithin(t, l, testTimeout); c == nil {
+			t.Fatalf("pending Conn %d not accepted", i)
+		}
+	}
+	if c := acceptWithin(t, l, 100*time.Millisecond); c != nil {
+		t.Fatal("accepted a Conn beyond MaxPending")
+	}
+
+	// Accepting makes room.
+	clts[2].Write(firstPkt())
+	if c := acceptWithin(t, l, testTimeout); c == nil {
+		t.Fatal("Conn not accepted after others were")
+	}
+}
+
+func TestMaxPendingPerIP(t *testing.T) {
+	l, _ := rawPipe(t, Config{MaxPending: 3, MaxPendingPerIP: 2})
+
+	add := func(ip string, port int) error {
+		_, err := l.add(0, &net.UDPAddr{IP: net.ParseIP(ip), Port: port})
+		return err
+	}
+
+	for port := 1; port <= 2; port++ {
+		if err := add("192.0.2.1", port); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := add("192.0.2.1", 3); err != errTooManyPending {
+		t.Fatalf("MaxPendingPerIP: got %v, want %v", err, errTooManyPending)
+	}
+	if err := add("192.0.2.2", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := add("192.0.2.3", 1); err != errTooManyPending {
+		t.Fatalf("MaxPending: got %v, want %v", err, errTooManyPending)
+	}
+}
+
+func TestAcceptTimeout(t *testing.T) {
+	l, dial := Pipe(Config{AcceptTimeout: 50 * time.Millisecond}, Config{}, PipeConfig{})
+	defer l.Close()
+
+	clt := dial()
+	defer clt.Close()
+	if _, err := clt.sendRaw(func(buf []byte) int {
+		return copy(buf, ctlPkt(ctlPing))
+	}, PktInfo{Unrel: true})(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The server Conn disconnects the client when it times out.
+	select {
+	case <-clt.Closed():
+	case <-time.After(testTimeout):
+		t.Fatal("unaccepted Conn not closed")
+	}
+	if c := acceptWithin(t, l, 100*time.Millisecond); c != nil {
+		t.Fatal("accepted a timed out Conn")
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.pending != 0 || len(l.clts) != 0 {
+		t.Errorf("%d pending, %d clients left", l.pending, len(l.clts))
+	}
+}
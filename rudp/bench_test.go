@@ -0,0 +1,97 @@
+package rudp
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// benchRecv measures receiving a Pkt made of the UDP packets pkts.
+// If snOff is not 0, the seqnum at pkts[j][snOff:] is incremented per Pkt.
+func benchRecv(b *testing.B, pkts [][]byte, snOff int, sn seqnum) {
+	c, f := newTestConn(b, Config{})
+
+	// The Conn copies packets before the next one is sent,
+	// so two sets of buffers suffice.
+	var bufs [2][][]byte
+	for i := range bufs {
+		for _, pkt := range pkts {
+			bufs[i] = append(bufs[i], append([]byte(nil), pkt...))
+		}
+	}
+
+	go func() {
+		for i := 0; i < b.N; i++ {
+			for _, pkt := range bufs[i%2] {
+				if snOff != 0 {
+					be.PutUint16(pkt[snOff:], uint16(sn+seqnum(i)))
+				}
+				select {
+				case f.in <- pkt:
+				case <-f.closed:
+					return
+				}
+			}
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pkt, err := c.Recv()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := io.Copy(io.Discard, pkt.Reader); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRecvUnrel(b *testing.B) {
+	pkt := udpPkt(0, origPkt(string(make([]byte, 400))))
+	benchRecv(b, [][]byte{pkt}, 0, 0)
+}
+
+func BenchmarkRecvRel(b *testing.B) {
+	pkt := udpPkt(0, relPkt(initSeqnum, origPkt(string(make([]byte, 400)))))
+	benchRecv(b, [][]byte{pkt}, 8, initSeqnum)
+}
+
+func BenchmarkRecvSplit(b *testing.B) {
+	chunk := string(make([]byte, 400))
+	pkts := make([][]byte, 8)
+	for i := range pkts {
+		pkts[i] = udpPkt(0, splitPkt(0, 8, uint16(i), chunk))
+	}
+	benchRecv(b, pkts, 8, 0)
+}
+
+func BenchmarkSend(b *testing.B) {
+	c, f := newTestConn(b, Config{})
+	go func() {
+		for {
+			select {
+			case <-f.out:
+			case <-f.closed:
+				return
+			}
+		}
+	}()
+
+	data := make([]byte, 4000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := c.Send(Pkt{
+			Reader:  bytes.NewReader(data),
+			PktInfo: PktInfo{Unrel: true},
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package rudp
+
+import (
+	"io"
+	"net"
+	"sync"
+)
+
+// A udpBuf holds a received UDP packet.
+// Received packets are read into pooled udpBufs;
+// the owner of a udpBuf releases it once nothing refers to its data.
+type udpBuf struct {
+	data []byte
+}
+
+var udpBufs sync.Pool
+
+// newUDPBuf returns a udpBuf with len(data) == size.
+func newUDPBuf(size int) *udpBuf {
+	if b, ok := udpBufs.Get().(*udpBuf); ok && cap(b.data) >= size {
+		b.data = b.data[:size]
+		return b
+	}
+	return &udpBuf{data: make([]byte, size)}
+}
+
+// release returns b to the pool. b must not be used afterwards.
+func (b *udpBuf) release() {
+	if b != nil {
+		udpBufs.Put(b)
+	}
+}
+
+// A lentReader reads chunks of udpBufs
+// and releases the udpBufs once all chunks have been read.
+type lentReader struct {
+	chunks net.Buffers
+	bufs   []*udpBuf
+
+	// Used by newLentReader to avoid allocating slices.
+	chunk [1][]byte
+	buf   [1]*udpBuf
+}
+
+// newLentReader returns a lentReader reading the single chunk data of b.
+func newLentReader(data []byte, b *udpBuf) *lentReader {
+	r := &lentReader{}
+	r.chunk[0], r.buf[0] = data, b
+	r.chunks, r.bufs = r.chunk[:], r.buf[:]
+	return r
+}
+
+func (r *lentReader) Read(p []byte) (int, error) {
+	n, err := r.chunks.Read(p)
+	if err == io.EOF {
+		r.release()
+	}
+	return n, err
+}
+
+func (r *lentReader) WriteTo(w io.Writer) (int64, error) {
+	n, err := r.chunks.WriteTo(w)
+	if len(r.chunks) == 0 {
+		r.release()
+	}
+	return n, err
+}
+
+func (r *lentReader) release() {
+	for _, b := range r.bufs {
+		b.release()
+	}
+	r.bufs = nil
+}
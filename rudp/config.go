@@ -0,0 +1,248 @@
+package rudp
+
+import (
+	"net"
+	"time"
+)
+
+const (
+	// ResendTimeout is the default time to wait for an ack
+	// before resending a reliable packet
+	// until the round-trip time has been measured.
+	ResendTimeout = 500 * time.Millisecond
+
+	// MinResendTimeout and MaxResendTimeout are the default bounds
+	// of the adaptive resend timeout.
+	MinResendTimeout = 100 * time.Millisecond
+	MaxResendTimeout = 3 * time.Second
+
+	// MaxRelWindow is the largest possible RelWindow.
+	MaxRelWindow = 0x8000
+
+	// UDPPktSize is the default MaxUDPPktSize.
+	UDPPktSize = 512
+
+	// MaxSplitsPerChan is the default maximum number of
+	// incomplete split packets per Channel.
+	MaxSplitsPerChan = 64
+
+	// MaxSplitMem is the default maximum number of bytes
+	// buffered for incomplete split packets per Conn.
+	MaxSplitMem = 8 << 20
+
+	// MaxListenerSplitMem is the default maximum number of bytes
+	// buffered for incomplete split packets by all Conns of a Listener.
+	MaxListenerSplitMem = 64 << 20
+
+	// MaxPending and MaxPendingPerIP are the default maximum numbers
+	// of Conns a Listener has created but that have not been accepted yet.
+	MaxPending      = 256
+	MaxPendingPerIP = 8
+)
+
+// A Config configures a Conn or a Listener and the Conns accepted by it.
+// Zero fields are replaced by their defaults.
+type Config struct {
+	// ConnTimeout is how long to wait for a packet from the peer
+	// before closing with ErrTimedOut. Defaults to ConnTimeout.
+	ConnTimeout time.Duration
+
+	// PingTimeout is how long to wait after sending a packet
+	// before sending a ping. Defaults to PingTimeout.
+	PingTimeout time.Duration
+
+	// ResendTimeout is how long to wait for an ack
+	// before resending a reliable packet
+	// until the round-trip time has been measured.
+	// Like the calculated resend timeout, it is clamped to
+	// [MinResendTimeout, MaxResendTimeout], so lowering or raising it
+	// past the defaults requires setting those as well.
+	// Defaults to ResendTimeout.
+	ResendTimeout time.Duration
+
+	// MinResendTimeout and MaxResendTimeout bound the resend timeout
+	// calculated from the round-trip time, including exponential backoff.
+	// They default to MinResendTimeout and MaxResendTimeout.
+	MinResendTimeout time.Duration
+	MaxResendTimeout time.Duration
+
+	// RelWindow is the maximum number of unacknowledged reliable packets
+	// per Channel. Send blocks while the window is full.
+	// Defaults to MaxRelWindow, which is also the upper limit.
+	RelWindow int
+
+	// MaxUDPPktSize is the size of the largest UDP packet that is sent
+	// or can be received. Defaults to UDPPktSize.
+	MaxUDPPktSize int
+
+	// MaxBytesPerSec limits the rate at which UDP packets are sent,
+	// excluding control packets like acks, pings and disconnects,
+	// including reliable ones.
+	// Sends are delayed until the limit allows them.
+	// Zero means no limit.
+	MaxBytesPerSec int
+
+	// Burst is how many bytes may be sent at once
+	// before MaxBytesPerSec applies.
+	// Defaults to 16 * MaxUDPPktSize if MaxBytesPerSec is set.
+	Burst int
+
+	// ChanWeights are the relative shares of the sending bandwidth
+	// that the Channels get while packets of several Channels are waiting
+	// to be sent, e.g. because of MaxBytesPerSec, so that bulk transfers
+	// don't delay interactive traffic on other Channels.
+	// Acks and other control packets are not delayed.
+	// Zero weights default to the weights in DefaultConfig, which are equal.
+	ChanWeights [ChannelCount]int
+
+	// AckDelay, if not zero, is how long acks are delayed
+	// so that duplicate acks of the same reliable packet can be dropped.
+	// This includes acks of retransmissions received within AckDelay
+	// after the packet was acknowledged.
+	// It does not reduce the number of acks of distinct packets:
+	// the protocol has one ack per UDP packet, so each is still sent
+	// in its own UDP packet, as peers expect.
+	// AckDelay adds to the round-trip time seen by the peer,
+	// so it should be much smaller than its resend timeout.
+	AckDelay time.Duration
+
+	// MaxSplitsPerChan limits the number of incomplete split packets
+	// per Channel. Defaults to MaxSplitsPerChan.
+	MaxSplitsPerChan int
+
+	// MaxSplitMem limits the memory used for incomplete split packets
+	// per Conn, which also limits the size of received split packets
+	// below MaxRelPktSize and MaxUnrelPktSize. Defaults to MaxSplitMem.
+	MaxSplitMem int
+
+	// MaxRelPktSize and MaxUnrelPktSize limit the size of
+	// received reliable and unreliable split packets.
+	// They default to MaxRelPktSize and MaxUnrelPktSize.
+	MaxRelPktSize   int
+	MaxUnrelPktSize int
+
+	// Tap, if not nil, is called with every UDP packet
+	// sent or received, including packets a Listener drops.
+	// It must not block.
+	Tap func(Capture)
+
+	// The following fields are only used by Listeners.
+
+	// Admit is called with the first UDP packet from an unknown address
+	// and reports whether a new Conn may be created for it.
+	// Packets without a valid header are rejected before Admit is called.
+	// Admit must not modify or retain pkt.
+	// Nil admits everything.
+	Admit func(addr net.Addr, pkt []byte) bool
+
+	// Migrate, if not nil, lets accepted Conns move to a new address,
+	// e.g. after a NAT rebinding. It is called with the first UDP packet
+	// from an unknown address whose header carries the PeerID of c
+	// and reports whether c should move to addr.
+	// If not, the packet is dropped.
+	// PeerIDs are easily guessed, so Migrate must validate the move,
+	// see MigrateSameIP. Migrate must not modify or retain pkt.
+	Migrate func(c *Conn, addr net.Addr, pkt []byte) bool
+
+	// MaxPending and MaxPendingPerIP limit the number of Conns
+	// that have been created but not accepted yet,
+	// in total and per IP address. Packets from unknown addresses
+	// are dropped while a limit is reached.
+	// They default to MaxPending and MaxPendingPerIP.
+	MaxPending      int
+	MaxPendingPerIP int
+
+	// AcceptTimeout is how long a Conn waits to be accepted
+	// before it is closed with ErrTimedOut. Defaults to ConnTimeout.
+	AcceptTimeout time.Duration
+
+	// MaxListenerSplitMem limits the memory used for
+	// incomplete split packets by all Conns of a Listener,
+	// which would otherwise grow with the number of Conns.
+	// Once it is reached, only Conns using more than their share,
+	// MaxListenerSplitMem divided by the number of open Conns, fail;
+	// the others may still use up to their share,
+	// so the total may exceed it up to twice.
+	// Defaults to MaxListenerSplitMem.
+	MaxListenerSplitMem int
+}
+
+// DefaultConfig is the Config used by Connect and Listen.
+var DefaultConfig = Config{
+	ConnTimeout:         ConnTimeout,
+	PingTimeout:         PingTimeout,
+	ResendTimeout:       ResendTimeout,
+	MinResendTimeout:    MinResendTimeout,
+	MaxResendTimeout:    MaxResendTimeout,
+	RelWindow:           MaxRelWindow,
+	MaxUDPPktSize:       UDPPktSize,
+	ChanWeights:         [ChannelCount]int{1, 1, 1},
+	MaxSplitsPerChan:    MaxSplitsPerChan,
+	MaxSplitMem:         MaxSplitMem,
+	MaxRelPktSize:       MaxRelPktSize,
+	MaxUnrelPktSize:     MaxUnrelPktSize,
+	MaxPending:          MaxPending,
+	MaxPendingPerIP:     MaxPendingPerIP,
+	AcceptTimeout:       ConnTimeout,
+	MaxListenerSplitMem: MaxListenerSplitMem,
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.ConnTimeout <= 0 {
+		cfg.ConnTimeout = DefaultConfig.ConnTimeout
+	}
+	if cfg.PingTimeout <= 0 {
+		cfg.PingTimeout = DefaultConfig.PingTimeout
+	}
+	if cfg.ResendTimeout <= 0 {
+		cfg.ResendTimeout = DefaultConfig.ResendTimeout
+	}
+	if cfg.MinResendTimeout <= 0 {
+		cfg.MinResendTimeout = DefaultConfig.MinResendTimeout
+	}
+	if cfg.MaxResendTimeout <= 0 {
+		cfg.MaxResendTimeout = DefaultConfig.MaxResendTimeout
+	}
+	if cfg.MaxResendTimeout < cfg.MinResendTimeout {
+		cfg.MaxResendTimeout = cfg.MinResendTimeout
+	}
+	if cfg.RelWindow <= 0 || cfg.RelWindow > MaxRelWindow {
+		cfg.RelWindow = DefaultConfig.RelWindow
+	}
+	if cfg.MaxUDPPktSize <= 0 {
+		cfg.MaxUDPPktSize = DefaultConfig.MaxUDPPktSize
+	}
+	for i, w := range cfg.ChanWeights {
+		if w <= 0 {
+			cfg.ChanWeights[i] = DefaultConfig.ChanWeights[i]
+		}
+	}
+	if cfg.MaxSplitsPerChan <= 0 {
+		cfg.MaxSplitsPerChan = DefaultConfig.MaxSplitsPerChan
+	}
+	if cfg.MaxSplitMem <= 0 {
+		cfg.MaxSplitMem = DefaultConfig.MaxSplitMem
+	}
+	if cfg.MaxRelPktSize <= 0 {
+		cfg.MaxRelPktSize = DefaultConfig.MaxRelPktSize
+	}
+	if cfg.MaxUnrelPktSize <= 0 {
+		cfg.MaxUnrelPktSize = DefaultConfig.MaxUnrelPktSize
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = DefaultConfig.MaxPending
+	}
+	if cfg.MaxPendingPerIP <= 0 {
+		cfg.MaxPendingPerIP = DefaultConfig.MaxPendingPerIP
+	}
+	if cfg.AcceptTimeout <= 0 {
+		cfg.AcceptTimeout = DefaultConfig.AcceptTimeout
+	}
+	if cfg.MaxListenerSplitMem <= 0 {
+		cfg.MaxListenerSplitMem = DefaultConfig.MaxListenerSplitMem
+	}
+	if cfg.MaxBytesPerSec > 0 && cfg.Burst <= 0 {
+		cfg.Burst = 16 * cfg.MaxUDPPktSize
+	}
+	return cfg
+}
@@ -1,6 +1,7 @@
 package rudp
 
 import (
+	"context"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -10,7 +11,14 @@ import (
 // A Conn is a connection to a client or server.
 // All Conn's methods are safe for concurrent use.
 type Conn struct {
+	// First fields to keep them 64-bit aligned for atomic operations.
+	splitMem int64
+	lastRecv int64 // UnixNano
+
 	udpConn udpConn
+	cfg     Config
+
+	ln *Listener // The Listener c belongs to, nil otherwise.
 
 	id PeerID
 
@@ -27,6 +35,13 @@ type Conn struct {
 	mu       sync.RWMutex
 	remoteID PeerID
 
+	rtt   rttEstimator
+	rate  *tokenBucket // nil if unlimited
+	sched sched
+
+	// Pointer to keep it 64-bit aligned for atomic operations.
+	stats *Stats
+
 	chans [ChannelCount]pktChan // read/write
 }
 
@@ -57,12 +72,24 @@ func (c *Conn) LocalAddr() net.Addr { return c.udpConn.LocalAddr() }
 // RemoteAddr returns the remote network address.
 func (c *Conn) RemoteAddr() net.Addr { return c.udpConn.RemoteAddr() }
 
+// An inRel is a received reliable packet waiting for its predecessors.
+type inRel struct {
+	data []byte
+	buf  *udpBuf
+}
+
 type pktChan struct {
 	// Only accessed by Conn.recvUDPPkts goroutine.
-	inRels  *[0x8000][]byte
+	inRels  *[MaxRelWindow]inRel
 	inRelSN seqnum
-	sendAck func() (<-chan struct{}, error)
-	ackBuf  []byte
+
+	ackMu    sync.Mutex
+	sendAck  sendFunc
+	ackBuf   []byte
+	acks     map[seqnum]bool // Delayed by Config.AckDelay.
+	acked    map[seqnum]bool // Sent at ackTime.
+	ackTime  time.Time
+	ackTimer *time.Timer
 
 	inSplitsMu sync.RWMutex
 	inSplits   map[seqnum]*inSplit
@@ -77,24 +104,49 @@ type pktChan struct {
 	outRelWin seqnum
 }
 
-type inSplit struct {
-	chunks  [][]byte
-	got     int
-	timeout *time.Timer
-}
-
 // Close closes the Conn.
 // Any blocked Send or Recv calls will return net.ErrClosed.
 func (c *Conn) Close() error {
 	return c.closeDisco(nil)
 }
 
+// CloseGracefully waits until all reliable packets sent so far
+// have been acknowledged or ctx is done and then closes the Conn.
+// It returns ctx.Err() if ctx is done first.
+func (c *Conn) CloseGracefully(ctx context.Context) error {
+	for {
+		var acks []chan struct{}
+		for i := range c.chans {
+			c.chans[i].ackChans.Range(func(_, ack interface{}) bool {
+				acks = append(acks, ack.(chan struct{}))
+				return true
+			})
+		}
+		if len(acks) == 0 {
+			break
+		}
+
+		for _, ack := range acks {
+			select {
+			case <-ack:
+			case <-c.Closed():
+				return net.ErrClosed
+			case <-ctx.Done():
+				c.Close()
+				return ctx.Err()
+			}
+		}
+	}
+
+	return c.Close()
+}
+
 func (c *Conn) closeDisco(err error) error {
 	c.sendRaw(func(buf []byte) int {
 		buf[0] = uint8(rawCtl)
 		buf[1] = uint8(ctlDisco)
 		return 2
-	}, PktInfo{Unrel: true})()
+	}, PktInfo{Unrel: true})(context.Background())
 
 	return c.close(err)
 }
@@ -106,6 +158,17 @@ func (c *Conn) close(err error) error {
 
 	c.timeout.Stop()
 	c.ping.Stop()
+	c.stopAcks()
+
+	// Stop split timeouts so they don't keep the Conn alive.
+	for i := range c.chans {
+		ch := &c.chans[i]
+		ch.inSplitsMu.Lock()
+		for sn := range ch.inSplits {
+			c.dropSplit(ch, sn)
+		}
+		ch.inSplitsMu.Unlock()
+	}
 
 	c.err = err
 	defer close(c.closed)
@@ -113,31 +176,48 @@ func (c *Conn) close(err error) error {
 	return c.udpConn.Close()
 }
 
-func newConn(uc udpConn, id, remoteID PeerID) *Conn {
+func newConn(uc udpConn, id, remoteID PeerID, cfg Config) *Conn {
 	var c *Conn
 	c = &Conn{
 		udpConn: uc,
+		cfg:     cfg,
 
 		id: id,
 
 		pkts: make(chan Pkt),
 		errs: make(chan error),
 
-		timeout: time.AfterFunc(ConnTimeout, func() {
+		timeout: time.AfterFunc(cfg.ConnTimeout, func() {
 			c.closeDisco(ErrTimedOut)
 		}),
-		ping: time.NewTicker(PingTimeout),
+		ping: time.NewTicker(cfg.PingTimeout),
 
 		closed: make(chan struct{}),
 
 		remoteID: remoteID,
+
+		lastRecv: time.Now().UnixNano(),
+
+		stats: new(Stats),
+	}
+
+	if uc, ok := uc.(*udpClt); ok {
+		c.ln = uc.l
 	}
 
+	if cfg.MaxBytesPerSec > 0 {
+		c.rate = newTokenBucket(cfg.MaxBytesPerSec, cfg.Burst)
+	}
+	c.sched.init(cfg)
+
 	for i := range c.chans {
 		c.chans[i] = pktChan{
-			inRels:  new([0x8000][]byte),
+			inRels:  new([MaxRelWindow]inRel),
 			inRelSN: initSeqnum,
 
+			acks:  make(map[seqnum]bool),
+			acked: make(map[seqnum]bool),
+
 			inSplits: make(map[seqnum]*inSplit),
 
 			outSplitSN: initSeqnum,
@@ -150,6 +230,7 @@ func newConn(uc udpConn, id, remoteID PeerID) *Conn {
 	c.newAckBuf()
 
 	go c.sendPings(c.ping.C)
+	go c.sendUDPPkts()
 	go c.recvUDPPkts()
 
 	return c
@@ -165,7 +246,7 @@ func (c *Conn) sendPings(ping <-chan time.Time) {
 	for {
 		select {
 		case <-ping:
-			send()
+			send(context.Background())
 		case <-c.Closed():
 			return
 		}
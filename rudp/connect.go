@@ -4,15 +4,28 @@ import "net"
 
 type udpSrv struct {
 	net.Conn
+	maxPktSize int
 }
 
-func (us udpSrv) recvUDP() ([]byte, error) {
-	buf := make([]byte, maxUDPPktSize)
-	n, err := us.Read(buf)
-	return buf[:n], err
+func (us udpSrv) recvUDP() (*udpBuf, error) {
+	buf := newUDPBuf(us.maxPktSize)
+	n, err := us.Read(buf.data)
+	if err != nil {
+		buf.release()
+		return nil, err
+	}
+	buf.data = buf.data[:n]
+	return buf, nil
 }
 
-// Connect returns a Conn connected to conn.
+// Connect returns a Conn connected to conn using DefaultConfig.
 func Connect(conn net.Conn) *Conn {
-	return newConn(udpSrv{conn}, PeerIDSrv, PeerIDNil)
+	return ConnectConfig(conn, DefaultConfig)
+}
+
+// ConnectConfig is like Connect but uses cfg instead of DefaultConfig.
+func ConnectConfig(conn net.Conn, cfg Config) *Conn {
+	cfg = cfg.withDefaults()
+	uc := tapped(udpSrv{conn, cfg.MaxUDPPktSize}, cfg.Tap)
+	return newConn(uc, PeerIDSrv, PeerIDNil, cfg)
 }
@@ -0,0 +1,82 @@
+//go:build go1.18
+// +build go1.18
+
+package rudp
+
+import (
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+)
+
+// FuzzConn feeds arbitrary UDP packets into a Conn.
+// The input is a sequence of UDP packets, each prefixed by its length.
+func FuzzConn(f *testing.F) {
+	for _, pkts := range [][][]byte{
+		{udpPkt(0, origPkt("hello"))},
+		{udpPkt(0, relPkt(initSeqnum+1, origPkt("b"))), udpPkt(0, relPkt(initSeqnum, origPkt("a")))},
+		{udpPkt(1, splitPkt(3, 2, 1, "cd")), udpPkt(1, splitPkt(3, 2, 0, "ab"))},
+		{udpPkt(2, relPkt(initSeqnum, splitPkt(0, 2, 0, "ab"))), udpPkt(2, relPkt(initSeqnum+1, splitPkt(0, 2, 1, "cd")))},
+		{udpPkt(0, relPkt(initSeqnum, ctlPkt(ctlSetPeerID, 0, 2))), udpPkt(0, ackPkt(initSeqnum))},
+		{udpPkt(0, ctlPkt(ctlPing)), udpPkt(0, ctlPkt(ctlDisco))},
+	} {
+		var in []byte
+		for _, pkt := range pkts {
+			in = append(in, uint8(len(pkt)))
+			in = append(in, pkt...)
+		}
+		f.Add(in)
+	}
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		cfg := Config{
+			MaxSplitsPerChan: 4,
+			MaxSplitMem:      1 << 12,
+			MaxRelPktSize:    1 << 10,
+			MaxUnrelPktSize:  1 << 10,
+		}
+		c, fu := newTestConn(t, cfg)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for {
+				pkt, err := c.Recv()
+				if err == net.ErrClosed {
+					return
+				}
+				if err == nil {
+					io.Copy(io.Discard, pkt)
+				}
+			}
+		}()
+
+		for len(in) > 0 {
+			n := int(in[0])
+			in = in[1:]
+			if n > len(in) {
+				n = len(in)
+			}
+			fu.feed(t, in[:n])
+			in = in[n:]
+
+			if m := atomic.LoadInt64(&c.splitMem); m > int64(cfg.MaxSplitMem) {
+				t.Fatalf("splitMem = %d > %d", m, cfg.MaxSplitMem)
+			}
+		}
+
+		for i := range c.chans {
+			ch := &c.chans[i]
+			ch.inSplitsMu.RLock()
+			n := len(ch.inSplits)
+			ch.inSplitsMu.RUnlock()
+			if n > cfg.MaxSplitsPerChan {
+				t.Fatalf("%d incomplete splits on channel %d", n, i)
+			}
+		}
+
+		c.Close()
+		<-done
+	})
+}
@@ -1,9 +1,11 @@
 package rudp
 
 import (
+	"context"
 	"errors"
 	"net"
 	"sync"
+	"time"
 )
 
 func tryClose(ch chan struct{}) (ok bool) {
@@ -15,15 +17,38 @@ func tryClose(ch chan struct{}) (ok bool) {
 type udpClt struct {
 	l      *Listener
 	id     PeerID
-	addr   net.Addr
-	pkts   chan []byte
+	pkts   chan *udpBuf
 	closed chan struct{}
+
+	// Changed with both mu and l.mu locked when the Conn migrates.
+	mu   sync.RWMutex
+	pc   int // Index into l.pcs.
+	addr net.Addr
+
+	pending bool  // Protected by l.mu.
+	conn    *Conn // Protected by l.mu.
 }
 
 func (c *udpClt) mkConn() {
-	conn := newConn(c, c.id, PeerIDSrv)
+	conn := newConn(c, c.id, PeerIDSrv, c.l.cfg)
+
+	c.l.mu.Lock()
+	c.l.open[conn] = true
+	c.conn = conn
+	c.l.mu.Unlock()
+
 	go func() {
 		<-conn.Closed()
+
+		c.l.mu.Lock()
+		delete(c.l.open, conn)
+		s := conn.Stats()
+		for i := range s.Chans {
+			s.Chans[i].AcksOutstanding = 0
+		}
+		c.l.closedStats.add(s)
+		c.l.mu.Unlock()
+
 		c.l.wg.Done()
 	}()
 	conn.sendRaw(func(buf []byte) int {
@@ -31,11 +56,20 @@ func (c *udpClt) mkConn() {
 		buf[1] = uint8(ctlSetPeerID)
 		be.PutUint16(buf[2:4], uint16(conn.ID()))
 		return 4
-	}, PktInfo{})()
+	}, PktInfo{})(context.Background())
+
+	defer c.l.unpend(c)
+
+	t := time.NewTimer(c.l.cfg.AcceptTimeout)
+	defer t.Stop()
+
 	select {
 	case c.l.conns <- conn:
 	case <-c.l.closed:
 		conn.Close()
+	case <-t.C:
+		conn.closeDisco(ErrTimedOut)
+	case <-conn.Closed():
 	}
 }
 
@@ -46,10 +80,24 @@ func (c *udpClt) Write(pkt []byte) (int, error) {
 	default:
 	}
 
-	return c.l.pc.WriteTo(pkt, c.addr)
+	c.mu.RLock()
+	pc, addr := c.l.pcs[c.pc], c.addr
+	c.mu.RUnlock()
+
+	n, err := pc.WriteTo(pkt, addr)
+	if err == nil && c.l.cfg.Tap != nil {
+		c.l.cfg.Tap(Capture{
+			Sent:   true,
+			Time:   time.Now(),
+			Local:  pc.LocalAddr(),
+			Remote: addr,
+			Data:   pkt,
+		})
+	}
+	return n, err
 }
 
-func (c *udpClt) recvUDP() ([]byte, error) {
+func (c *udpClt) recvUDP() (*udpBuf, error) {
 	select {
 	case pkt := <-c.pkts:
 		return pkt, nil
@@ -67,17 +115,42 @@ func (c *udpClt) Close() error {
 	defer c.l.mu.Unlock()
 
 	delete(c.l.ids, c.id)
-	delete(c.l.clts, c.addr.String())
+	delete(c.l.clts, c.key())
 
 	return nil
 }
 
-func (c *udpClt) LocalAddr() net.Addr  { return c.l.pc.LocalAddr() }
-func (c *udpClt) RemoteAddr() net.Addr { return c.addr }
+// key returns the key of c in l.clts.
+// c.mu or c.l.mu must be locked.
+func (c *udpClt) key() cltKey { return cltKey{c.pc, c.addr.String()} }
+
+func (c *udpClt) LocalAddr() net.Addr {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.l.pcs[c.pc].LocalAddr()
+}
+
+func (c *udpClt) RemoteAddr() net.Addr {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.addr
+}
+
+// A cltKey identifies a client by the socket it uses and its address.
+type cltKey struct {
+	pc   int // Index into Listener.pcs.
+	addr string
+}
 
 // All Listener's methods are safe for concurrent use.
 type Listener struct {
-	pc net.PacketConn
+	// First field to keep it 64-bit aligned for atomic operations.
+	splitMem int64 // Charged by the Conns' split packets.
+
+	pcs []net.PacketConn
+	cfg Config
 
 	peerID PeerID
 	conns  chan *Conn
@@ -85,43 +158,73 @@ type Listener struct {
 	closed chan struct{}
 	wg     sync.WaitGroup
 
-	mu   sync.RWMutex
-	ids  map[PeerID]bool
-	clts map[string]*udpClt
+	mu           sync.RWMutex
+	ids          map[PeerID]*udpClt
+	clts         map[cltKey]*udpClt
+	open         map[*Conn]bool
+	closedStats  Stats
+	pending      int
+	pendingPerIP map[string]int
 }
 
-// Listen listens for connections on pc, pc is closed once the returned Listener
+// Listen listens for connections on pc using DefaultConfig,
+// pc is closed once the returned Listener
 // and all Conns connected through it are closed.
 func Listen(pc net.PacketConn) *Listener {
+	return ListenConfig(pc, DefaultConfig)
+}
+
+// ListenConfig is like Listen but uses cfg instead of DefaultConfig
+// for the Listener and all Conns accepted through it.
+func ListenConfig(pc net.PacketConn, cfg Config) *Listener {
+	return ListenMulti(cfg, pc)
+}
+
+// ListenMulti is like ListenConfig but listens on all of pcs,
+// e.g. an IPv4 and an IPv6 socket. Conns from all of pcs
+// share the same PeerIDs and are returned by the same Accept.
+func ListenMulti(cfg Config, pcs ...net.PacketConn) *Listener {
 	l := &Listener{
-		pc: pc,
+		pcs: pcs,
+		cfg: cfg.withDefaults(),
 
 		conns:  make(chan *Conn),
 		closed: make(chan struct{}),
 
-		ids:  make(map[PeerID]bool),
-		clts: make(map[string]*udpClt),
+		ids:  make(map[PeerID]*udpClt),
+		clts: make(map[cltKey]*udpClt),
+		open: make(map[*Conn]bool),
+
+		pendingPerIP: make(map[string]int),
 	}
 
-	go func() {
-		for {
-			if err := l.processNetPkt(); err != nil {
-				if errors.Is(err, net.ErrClosed) {
-					break
-				}
-				select {
-				case l.errs <- err:
-				case <-l.closed:
+	for i := range pcs {
+		go func(i int) {
+			for {
+				if err := l.processNetPkt(i); err != nil {
+					if errors.Is(err, net.ErrClosed) {
+						break
+					}
+					select {
+					case l.errs <- err:
+					case <-l.closed:
+					}
 				}
 			}
-		}
-	}()
+		}(i)
+	}
 
 	return l
 }
 
 // Accept waits for and returns the next incoming Conn or an error.
 func (l *Listener) Accept() (*Conn, error) {
+	return l.AcceptContext(context.Background())
+}
+
+// AcceptContext is like Accept but returns ctx.Err() if ctx is done
+// before a Conn is accepted.
+func (l *Listener) AcceptContext(ctx context.Context) (*Conn, error) {
 	select {
 	case c := <-l.conns:
 		return c, nil
@@ -129,6 +232,8 @@ func (l *Listener) Accept() (*Conn, error) {
 		return nil, err
 	case <-l.closed:
 		return nil, net.ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 }
 
@@ -142,26 +247,98 @@ func (l *Listener) Close() error {
 
 	go func() {
 		l.wg.Wait()
-		l.pc.Close()
+		for _, pc := range l.pcs {
+			pc.Close()
+		}
 	}()
 
 	return nil
 }
 
-// Addr returns the Listener's network address.
-func (l *Listener) Addr() net.Addr { return l.pc.LocalAddr() }
+// Shutdown closes the Listener and gracefully closes
+// all Conns created by it, see Conn.CloseGracefully.
+// It returns ctx.Err() if ctx is done before all Conns are drained.
+func (l *Listener) Shutdown(ctx context.Context) error {
+	l.Close()
+
+	l.mu.RLock()
+	var conns []*Conn
+	for c := range l.open {
+		conns = append(conns, c)
+	}
+	l.mu.RUnlock()
+
+	errs := make(chan error, len(conns))
+	for _, c := range conns {
+		go func(c *Conn) {
+			errs <- c.CloseGracefully(ctx)
+		}(c)
+	}
+
+	var err error
+	for range conns {
+		if e := <-errs; e != nil && e != net.ErrClosed && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// splitMemShare returns the share of MaxListenerSplitMem
+// guaranteed to each open Conn.
+func (l *Listener) splitMemShare() int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	n := len(l.open)
+	if n == 0 {
+		n = 1
+	}
+	return int64(l.cfg.MaxListenerSplitMem / n)
+}
+
+// Addr returns the network address of the Listener's first socket.
+func (l *Listener) Addr() net.Addr { return l.pcs[0].LocalAddr() }
+
+// Addrs returns the network addresses of all of the Listener's sockets.
+func (l *Listener) Addrs() []net.Addr {
+	addrs := make([]net.Addr, len(l.pcs))
+	for i, pc := range l.pcs {
+		addrs[i] = pc.LocalAddr()
+	}
+	return addrs
+}
 
 var ErrOutOfPeerIDs = errors.New("out of peer ids")
 
-func (l *Listener) processNetPkt() error {
-	buf := make([]byte, maxUDPPktSize)
-	n, addr, err := l.pc.ReadFrom(buf)
+func (l *Listener) processNetPkt(i int) error {
+	pc := l.pcs[i]
+
+	buf := newUDPBuf(l.cfg.MaxUDPPktSize)
+	sent := false
+	defer func() {
+		if !sent {
+			buf.release()
+		}
+	}()
+
+	n, addr, err := pc.ReadFrom(buf.data)
 	if err != nil {
 		return err
 	}
+	buf.data = buf.data[:n]
+
+	if l.cfg.Tap != nil {
+		l.cfg.Tap(Capture{
+			Time:   time.Now(),
+			Local:  pc.LocalAddr(),
+			Remote: addr,
+			Data:   buf.data,
+		})
+	}
 
 	l.mu.RLock()
-	clt, ok := l.clts[addr.String()]
+	clt, ok := l.clts[cltKey{i, addr.String()}]
 	l.mu.RUnlock()
 	if !ok {
 		select {
@@ -170,42 +347,62 @@ func (l *Listener) processNetPkt() error {
 		default:
 		}
 
-		clt, err = l.add(addr)
+		if l.cfg.Migrate != nil {
+			if clt, ok = l.migrate(i, addr, buf.data); ok && clt == nil {
+				return nil
+			}
+		}
+	}
+	if !ok {
+		if !l.admit(addr, buf.data) {
+			return nil
+		}
+
+		clt, err = l.add(i, addr)
+		if err == errTooManyPending {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
 	}
 
 	select {
-	case clt.pkts <- buf[:n]:
+	case clt.pkts <- buf:
+		sent = true
 	case <-clt.closed:
 	}
 
 	return nil
 }
 
-func (l *Listener) add(addr net.Addr) (*udpClt, error) {
+func (l *Listener) add(pc int, addr net.Addr) (*udpClt, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
 	start := l.peerID
 	l.peerID++
-	for l.peerID < PeerIDCltMin || l.ids[l.peerID] {
+	for l.peerID < PeerIDCltMin || l.ids[l.peerID] != nil {
 		if l.peerID == start {
 			return nil, ErrOutOfPeerIDs
 		}
 		l.peerID++
 	}
-	l.ids[l.peerID] = true
 
 	clt := &udpClt{
 		l:      l,
 		id:     l.peerID,
+		pc:     pc,
 		addr:   addr,
-		pkts:   make(chan []byte),
+		pkts:   make(chan *udpBuf),
 		closed: make(chan struct{}),
 	}
-	l.clts[addr.String()] = clt
+	if err := l.pend(clt); err != nil {
+		return nil, err
+	}
+
+	l.ids[clt.id] = clt
+	l.clts[clt.key()] = clt
 
 	l.wg.Add(1)
 	go clt.mkConn()
@@ -0,0 +1,151 @@
+package rudp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// acceptN dials n Conns, accepts them
+// and discards the packets received by the clients.
+func acceptN(t *testing.T, l *Listener, dial func() *Conn, n int) (clts, srvs []*Conn) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		clt := dial()
+		t.Cleanup(func() { clt.Close() })
+		go func() {
+			for {
+				if _, err := clt.Recv(); errors.Is(err, net.ErrClosed) {
+					return
+				}
+			}
+		}()
+		go clt.sendRaw(func(buf []byte) int {
+			buf[0] = uint8(rawCtl)
+			buf[1] = uint8(ctlPing)
+			return 2
+		}, PktInfo{})(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		srv, err := l.AcceptContext(ctx)
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { srv.Close() })
+
+		clts = append(clts, clt)
+		srvs = append(srvs, srv)
+	}
+	return
+}
+
+func TestShutdown(t *testing.T) {
+	// The client taps record the order in which UDP packets arrive.
+	var mu sync.Mutex
+	recvd := make(map[string][][]byte)
+	tap := func(c Capture) {
+		if c.Sent {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		k := c.Local.String()
+		recvd[k] = append(recvd[k], append([]byte(nil), c.Data...))
+	}
+
+	l, dial := Pipe(Config{}, Config{Tap: tap}, PipeConfig{Latency: 100 * time.Millisecond})
+	clts, srvs := acceptN(t, l, dial, 3)
+
+	const n = 3
+	for _, srv := range srvs {
+		for i := 0; i < n; i++ {
+			ack, err := srv.Send(Pkt{Reader: strings.NewReader(fmt.Sprint("data ", i))})
+			if err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-ack:
+				t.Fatal("ack before Shutdown")
+			default:
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := l.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, clt := range clts {
+		select {
+		case <-clt.Closed():
+		case <-time.After(testTimeout):
+			t.Fatalf("client %d not disconnected", i)
+		}
+
+		mu.Lock()
+		pkts := recvd[clt.LocalAddr().String()]
+		mu.Unlock()
+
+		// All data must arrive before the disconnect.
+		got := 0
+		disco := false
+		for _, pkt := range pkts {
+			if bytes.Equal(pkt[7:], []byte{uint8(rawCtl), uint8(ctlDisco)}) {
+				disco = true
+			} else if bytes.Contains(pkt, []byte("data ")) {
+				if disco {
+					t.Errorf("client %d: %q after disconnect", i, pkt)
+				}
+				got++
+			}
+		}
+		if got != n || !disco {
+			t.Errorf("client %d: got %d data packets, disconnect %v", i, got, disco)
+		}
+	}
+	for i, srv := range srvs {
+		if srv.WhyClosed() != nil {
+			t.Errorf("server Conn %d: %v", i, srv.WhyClosed())
+		}
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	l, dial := Pipe(Config{}, Config{}, PipeConfig{})
+	clts, srvs := acceptN(t, l, dial, 2)
+
+	// Lose all packets from now on so nothing is ever acked.
+	pn := clts[0].udpConn.(*pipeClt).pn
+	pn.mu.Lock()
+	pn.cfg.Loss = 1
+	pn.mu.Unlock()
+
+	for _, srv := range srvs {
+		if _, err := srv.Send(Pkt{Reader: strings.NewReader("lost")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := l.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	for i, srv := range srvs {
+		select {
+		case <-srv.Closed():
+		default:
+			t.Errorf("server Conn %d not closed", i)
+		}
+	}
+}
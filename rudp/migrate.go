@@ -0,0 +1,68 @@
+package rudp
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// MigrateQuiet is how long MigrateSameIP requires
+// nothing to be received from a Conn's old address.
+const MigrateQuiet = time.Second
+
+// MigrateSameIP is a heuristic for Config.Migrate.
+// It lets a Conn move to another port of the same IP address,
+// as happens when a NAT rebinds, if nothing has been received
+// from the Conn for MigrateQuiet.
+func MigrateSameIP(c *Conn, addr net.Addr, pkt []byte) bool {
+	return host(addr) == host(c.RemoteAddr()) && c.sinceRecv() >= MigrateQuiet
+}
+
+// sinceRecv returns the time since the last UDP packet was received.
+func (c *Conn) sinceRecv() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&c.lastRecv)))
+}
+
+// migrate checks whether a UDP packet from the unknown address addr
+// received on l.pcs[pc] carries the PeerID of an accepted Conn.
+// If so, it returns the Conn's udpClt moved to addr,
+// or nil if Config.Migrate rejects the move.
+func (l *Listener) migrate(pc int, addr net.Addr, pkt []byte) (clt *udpClt, ok bool) {
+	if len(pkt) < 7 || be.Uint32(pkt[0:4]) != protoID {
+		return nil, false
+	}
+	id := PeerID(be.Uint16(pkt[4:6]))
+
+	var conn *Conn
+	l.mu.RLock()
+	clt = l.ids[id]
+	if clt != nil && !clt.pending {
+		conn = clt.conn
+	}
+	l.mu.RUnlock()
+	if conn == nil {
+		return nil, false
+	}
+
+	if !l.cfg.Migrate(conn, addr, pkt) {
+		return nil, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The Conn might have closed or another packet from addr
+	// might have been processed while Migrate was called.
+	key := cltKey{pc, addr.String()}
+	if l.ids[id] != clt || l.clts[key] != nil {
+		return nil, true
+	}
+
+	clt.mu.Lock()
+	delete(l.clts, clt.key())
+	clt.pc, clt.addr = pc, addr
+	clt.mu.Unlock()
+
+	l.clts[key] = clt
+	return clt, true
+}
@@ -0,0 +1,161 @@
+package rudp
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	pcapMagic   = 0xa1b2c3d4
+	pcapSnapLen = 0xffff
+	linkTypeRaw = 101 // Packets begin with an IPv4 or IPv6 header.
+)
+
+// A PcapWriter writes Captures to a pcap file
+// that Wireshark and tcpdump can read.
+// Since Captures only contain UDP payloads,
+// IP and UDP headers are synthesized from their addresses.
+// Addresses that are not *net.UDPAddrs are replaced by
+// 127.0.0.1:30000 for the local and 127.0.0.2:30000 for the remote address.
+//
+// All PcapWriter's methods are safe for concurrent use.
+type PcapWriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewPcapWriter writes a pcap file header to w
+// and returns a PcapWriter that writes packets to w.
+func NewPcapWriter(w io.Writer) (*PcapWriter, error) {
+	hdr := make([]byte, 24)
+	le.PutUint32(hdr[0:4], pcapMagic)
+	le.PutUint16(hdr[4:6], 2)
+	le.PutUint16(hdr[6:8], 4)
+	le.PutUint32(hdr[16:20], pcapSnapLen)
+	le.PutUint32(hdr[20:24], linkTypeRaw)
+	if _, err := w.Write(hdr); err != nil {
+		return nil, err
+	}
+
+	return &PcapWriter{w: w}, nil
+}
+
+var le = binary.LittleEndian
+
+// Tap writes c to the pcap file.
+// It can be used as Config.Tap.
+// Write errors are reported by Err.
+func (pw *PcapWriter) Tap(c Capture) {
+	src, dst := pcapAddr(c.Local, 1), pcapAddr(c.Remote, 2)
+	if !c.Sent {
+		src, dst = dst, src
+	}
+
+	pkt := ipUDPPkt(src, dst, c.Data)
+	if len(pkt) > pcapSnapLen {
+		pkt = pkt[:pcapSnapLen]
+	}
+
+	hdr := make([]byte, 16)
+	le.PutUint32(hdr[0:4], uint32(c.Time.Unix()))
+	le.PutUint32(hdr[4:8], uint32(c.Time.Nanosecond()/1000))
+	le.PutUint32(hdr[8:12], uint32(len(pkt)))
+	le.PutUint32(hdr[12:16], uint32(len(pkt)))
+
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if pw.err != nil {
+		return
+	}
+	if _, pw.err = pw.w.Write(hdr); pw.err != nil {
+		return
+	}
+	_, pw.err = pw.w.Write(pkt)
+}
+
+// Err returns the first error encountered while writing.
+func (pw *PcapWriter) Err() error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	return pw.err
+}
+
+func pcapAddr(addr net.Addr, fallback byte) *net.UDPAddr {
+	if addr, ok := addr.(*net.UDPAddr); ok {
+		return addr
+	}
+	return &net.UDPAddr{IP: net.IPv4(127, 0, 0, fallback), Port: 30000}
+}
+
+// ipUDPPkt returns an IPv4 or IPv6 packet containing a UDP packet.
+func ipUDPPkt(src, dst *net.UDPAddr, data []byte) []byte {
+	udpLen := 8 + len(data)
+
+	var pkt, pseudo []byte
+	if src4, dst4 := src.IP.To4(), dst.IP.To4(); src4 != nil && dst4 != nil {
+		pkt = make([]byte, 20+udpLen)
+		ip := pkt[:20]
+		ip[0] = 0x45 // Version 4, 5 * 4 byte header.
+		be.PutUint16(ip[2:4], uint16(len(pkt)))
+		be.PutUint16(ip[6:8], 0x4000) // Don't fragment.
+		ip[8] = 64                    // TTL.
+		ip[9] = 17                    // UDP.
+		copy(ip[12:16], src4)
+		copy(ip[16:20], dst4)
+		be.PutUint16(ip[10:12], ^onesSum(0, ip))
+
+		pseudo = make([]byte, 12)
+		copy(pseudo[0:4], src4)
+		copy(pseudo[4:8], dst4)
+		pseudo[9] = 17
+		be.PutUint16(pseudo[10:12], uint16(udpLen))
+	} else {
+		pkt = make([]byte, 40+udpLen)
+		ip := pkt[:40]
+		ip[0] = 0x60 // Version 6.
+		be.PutUint16(ip[4:6], uint16(udpLen))
+		ip[6] = 17 // UDP.
+		ip[7] = 64 // Hop limit.
+		copy(ip[8:24], src.IP.To16())
+		copy(ip[24:40], dst.IP.To16())
+
+		pseudo = make([]byte, 40)
+		copy(pseudo[0:32], ip[8:40])
+		be.PutUint32(pseudo[32:36], uint32(udpLen))
+		pseudo[39] = 17
+	}
+
+	udp := pkt[len(pkt)-udpLen:]
+	be.PutUint16(udp[0:2], uint16(src.Port))
+	be.PutUint16(udp[2:4], uint16(dst.Port))
+	be.PutUint16(udp[4:6], uint16(udpLen))
+	copy(udp[8:], data)
+
+	sum := ^onesSum(onesSum(0, pseudo), udp)
+	if sum == 0 {
+		sum = 0xffff
+	}
+	be.PutUint16(udp[6:8], sum)
+
+	return pkt
+}
+
+// onesSum adds b to the ones' complement sum s.
+func onesSum(s uint16, b []byte) uint16 {
+	sum := uint32(s)
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(be.Uint16(b[i:]))
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum > 0xffff {
+		sum = sum&0xffff + sum>>16
+	}
+	return uint16(sum)
+}
@@ -0,0 +1,229 @@
+package rudp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// checksum returns the internet checksum of the concatenation of bs.
+func checksum(bs ...[]byte) uint16 {
+	b := bytes.Join(bs, nil)
+	if len(b)%2 == 1 {
+		b = append(b, 0)
+	}
+
+	var sum uint32
+	for i := 0; i < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+func TestPcapWriter(t *testing.T) {
+	ts := time.Unix(1600000000, 123456789)
+	v4 := [2]*net.UDPAddr{
+		{IP: net.IPv4(192, 0, 2, 1), Port: 30000},
+		{IP: net.IPv4(198, 51, 100, 7), Port: 54321},
+	}
+	v6 := [2]*net.UDPAddr{
+		{IP: net.ParseIP("2001:db8::1"), Port: 30000},
+		{IP: net.ParseIP("2001:db8::2"), Port: 40000},
+	}
+	caps := []Capture{
+		{Sent: true, Time: ts, Local: v4[0], Remote: v4[1], Data: []byte("odd")},
+		{Time: ts, Local: v4[0], Remote: v4[1], Data: []byte("even")},
+		{Sent: true, Time: ts, Local: v6[0], Remote: v6[1], Data: []byte("odd")},
+		{Time: ts, Local: v6[0], Remote: v6[1], Data: bytes.Repeat([]byte{0xff}, 1000)},
+		{Sent: true, Time: ts, Local: pipeAddr(1), Remote: pipeAddr(2), Data: nil},
+	}
+
+	var b bytes.Buffer
+	pw, err := NewPcapWriter(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range caps {
+		pw.Tap(c)
+	}
+	if err := pw.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	hdr := b.Next(24)
+	if le.Uint32(hdr[0:4]) != 0xa1b2c3d4 ||
+		le.Uint16(hdr[4:6]) != 2 || le.Uint16(hdr[6:8]) != 4 ||
+		le.Uint32(hdr[16:20]) != 0xffff || le.Uint32(hdr[20:24]) != 101 {
+		t.Fatalf("bad global header: %x", hdr)
+	}
+
+	for i, c := range caps {
+		rec := b.Next(16)
+		if len(rec) < 16 {
+			t.Fatalf("capture %d: missing record", i)
+		}
+		if sec, usec := le.Uint32(rec[0:4]), le.Uint32(rec[4:8]); sec != 1600000000 || usec != 123456 {
+			t.Errorf("capture %d: timestamp %d.%06d", i, sec, usec)
+		}
+		incl, orig := le.Uint32(rec[8:12]), le.Uint32(rec[12:16])
+		if incl != orig {
+			t.Errorf("capture %d: included length %d != original length %d", i, incl, orig)
+		}
+		pkt := b.Next(int(incl))
+
+		local, remote := pcapAddr(c.Local, 1), pcapAddr(c.Remote, 2)
+		src, dst := local, remote
+		if !c.Sent {
+			src, dst = remote, local
+		}
+		udpLen := 8 + len(c.Data)
+
+		var udp, pseudo []byte
+		if src.IP.To4() != nil {
+			if len(pkt) != 20+udpLen {
+				t.Fatalf("capture %d: length %d, want %d", i, len(pkt), 20+udpLen)
+			}
+			ip := pkt[:20]
+			if ip[0] != 0x45 || ip[9] != 17 || int(be.Uint16(ip[2:4])) != len(pkt) {
+				t.Errorf("capture %d: bad IPv4 header: %x", i, ip)
+			}
+			if checksum(ip) != 0 {
+				t.Errorf("capture %d: bad IPv4 header checksum", i)
+			}
+			if !net.IP(ip[12:16]).Equal(src.IP) || !net.IP(ip[16:20]).Equal(dst.IP) {
+				t.Errorf("capture %d: %v -> %v, want %v -> %v", i, net.IP(ip[12:16]), net.IP(ip[16:20]), src.IP, dst.IP)
+			}
+
+			udp = pkt[20:]
+			pseudo = cat(ip[12:20], []byte{0, 17}, u16(uint16(udpLen)))
+		} else {
+			if len(pkt) != 40+udpLen {
+				t.Fatalf("capture %d: length %d, want %d", i, len(pkt), 40+udpLen)
+			}
+			ip := pkt[:40]
+			if ip[0]>>4 != 6 || ip[6] != 17 || int(be.Uint16(ip[4:6])) != udpLen {
+				t.Errorf("capture %d: bad IPv6 header: %x", i, ip)
+			}
+			if !net.IP(ip[8:24]).Equal(src.IP) || !net.IP(ip[24:40]).Equal(dst.IP) {
+				t.Errorf("capture %d: %v -> %v, want %v -> %v", i, net.IP(ip[8:24]), net.IP(ip[24:40]), src.IP, dst.IP)
+			}
+
+			udp = pkt[40:]
+			pseudo = cat(ip[8:40], []byte{0, 0}, u16(uint16(udpLen)), []byte{0, 0, 0, 17})
+		}
+
+		if int(be.Uint16(udp[0:2])) != src.Port || int(be.Uint16(udp[2:4])) != dst.Port {
+			t.Errorf("capture %d: bad UDP ports: %x", i, udp[0:4])
+		}
+		if int(be.Uint16(udp[4:6])) != udpLen {
+			t.Errorf("capture %d: UDP length %d, want %d", i, be.Uint16(udp[4:6]), udpLen)
+		}
+		if be.Uint16(udp[6:8]) == 0 || checksum(pseudo, udp) != 0 {
+			t.Errorf("capture %d: bad UDP checksum", i)
+		}
+		if !bytes.Equal(udp[8:], c.Data) {
+			t.Errorf("capture %d: got payload %q, want %q", i, udp[8:], c.Data)
+		}
+	}
+	if b.Len() > 0 {
+		t.Errorf("%d bytes of trailing data", b.Len())
+	}
+}
+
+// A tapLog records Captures.
+type tapLog struct {
+	mu         sync.Mutex
+	sent, recv []string
+}
+
+func (tl *tapLog) tap(c Capture) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	s := fmt.Sprintf("%x", c.Data)
+	if c.Sent {
+		tl.sent = append(tl.sent, s)
+	} else {
+		tl.recv = append(tl.recv, s)
+	}
+}
+
+// dirs returns the sorted payloads tl saw sent and received.
+func (tl *tapLog) dirs() (sent, recv []string) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	sent = append([]string(nil), tl.sent...)
+	recv = append([]string(nil), tl.recv...)
+	sort.Strings(sent)
+	sort.Strings(recv)
+	return
+}
+
+func TestListenerTap(t *testing.T) {
+	var ltap, ctap tapLog
+	l, dial := Pipe(Config{Tap: ltap.tap}, Config{Tap: ctap.tap}, PipeConfig{})
+	defer l.Close()
+
+	clt := dial()
+	defer clt.Close()
+
+	send := func(c *Conn, data string) {
+		t.Helper()
+		ack, err := c.Send(Pkt{Reader: strings.NewReader(data)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-ack:
+		case <-time.After(testTimeout):
+			t.Fatal("ack timeout")
+		}
+	}
+
+	go clt.Send(Pkt{Reader: strings.NewReader("hello")})
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	srv, err := l.AcceptContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	recvData(t, srv)
+	for i := 0; i < 5; i++ {
+		send(clt, fmt.Sprint("to srv ", i))
+		recvData(t, srv)
+		send(srv, fmt.Sprint("to clt ", i))
+		recvData(t, clt)
+	}
+
+	// The client taps packets after sending them,
+	// so they may be received before they are tapped.
+	deadline := time.Now().Add(testTimeout)
+	for {
+		csent, crecv := ctap.dirs()
+		lsent, lrecv := ltap.dirs()
+		if reflect.DeepEqual(lrecv, csent) && reflect.DeepEqual(lsent, crecv) {
+			if len(lrecv) == 0 || len(lsent) == 0 {
+				t.Fatal("no packets tapped")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("client sent %q, Listener received %q\nListener sent %q, client received %q",
+				csent, lrecv, lsent, crecv)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,272 @@
+package rudp
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A PipeConfig configures the simulated network used by Pipe.
+// The zero value is a network that does not lose, duplicate, reorder
+// or delay UDP packets, except that, like a real network,
+// it drops them while the receiver's buffer of 256 packets is full.
+type PipeConfig struct {
+	// Loss, Dup and Reorder are the probabilities of a UDP packet
+	// being dropped, duplicated or delayed so that later packets overtake it.
+	Loss, Dup, Reorder float64
+
+	// Latency is added to every UDP packet,
+	// Jitter is the maximum random latency added on top of it.
+	Latency, Jitter time.Duration
+
+	// Seed seeds the pseudo-random decisions above.
+	Seed int64
+}
+
+// pipeQueueLen is the number of UDP packets a pipe endpoint buffers.
+// Like a real network, the pipe drops packets if a buffer is full.
+const pipeQueueLen = 256
+
+var errPipeDeadline = errors.New("pipe: deadlines not supported")
+
+// Pipe returns a Listener and a function that returns new Conns
+// connected to it through an in-memory network instead of sockets.
+// The Listener and its Conns use lcfg, the dialed Conns use ccfg.
+func Pipe(lcfg, ccfg Config, pcfg PipeConfig) (l *Listener, dial func() *Conn) {
+	pn := newPipeNet(pcfg)
+	ccfg = ccfg.withDefaults()
+	return ListenConfig(pn.srv, lcfg), func() *Conn {
+		return newConn(tapped(pn.dial(), ccfg.Tap), PeerIDSrv, PeerIDNil, ccfg)
+	}
+}
+
+func newPipeNet(cfg PipeConfig) *pipeNet {
+	pn := &pipeNet{
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(cfg.Seed)),
+		clts: make(map[string]*pipeClt),
+	}
+	pn.srv = &pipeSrv{
+		pn:     pn,
+		pkts:   make(chan pipePkt, pipeQueueLen),
+		closed: make(chan struct{}),
+	}
+	return pn
+}
+
+// PipeConn returns a client Conn and the server Conn accepted for it,
+// connected through an in-memory network configured by pcfg.
+func PipeConn(cfg Config, pcfg PipeConfig) (clt, srv *Conn, err error) {
+	l, dial := Pipe(cfg, cfg, pcfg)
+	defer l.Close()
+
+	clt = dial()
+
+	// Make the Listener notice the client.
+	go clt.sendRaw(func(buf []byte) int {
+		buf[0] = uint8(rawCtl)
+		buf[1] = uint8(ctlPing)
+		return 2
+	}, PktInfo{})(context.Background())
+
+	srv, err = l.Accept()
+	if err != nil {
+		clt.Close()
+		return nil, nil, err
+	}
+	return clt, srv, nil
+}
+
+type pipeAddr int
+
+func (pipeAddr) Network() string  { return "pipe" }
+func (a pipeAddr) String() string { return "pipe:" + strconv.Itoa(int(a)) }
+
+type pipePkt struct {
+	buf  *udpBuf
+	from net.Addr
+}
+
+type pipeNet struct {
+	cfg PipeConfig
+	srv *pipeSrv
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	lastAddr pipeAddr
+	clts     map[string]*pipeClt
+}
+
+func (pn *pipeNet) dial() *pipeClt {
+	pn.mu.Lock()
+	defer pn.mu.Unlock()
+
+	pn.lastAddr++
+	c := &pipeClt{
+		pn:     pn,
+		addr:   pn.lastAddr,
+		pkts:   make(chan pipePkt, pipeQueueLen),
+		closed: make(chan struct{}),
+	}
+	pn.clts[c.addr.String()] = c
+	return c
+}
+
+// send sends copies of data to dest, simulating the configured network.
+func (pn *pipeNet) send(dest chan<- pipePkt, closed <-chan struct{}, data []byte, from net.Addr) {
+	pn.mu.Lock()
+	if pn.rand.Float64() < pn.cfg.Loss {
+		pn.mu.Unlock()
+		return
+	}
+	n := 1
+	if pn.rand.Float64() < pn.cfg.Dup {
+		n = 2
+	}
+	delays := make([]time.Duration, n)
+	for i := range delays {
+		d := pn.cfg.Latency
+		if pn.cfg.Jitter > 0 {
+			d += time.Duration(pn.rand.Int63n(int64(pn.cfg.Jitter)))
+		}
+		if pn.rand.Float64() < pn.cfg.Reorder {
+			d += 2*(pn.cfg.Latency+pn.cfg.Jitter) + time.Millisecond
+		}
+		delays[i] = d
+	}
+	pn.mu.Unlock()
+
+	for _, d := range delays {
+		// Every copy needs its own buffer because the receiver releases it.
+		pkt := pipePkt{newUDPBuf(len(data)), from}
+		copy(pkt.buf.data, data)
+
+		deliver := func() {
+			select {
+			case <-closed:
+			case dest <- pkt:
+				return
+			default:
+				// Queue full.
+			}
+			pkt.buf.release()
+		}
+		if d > 0 {
+			time.AfterFunc(d, deliver)
+		} else {
+			deliver()
+		}
+	}
+}
+
+// pipeSrv is the server side of a pipeNet.
+type pipeSrv struct {
+	pn     *pipeNet
+	pkts   chan pipePkt
+	closed chan struct{}
+}
+
+func (s *pipeSrv) ReadFrom(buf []byte) (int, net.Addr, error) {
+	select {
+	case pkt := <-s.pkts:
+		n := copy(buf, pkt.buf.data)
+		pkt.buf.release()
+		return n, pkt.from, nil
+	case <-s.closed:
+		return 0, nil, net.ErrClosed
+	}
+}
+
+func (s *pipeSrv) WriteTo(pkt []byte, addr net.Addr) (int, error) {
+	select {
+	case <-s.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	s.pn.mu.Lock()
+	c := s.pn.clts[addr.String()]
+	s.pn.mu.Unlock()
+
+	if c != nil {
+		s.pn.send(c.pkts, c.closed, pkt, s.LocalAddr())
+	}
+	return len(pkt), nil
+}
+
+func (s *pipeSrv) Close() error {
+	if !tryClose(s.closed) {
+		return net.ErrClosed
+	}
+	return nil
+}
+
+func (s *pipeSrv) LocalAddr() net.Addr { return pipeAddr(0) }
+
+func (s *pipeSrv) SetDeadline(time.Time) error      { return errPipeDeadline }
+func (s *pipeSrv) SetReadDeadline(time.Time) error  { return errPipeDeadline }
+func (s *pipeSrv) SetWriteDeadline(time.Time) error { return errPipeDeadline }
+
+// pipeClt is the client side of a pipeNet.
+type pipeClt struct {
+	pn     *pipeNet
+	addr   pipeAddr // Protected by pn.mu.
+	pkts   chan pipePkt
+	closed chan struct{}
+}
+
+// rebind gives c a new address, like a NAT rebinding would.
+func (c *pipeClt) rebind() {
+	c.pn.mu.Lock()
+	defer c.pn.mu.Unlock()
+
+	delete(c.pn.clts, c.addr.String())
+	c.pn.lastAddr++
+	c.addr = c.pn.lastAddr
+	c.pn.clts[c.addr.String()] = c
+}
+
+func (c *pipeClt) recvUDP() (*udpBuf, error) {
+	select {
+	case pkt := <-c.pkts:
+		return pkt.buf, nil
+	case <-c.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (c *pipeClt) Write(pkt []byte) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	c.pn.send(c.pn.srv.pkts, c.pn.srv.closed, pkt, c.LocalAddr())
+	return len(pkt), nil
+}
+
+func (c *pipeClt) Close() error {
+	if !tryClose(c.closed) {
+		return net.ErrClosed
+	}
+
+	c.pn.mu.Lock()
+	delete(c.pn.clts, c.addr.String())
+	c.pn.mu.Unlock()
+
+	return nil
+}
+
+func (c *pipeClt) LocalAddr() net.Addr {
+	c.pn.mu.Lock()
+	defer c.pn.mu.Unlock()
+
+	return c.addr
+}
+
+func (c *pipeClt) RemoteAddr() net.Addr { return c.pn.srv.LocalAddr() }
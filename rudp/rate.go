@@ -0,0 +1,66 @@
+package rudp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// A tokenBucket limits the rate at which bytes are sent.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // bytes per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until n bytes may be sent.
+// It returns ctx.Err() if ctx is done first
+// or net.ErrClosed if closed is closed first.
+func (tb *tokenBucket) wait(ctx context.Context, n int, closed <-chan struct{}) error {
+	tb.mu.Lock()
+	now := time.Now()
+	tb.tokens += tb.rate * now.Sub(tb.last).Seconds()
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.last = now
+
+	// Reserve the tokens so concurrent senders queue up behind each other.
+	tb.tokens -= float64(n)
+	d := time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+	tb.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	var err error
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-closed:
+		err = net.ErrClosed
+	}
+
+	tb.mu.Lock()
+	tb.tokens += float64(n)
+	tb.mu.Unlock()
+	return err
+}
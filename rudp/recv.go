@@ -1,16 +1,23 @@
 package rudp
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net"
+	"sync/atomic"
 	"time"
 )
 
 // Recv receives a Pkt from the Conn.
 func (c *Conn) Recv() (Pkt, error) {
+	return c.RecvContext(context.Background())
+}
+
+// RecvContext is like Recv but returns ctx.Err() if ctx is done
+// before a Pkt is received.
+func (c *Conn) RecvContext(ctx context.Context) (Pkt, error) {
 	select {
 	case pkt := <-c.pkts:
 		return pkt, nil
@@ -18,6 +25,8 @@ func (c *Conn) Recv() (Pkt, error) {
 		return Pkt{}, err
 	case <-c.Closed():
 		return Pkt{}, net.ErrClosed
+	case <-ctx.Done():
+		return Pkt{}, ctx.Err()
 	}
 }
 
@@ -29,6 +38,8 @@ func (c *Conn) gotPkt(pkt Pkt) {
 }
 
 func (c *Conn) gotErr(kind string, data []byte, err error) {
+	inc(&c.stats.Errs)
+
 	select {
 	case c.errs <- fmt.Errorf("%s: %x: %w", kind, data, err):
 	case <-c.Closed():
@@ -37,41 +48,55 @@ func (c *Conn) gotErr(kind string, data []byte, err error) {
 
 func (c *Conn) recvUDPPkts() {
 	for {
-		pkt, err := c.udpConn.recvUDP()
+		buf, err := c.udpConn.recvUDP()
 		if err != nil {
 			c.closeDisco(err)
 			break
 		}
 
-		if err := c.processUDPPkt(pkt); err != nil {
-			c.gotErr("udp", pkt, err)
+		kept, err := c.processUDPPkt(buf)
+		if err != nil {
+			c.gotErr("udp", buf.data, err)
+		}
+		if !kept {
+			buf.release()
 		}
 	}
 }
 
-func (c *Conn) processUDPPkt(pkt []byte) error {
+// processUDPPkt processes the UDP packet in buf.
+// It reports whether buf is still in use and must not be released.
+func (c *Conn) processUDPPkt(buf *udpBuf) (kept bool, err error) {
+	pkt := buf.data
+
 	if c.timeout.Stop() {
-		c.timeout.Reset(ConnTimeout)
+		c.timeout.Reset(c.cfg.ConnTimeout)
 	}
+	atomic.StoreInt64(&c.lastRecv, time.Now().UnixNano())
 
-	if len(pkt) < 6 {
-		return io.ErrUnexpectedEOF
+	if len(pkt) < 7 {
+		return false, io.ErrUnexpectedEOF
 	}
 
 	if id := be.Uint32(pkt[0:4]); id != protoID {
-		return fmt.Errorf("unsupported protocol id: 0x%08x", id)
+		return false, fmt.Errorf("unsupported protocol id: 0x%08x", id)
 	}
 
 	ch := Channel(pkt[6])
 	if ch >= ChannelCount {
-		return TooBigChError(ch)
+		return false, TooBigChError(ch)
 	}
 
-	if err := c.processRawPkt(pkt[7:], PktInfo{Channel: ch, Unrel: true}); err != nil {
+	st := &c.stats.Chans[ch]
+	inc(&st.PktsRecvd)
+	add(&st.BytesRecvd, len(pkt))
+
+	kept, err = c.processRawPkt(pkt[7:], PktInfo{Channel: ch, Unrel: true}, buf)
+	if err != nil {
 		c.gotErr("raw", pkt, err)
 	}
 
-	return nil
+	return kept, nil
 }
 
 // A TrailingDataError reports trailing data after a packet.
@@ -81,18 +106,22 @@ func (e TrailingDataError) Error() string {
 	return fmt.Sprintf("trailing data: %x", []byte(e))
 }
 
-func (c *Conn) processRawPkt(data []byte, pi PktInfo) (err error) {
+// errEOFPanic is panicked by processRawPkt when data ends unexpectedly.
+var errEOFPanic = new(byte)
+
+// processRawPkt processes data, which is part of buf.
+// It reports whether buf is still in use and must not be released.
+func (c *Conn) processRawPkt(data []byte, pi PktInfo, buf *udpBuf) (kept bool, err error) {
 	errWrap := func(format string, a ...interface{}) {
 		if err != nil {
 			err = fmt.Errorf(format+": %w", append(a, err)...)
 		}
 	}
 
-	eof := new(byte)
 	defer func() {
 		switch r := recover(); r {
 		case nil:
-		case eof:
+		case errEOFPanic:
 			err = io.ErrUnexpectedEOF
 		default:
 			panic(r)
@@ -103,13 +132,14 @@ func (c *Conn) processRawPkt(data []byte, pi PktInfo) (err error) {
 	eat := func(n int) []byte {
 		i := off
 		off += n
-		if i > len(data) {
-			panic(eof)
+		if off > len(data) {
+			panic(errEOFPanic)
 		}
 		return data[i:off]
 	}
 
 	ch := &c.chans[pi.Channel]
+	st := &c.stats.Chans[pi.Channel]
 
 	switch t := rawType(eat(1)[0]); t {
 	case rawCtl:
@@ -123,16 +153,20 @@ func (c *Conn) processRawPkt(data []byte, pi PktInfo) (err error) {
 
 			if ack, ok := ch.ackChans.LoadAndDelete(sn); ok {
 				close(ack.(chan struct{}))
+				dec(&st.AcksOutstanding)
 			}
 		case ctlSetPeerID:
 			defer errWrap("set peer id")
 
+			id := PeerID(be.Uint16(eat(2)))
+
 			c.mu.Lock()
 			if c.remoteID != PeerIDNil {
-				return errors.New("peer id already set")
+				c.mu.Unlock()
+				return false, errors.New("peer id already set")
 			}
 
-			c.remoteID = PeerID(be.Uint16(eat(2)))
+			c.remoteID = id
 			c.mu.Unlock()
 
 			c.newAckBuf()
@@ -143,17 +177,18 @@ func (c *Conn) processRawPkt(data []byte, pi PktInfo) (err error) {
 
 			c.close(nil)
 		default:
-			return fmt.Errorf("unsupported ctl type: %d", ct)
+			return false, fmt.Errorf("unsupported ctl type: %d", ct)
 		}
 
 		if off < len(data) {
-			return TrailingDataError(data[off:])
+			return false, TrailingDataError(append([]byte(nil), data[off:]...))
 		}
 	case rawOrig:
 		c.gotPkt(Pkt{
-			Reader:  bytes.NewReader(data[off:]),
+			Reader:  newLentReader(data[off:], buf),
 			PktInfo: pi,
 		})
+		return true, nil
 	case rawSplit:
 		defer errWrap("split")
 
@@ -164,56 +199,27 @@ func (c *Conn) processRawPkt(data []byte, pi PktInfo) (err error) {
 		defer errWrap("%d", sn)
 
 		if i >= n {
-			return fmt.Errorf("chunk number (%d) > chunk count (%d)", i, n)
+			return false, fmt.Errorf("chunk number (%d) > chunk count (%d)", i, n)
 		}
 
-		ch.inSplitsMu.RLock()
-		s := ch.inSplits[sn]
-		ch.inSplitsMu.RUnlock()
-
-		if s == nil {
-			s = &inSplit{chunks: make([][]byte, n)}
-			if pi.Unrel {
-				s.timeout = time.AfterFunc(ConnTimeout, func() {
-					ch.inSplitsMu.Lock()
-					delete(ch.inSplits, sn)
-					ch.inSplitsMu.Unlock()
-				})
-			}
-
-			ch.inSplitsMu.Lock()
-			ch.inSplits[sn] = s
-			ch.inSplitsMu.Unlock()
-		}
-
-		if int(n) != len(s.chunks) {
-			return fmt.Errorf("chunk count changed from %d to %d", len(s.chunks), n)
-		}
-
-		if s.chunks[i] == nil {
-			s.chunks[i] = data[off:]
-			s.got++
-		}
-
-		if s.got < len(s.chunks) {
-			if s.timeout != nil && s.timeout.Stop() {
-				s.timeout.Reset(ConnTimeout)
+		r, kept, err := c.addChunk(pi, sn, n, i, data[off:], buf)
+		if err != nil {
+			if !pi.Unrel && isSplitLimit(err) {
+				c.closeDisco(err)
 			}
-			return
+			return false, err
 		}
-
-		if s.timeout != nil {
-			s.timeout.Stop()
+		if r == nil {
+			return kept, nil
 		}
 
-		ch.inSplitsMu.Lock()
-		delete(ch.inSplits, sn)
-		ch.inSplitsMu.Unlock()
+		inc(&st.SplitsDone)
 
 		c.gotPkt(Pkt{
-			Reader:  (*net.Buffers)(&s.chunks),
+			Reader:  r,
 			PktInfo: pi,
 		})
+		return true, nil
 	case rawRel:
 		defer errWrap("rel")
 
@@ -221,39 +227,32 @@ func (c *Conn) processRawPkt(data []byte, pi PktInfo) (err error) {
 
 		defer errWrap("%d", sn)
 
-		be.PutUint16(ch.ackBuf, uint16(sn))
-		ch.sendAck()
+		c.ack(pi.Channel, sn)
 
-		if sn-ch.inRelSN >= 0x8000 {
+		if sn-ch.inRelSN >= MaxRelWindow || ch.inRels[sn%MaxRelWindow].data != nil {
 			// Already received.
-			return nil
+			inc(&st.DupsDropped)
+			return false, nil
 		}
 
-		ch.inRels[sn&0x7fff] = data[off:]
+		ch.inRels[sn%MaxRelWindow] = inRel{data[off:], buf}
 
-		i := func() seqnum { return ch.inRelSN & 0x7fff }
-		for ; ch.inRels[i()] != nil; ch.inRelSN++ {
-			data := ch.inRels[i()]
-			ch.inRels[i()] = nil
-			if err := c.processRawPkt(data, PktInfo{Channel: pi.Channel}); err != nil {
-				c.gotErr("rel", data, err)
+		i := func() seqnum { return ch.inRelSN % MaxRelWindow }
+		for ; ch.inRels[i()].data != nil; ch.inRelSN++ {
+			rel := ch.inRels[i()]
+			ch.inRels[i()] = inRel{}
+			kept, err := c.processRawPkt(rel.data, PktInfo{Channel: pi.Channel}, rel.buf)
+			if err != nil {
+				c.gotErr("rel", rel.data, err)
+			}
+			if !kept {
+				rel.buf.release()
 			}
 		}
+		return true, nil
 	default:
-		return fmt.Errorf("unsupported pkt type: %d", t)
+		return false, fmt.Errorf("unsupported pkt type: %d", t)
 	}
 
-	return nil
-}
-
-func (c *Conn) newAckBuf() {
-	for i := range c.chans {
-		ch := &c.chans[i]
-		ch.sendAck = c.sendRaw(func(buf []byte) int {
-			buf[0] = uint8(rawCtl)
-			buf[1] = uint8(ctlAck)
-			ch.ackBuf = buf[2:4]
-			return 4
-		}, PktInfo{Channel: Channel(i), Unrel: true})
-	}
+	return false, nil
 }
@@ -0,0 +1,72 @@
+package rudp
+
+import (
+	"sync"
+	"time"
+)
+
+// rttEstimator calculates the resend timeout from measured round-trip times
+// as described in RFC 6298.
+type rttEstimator struct {
+	mu     sync.Mutex
+	srtt   time.Duration
+	rttvar time.Duration
+	rto    time.Duration
+}
+
+// RTT returns the smoothed round-trip time of the Conn
+// or 0 if it has not been measured yet.
+func (c *Conn) RTT() time.Duration {
+	c.rtt.mu.Lock()
+	defer c.rtt.mu.Unlock()
+
+	return c.rtt.srtt
+}
+
+// resendTimeout returns how long to wait for an ack
+// before resending a packet for the first time.
+func (c *Conn) resendTimeout() time.Duration {
+	c.rtt.mu.Lock()
+	defer c.rtt.mu.Unlock()
+
+	if c.rtt.rto == 0 {
+		return c.clampRTO(c.cfg.ResendTimeout)
+	}
+	return c.rtt.rto
+}
+
+// backoff returns the resend timeout to use after rto expired.
+func (c *Conn) backoff(rto time.Duration) time.Duration {
+	return c.clampRTO(2 * rto)
+}
+
+// gotRTT updates the estimate with a round-trip time measured
+// using a packet that was not resent.
+func (c *Conn) gotRTT(r time.Duration) {
+	c.rtt.mu.Lock()
+	defer c.rtt.mu.Unlock()
+
+	e := &c.rtt
+	if e.srtt == 0 {
+		e.srtt = r
+		e.rttvar = r / 2
+	} else {
+		d := e.srtt - r
+		if d < 0 {
+			d = -d
+		}
+		e.rttvar = (3*e.rttvar + d) / 4
+		e.srtt = (7*e.srtt + r) / 8
+	}
+	e.rto = c.clampRTO(e.srtt + 4*e.rttvar)
+}
+
+func (c *Conn) clampRTO(rto time.Duration) time.Duration {
+	if rto < c.cfg.MinResendTimeout {
+		return c.cfg.MinResendTimeout
+	}
+	if rto > c.cfg.MaxResendTimeout {
+		return c.cfg.MaxResendTimeout
+	}
+	return rto
+}
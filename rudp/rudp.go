@@ -85,6 +85,7 @@ const (
 	ctlDisco
 )
 
+// Reading a received Pkt until io.EOF lets its buffers be reused.
 type Pkt struct {
 	io.Reader
 	PktInfo
@@ -0,0 +1,1042 @@
+package rudp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testTimeout = 5 * time.Second
+
+// fakeUDP is a udpConn controlled by a test.
+type fakeUDP struct {
+	in     chan []byte
+	out    chan []byte
+	closed chan struct{}
+}
+
+func newFakeUDP() *fakeUDP {
+	return &fakeUDP{
+		in:     make(chan []byte),
+		out:    make(chan []byte, 1024),
+		closed: make(chan struct{}),
+	}
+}
+
+func (f *fakeUDP) recvUDP() (*udpBuf, error) {
+	select {
+	case pkt := <-f.in:
+		// Copy like reading from a socket would.
+		buf := newUDPBuf(len(pkt))
+		copy(buf.data, pkt)
+		return buf, nil
+	case <-f.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (f *fakeUDP) Write(pkt []byte) (int, error) {
+	select {
+	case <-f.closed:
+		return 0, net.ErrClosed
+	case f.out <- append([]byte(nil), pkt...):
+	default:
+	}
+	return len(pkt), nil
+}
+
+func (f *fakeUDP) Close() error {
+	if !tryClose(f.closed) {
+		return net.ErrClosed
+	}
+	return nil
+}
+
+func (f *fakeUDP) LocalAddr() net.Addr  { return pipeAddr(1) }
+func (f *fakeUDP) RemoteAddr() net.Addr { return pipeAddr(0) }
+
+// newTestConn returns a client Conn whose peer is simulated by the test.
+func newTestConn(t testing.TB, cfg Config) (*Conn, *fakeUDP) {
+	f := newFakeUDP()
+	c := newConn(f, PeerIDSrv, PeerIDNil, cfg.withDefaults())
+	t.Cleanup(func() { c.Close() })
+	return c, f
+}
+
+func (f *fakeUDP) send(pkts ...[]byte) error {
+	for _, pkt := range pkts {
+		select {
+		case f.in <- pkt:
+		case <-f.closed:
+			return nil
+		case <-time.After(testTimeout):
+			return errors.New("Conn stopped receiving")
+		}
+	}
+	return nil
+}
+
+// feed makes the Conn receive pkts.
+// It returns early if the Conn is closed.
+func (f *fakeUDP) feed(t testing.TB, pkts ...[]byte) {
+	t.Helper()
+	if err := f.send(pkts...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// goFeed is like feed but doesn't wait for the Conn to receive pkts.
+func (f *fakeUDP) goFeed(t testing.TB, pkts ...[]byte) {
+	go func() {
+		if err := f.send(pkts...); err != nil {
+			t.Error(err)
+		}
+	}()
+}
+
+func (f *fakeUDP) next(t testing.TB) []byte {
+	t.Helper()
+	select {
+	case pkt := <-f.out:
+		return pkt
+	case <-time.After(testTimeout):
+		t.Fatal("nothing sent")
+		return nil
+	}
+}
+
+func recvData(t testing.TB, c *Conn) ([]byte, PktInfo) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	pkt, err := c.RecvContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(pkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data, pkt.PktInfo
+}
+
+func recvErr(t testing.TB, c *Conn) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	pkt, err := c.RecvContext(ctx)
+	if err == nil {
+		t.Fatalf("got pkt %v, want error", pkt)
+	}
+	return err
+}
+
+func cat(bufs ...[]byte) []byte { return bytes.Join(bufs, nil) }
+
+func u16(x uint16) []byte {
+	b := make([]byte, 2)
+	be.PutUint16(b, x)
+	return b
+}
+
+func udpPkt(ch Channel, raw ...[]byte) []byte {
+	hdr := make([]byte, 7)
+	be.PutUint32(hdr[0:4], protoID)
+	be.PutUint16(hdr[4:6], uint16(PeerIDSrv))
+	hdr[6] = uint8(ch)
+	return cat(append([][]byte{hdr}, raw...)...)
+}
+
+func origPkt(data string) []byte { return cat([]byte{uint8(rawOrig)}, []byte(data)) }
+
+func relPkt(sn seqnum, raw []byte) []byte {
+	return cat([]byte{uint8(rawRel)}, u16(uint16(sn)), raw)
+}
+
+func splitPkt(sn seqnum, n, i uint16, data string) []byte {
+	return cat([]byte{uint8(rawSplit)}, u16(uint16(sn)), u16(n), u16(i), []byte(data))
+}
+
+func ctlPkt(ct ctlType, data ...byte) []byte {
+	return cat([]byte{uint8(rawCtl), uint8(ct)}, data)
+}
+
+func ackPkt(sn seqnum) []byte {
+	return ctlPkt(ctlAck, u16(uint16(sn))...)
+}
+
+func TestSendRel(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	ack, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("hello"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := cat(
+		u16(0x4f45), u16(0x7403), u16(uint16(PeerIDNil)), []byte{0},
+		relPkt(initSeqnum, origPkt("hello")),
+	)
+	if got := f.next(t); !bytes.Equal(got, want) {
+		t.Fatalf("sent %x, want %x", got, want)
+	}
+
+	if got := c.Stats().Chans[0].AcksOutstanding; got != 1 {
+		t.Errorf("AcksOutstanding = %d, want 1", got)
+	}
+
+	f.feed(t, udpPkt(0, ackPkt(initSeqnum)))
+	select {
+	case <-ack:
+	case <-time.After(testTimeout):
+		t.Fatal("ack not closed")
+	}
+
+	if got := c.Stats().Chans[0].AcksOutstanding; got != 0 {
+		t.Errorf("AcksOutstanding = %d, want 0", got)
+	}
+}
+
+func TestResend(t *testing.T) {
+	c, f := newTestConn(t, Config{
+		ResendTimeout:    10 * time.Millisecond,
+		MinResendTimeout: 10 * time.Millisecond,
+	})
+
+	if _, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("x"))}); err != nil {
+		t.Fatal(err)
+	}
+
+	first := f.next(t)
+	if again := f.next(t); !bytes.Equal(first, again) {
+		t.Fatalf("resent %x, want %x", again, first)
+	}
+	if c.Stats().Chans[0].Resends == 0 {
+		t.Error("Resends = 0")
+	}
+}
+
+func TestRTT(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	if c.RTT() != 0 {
+		t.Fatalf("RTT = %v before measuring", c.RTT())
+	}
+
+	ack, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("x"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.next(t)
+	time.Sleep(20 * time.Millisecond)
+	f.feed(t, udpPkt(0, ackPkt(initSeqnum)))
+	<-ack
+
+	deadline := time.Now().Add(testTimeout)
+	for c.RTT() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("RTT not measured")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if rtt := c.RTT(); rtt < 20*time.Millisecond {
+		t.Errorf("RTT = %v, want >= 20ms", rtt)
+	}
+}
+
+func TestRTTExcludesRateLimit(t *testing.T) {
+	// The packet waits about 100ms for MaxBytesPerSec.
+	c, f := newTestConn(t, Config{MaxBytesPerSec: 100, Burst: 1})
+
+	ack, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("x"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.next(t)
+	f.feed(t, udpPkt(0, ackPkt(initSeqnum)))
+	<-ack
+
+	deadline := time.Now().Add(testTimeout)
+	for c.RTT() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("RTT not measured")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if rtt := c.RTT(); rtt >= 50*time.Millisecond {
+		t.Errorf("RTT = %v includes the time waiting for MaxBytesPerSec", rtt)
+	}
+}
+
+func TestRecvRelWraparound(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	sn := initSeqnum
+	for i := 0; i < 100; i++ {
+		data := string(rune('a' + i%26))
+		f.feed(t, udpPkt(1, relPkt(sn, origPkt(data))))
+
+		if got, want := f.next(t), udpPkt(1, ackPkt(sn)); !bytes.Equal(got[7:], want[7:]) {
+			t.Fatalf("sent %x, want ack %x", got, want)
+		}
+
+		got, pi := recvData(t, c)
+		if string(got) != data || pi != (PktInfo{Channel: 1}) {
+			t.Fatalf("%d: got %q %+v, want %q on reliable channel 1", sn, got, pi, data)
+		}
+
+		sn++
+	}
+	if sn > initSeqnum {
+		t.Fatal("seqnum did not wrap around")
+	}
+}
+
+func TestRecvRelOutOfOrder(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	f.feed(t,
+		udpPkt(0, relPkt(initSeqnum+2, origPkt("c"))),
+		udpPkt(0, relPkt(initSeqnum+1, origPkt("b"))),
+		udpPkt(0, relPkt(initSeqnum+1, origPkt("b"))), // Duplicate.
+	)
+
+	f.goFeed(t, udpPkt(0, relPkt(initSeqnum, origPkt("a"))))
+	for _, want := range []string{"a", "b", "c"} {
+		if got, _ := recvData(t, c); string(got) != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+
+	// Already delivered.
+	f.feed(t, udpPkt(0, relPkt(initSeqnum, origPkt("a"))))
+	f.feed(t, udpPkt(0, relPkt(initSeqnum+3, origPkt("d"))))
+	if got, _ := recvData(t, c); string(got) != "d" {
+		t.Fatalf("got %q, want %q", got, "d")
+	}
+
+	if got := c.Stats().Chans[0].DupsDropped; got != 2 {
+		t.Errorf("DupsDropped = %d, want 2", got)
+	}
+
+	// Every reliable packet is acked, including duplicates.
+	var acks []seqnum
+	for len(acks) < 6 {
+		pkt := f.next(t)
+		if pkt[7] == uint8(rawCtl) && pkt[8] == uint8(ctlAck) {
+			acks = append(acks, seqnum(be.Uint16(pkt[9:11])))
+		}
+	}
+	want := []seqnum{initSeqnum + 2, initSeqnum + 1, initSeqnum + 1, initSeqnum, initSeqnum, initSeqnum + 3}
+	for i := range want {
+		if acks[i] != want[i] {
+			t.Fatalf("acks = %v, want %v", acks, want)
+		}
+	}
+}
+
+func TestAckDelayDedup(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	c, f := newTestConn(t, Config{AckDelay: delay})
+
+	start := time.Now()
+	f.feed(t,
+		udpPkt(0, relPkt(initSeqnum+1, origPkt("b"))),
+		udpPkt(0, relPkt(initSeqnum+1, origPkt("b"))), // Duplicate.
+		udpPkt(0, relPkt(initSeqnum+2, origPkt("c"))),
+	)
+
+	nextAck := func() seqnum {
+		t.Helper()
+		pkt := f.next(t)
+		if pkt[7] != uint8(rawCtl) || pkt[8] != uint8(ctlAck) {
+			t.Fatalf("sent %x, want ack", pkt)
+		}
+		return seqnum(be.Uint16(pkt[9:11]))
+	}
+
+	// The duplicate is acked once,
+	// but distinct seqnums still get an ack each.
+	got := map[seqnum]bool{nextAck(): true, nextAck(): true}
+	if time.Since(start) < delay {
+		t.Error("acks not delayed")
+	}
+	if !got[initSeqnum+1] || !got[initSeqnum+2] {
+		t.Errorf("acked %v, want %d and %d", got, initSeqnum+1, initSeqnum+2)
+	}
+
+	// A retransmission crossing the ack is not acked again.
+	f.feed(t, udpPkt(0, relPkt(initSeqnum+2, origPkt("c"))))
+	select {
+	case pkt := <-f.out:
+		t.Fatalf("sent %x, want nothing", pkt)
+	case <-time.After(2 * delay):
+	}
+
+	if got := c.Stats().Chans[0].DupAcksDropped; got != 2 {
+		t.Errorf("DupAcksDropped = %d, want 2", got)
+	}
+
+	// Later ones are, in case the ack was lost.
+	f.feed(t, udpPkt(0, relPkt(initSeqnum+2, origPkt("c"))))
+	if sn := nextAck(); sn != initSeqnum+2 {
+		t.Errorf("acked %d, want %d", sn, initSeqnum+2)
+	}
+}
+
+func TestRecvSplit(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	// Unreliable, out of order with a duplicate chunk.
+	f.feed(t,
+		udpPkt(2, splitPkt(7, 3, 2, "ghi")),
+		udpPkt(2, splitPkt(7, 3, 0, "abc")),
+		udpPkt(2, splitPkt(7, 3, 0, "abc")),
+	)
+	f.goFeed(t, udpPkt(2, splitPkt(7, 3, 1, "def")))
+	if got, pi := recvData(t, c); string(got) != "abcdefghi" || pi != (PktInfo{2, true}) {
+		t.Fatalf("got %q %+v", got, pi)
+	}
+
+	// Reliable.
+	f.feed(t, udpPkt(0, relPkt(initSeqnum, splitPkt(initSeqnum, 2, 0, "12"))))
+	f.goFeed(t, udpPkt(0, relPkt(initSeqnum+1, splitPkt(initSeqnum, 2, 1, "34"))))
+	if got, pi := recvData(t, c); string(got) != "1234" || pi != (PktInfo{}) {
+		t.Fatalf("got %q %+v", got, pi)
+	}
+
+	st := c.Stats()
+	if st.Chans[2].SplitsDone != 1 || st.Chans[0].SplitsDone != 1 {
+		t.Errorf("SplitsDone = %d, %d, want 1, 1", st.Chans[2].SplitsDone, st.Chans[0].SplitsDone)
+	}
+	if m := atomic.LoadInt64(&c.splitMem); m != 0 {
+		t.Errorf("splitMem = %d after reassembly", m)
+	}
+}
+
+func TestRecvLentBufs(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	f.goFeed(t,
+		udpPkt(0, origPkt("first")),
+		udpPkt(0, splitPkt(0, 2, 0, "sec")),
+		udpPkt(0, splitPkt(0, 2, 1, "ond")),
+	)
+	first, err := c.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Drained Pkts release their buffers for reuse,
+	// undrained ones must keep theirs.
+	for i := 0; i < 10; i++ {
+		f.goFeed(t, udpPkt(0, origPkt("overwrite")))
+		if data, _ := recvData(t, c); string(data) != "overwrite" {
+			t.Fatalf("got %q", data)
+		}
+	}
+
+	for _, want := range []struct {
+		pkt  Pkt
+		data string
+	}{{first, "first"}, {second, "second"}} {
+		data, err := io.ReadAll(want.pkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want.data {
+			t.Errorf("got %q, want %q", data, want.data)
+		}
+	}
+}
+
+func TestRecvSplitErrors(t *testing.T) {
+	c, f := newTestConn(t, Config{MaxSplitsPerChan: 2, MaxUnrelPktSize: 8})
+
+	f.goFeed(t, udpPkt(0, splitPkt(1, 2, 2, "x")))
+	if err := recvErr(t, c); err == nil {
+		t.Fatal("accepted chunk number >= chunk count")
+	}
+
+	f.feed(t,
+		udpPkt(0, splitPkt(1, 2, 0, "x")),
+		udpPkt(0, splitPkt(2, 2, 0, "x")),
+	)
+	f.goFeed(t, udpPkt(0, splitPkt(3, 2, 0, "x")))
+	if err := recvErr(t, c); !errors.Is(err, ErrTooManySplits) {
+		t.Fatalf("got %v, want %v", err, ErrTooManySplits)
+	}
+
+	f.goFeed(t, udpPkt(0, splitPkt(1, 3, 1, "x")))
+	if err := recvErr(t, c); err == nil {
+		t.Fatal("accepted changed chunk count")
+	}
+
+	f.goFeed(t, udpPkt(0, splitPkt(2, 2, 1, "much too big")))
+	if err := recvErr(t, c); !errors.Is(err, ErrSplitTooBig) {
+		t.Fatalf("got %v, want %v", err, ErrSplitTooBig)
+	}
+}
+
+func TestRecvRelSplitTooBigCloses(t *testing.T) {
+	c, f := newTestConn(t, Config{MaxRelPktSize: 4})
+
+	f.feed(t, udpPkt(0, relPkt(initSeqnum, splitPkt(initSeqnum, 2, 0, "12345"))))
+	select {
+	case <-c.Closed():
+	case <-time.After(testTimeout):
+		t.Fatal("Conn not closed")
+	}
+	if err := c.WhyClosed(); !errors.Is(err, ErrSplitTooBig) {
+		t.Fatalf("WhyClosed() = %v, want %v", err, ErrSplitTooBig)
+	}
+}
+
+func TestRecvRelSplitBufFullCloses(t *testing.T) {
+	c, f := newTestConn(t, Config{MaxSplitMem: 2 * (sliceSize + ptrSize)})
+
+	f.feed(t, udpPkt(0, relPkt(initSeqnum, splitPkt(initSeqnum, 2, 0, "x"))))
+	select {
+	case <-c.Closed():
+	case <-time.After(testTimeout):
+		t.Fatal("Conn not closed")
+	}
+	if err := c.WhyClosed(); !errors.Is(err, ErrSplitBufFull) {
+		t.Fatalf("WhyClosed() = %v, want %v", err, ErrSplitBufFull)
+	}
+}
+
+func TestListenerSplitMem(t *testing.T) {
+	const limit = 4096
+	l, dial := Pipe(Config{MaxListenerSplitMem: limit}, Config{}, PipeConfig{})
+	defer l.Close()
+	clts, srvs := acceptN(t, l, dial, 2)
+
+	// sendSplit sends chunk i of a split packet of n chunks.
+	sendSplit := func(c *Conn, pi PktInfo, sn seqnum, n, i uint16, data string) {
+		t.Helper()
+		_, err := c.sendRaw(func(buf []byte) int {
+			return copy(buf, splitPkt(sn, n, i, data))
+		}, pi)(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitMem := func(done func(mem int64) bool) {
+		t.Helper()
+		deadline := time.Now().Add(testTimeout)
+		for !done(atomic.LoadInt64(&l.splitMem)) {
+			if time.Now().After(deadline) {
+				t.Fatalf("Listener split memory stuck at %d", atomic.LoadInt64(&l.splitMem))
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// The first Conn floods incomplete unreliable split packets,
+	// each of which charges about 1 KiB, until it pins the Listener's memory.
+	// Its server Conn reports the dropped ones.
+	go func() {
+		for {
+			if _, err := srvs[0].Recv(); errors.Is(err, net.ErrClosed) {
+				return
+			}
+		}
+	}()
+	flood := PktInfo{Unrel: true}
+	sendSplit(clts[0], flood, 0, 20, 0, "x")
+	waitMem(func(mem int64) bool { return mem > 0 })
+	per := atomic.LoadInt64(&l.splitMem)
+	for sn := seqnum(1); sn < 20; sn++ {
+		sendSplit(clts[0], flood, sn, 20, 0, "x")
+	}
+	waitMem(func(mem int64) bool { return mem+per > limit })
+
+	// The other Conn is still within its share.
+	sendSplit(clts[1], PktInfo{}, initSeqnum, 2, 0, "ab")
+	sendSplit(clts[1], PktInfo{}, initSeqnum, 2, 1, "cd")
+	if got, _ := recvData(t, srvs[1]); string(got) != "abcd" {
+		t.Fatalf("got %q, want %q", got, "abcd")
+	}
+
+	for i, srv := range srvs {
+		select {
+		case <-srv.Closed():
+			t.Fatalf("Conn %d closed: %v", i, srv.WhyClosed())
+		default:
+		}
+	}
+	if n := atomic.LoadInt64(&srvs[0].splitMem); n > limit {
+		t.Errorf("flooding Conn holds %d bytes, more than MaxListenerSplitMem", n)
+	}
+
+	for _, srv := range srvs {
+		srv.Close()
+		<-srv.Closed()
+	}
+	waitMem(func(mem int64) bool { return mem == 0 })
+}
+
+func TestSetPeerID(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	f.feed(t, udpPkt(0, relPkt(initSeqnum, ctlPkt(ctlSetPeerID, 0, 42))))
+	f.next(t) // Ack.
+
+	// Short.
+	f.goFeed(t, udpPkt(0, relPkt(initSeqnum+1, ctlPkt(ctlSetPeerID, 0))))
+	if err := recvErr(t, c); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	f.next(t)
+
+	// Already set.
+	f.goFeed(t, udpPkt(0, relPkt(initSeqnum+2, ctlPkt(ctlSetPeerID, 0, 43))))
+	if err := recvErr(t, c); err == nil {
+		t.Fatal("peer id changed")
+	}
+	f.next(t)
+
+	if _, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("x")), PktInfo: PktInfo{Unrel: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if id := PeerID(be.Uint16(f.next(t)[4:6])); id != 42 {
+		t.Fatalf("sent from %d, want 42", id)
+	}
+}
+
+func TestRecvMalformed(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	for _, tc := range []struct {
+		name string
+		pkt  []byte
+		want error
+	}{
+		{"short header", udpPkt(0)[:6], io.ErrUnexpectedEOF},
+		{"no type", udpPkt(0), io.ErrUnexpectedEOF},
+		{"short ack", udpPkt(0, ctlPkt(ctlAck, 0)), io.ErrUnexpectedEOF},
+		{"short rel", udpPkt(0, []byte{uint8(rawRel), 0}), io.ErrUnexpectedEOF},
+		{"short split", udpPkt(0, []byte{uint8(rawSplit), 0, 0, 0}), io.ErrUnexpectedEOF},
+		{"trailing data", udpPkt(0, ctlPkt(ctlPing, 1)), TrailingDataError{1}},
+		{"bad channel", udpPkt(ChannelCount, origPkt("")), TooBigChError(ChannelCount)},
+		{"bad type", udpPkt(0, []byte{42}), nil},
+		{"bad ctl type", udpPkt(0, ctlPkt(42)), nil},
+	} {
+		f.goFeed(t, tc.pkt)
+		err := recvErr(t, c)
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			var tde TrailingDataError
+			if !errors.As(err, &tde) || !errors.As(tc.want, new(TrailingDataError)) {
+				t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+			}
+		}
+	}
+
+	bad := udpPkt(0, origPkt(""))
+	bad[0] = 0
+	f.goFeed(t, bad)
+	recvErr(t, c)
+
+	if got := c.Stats().Errs; got == 0 {
+		t.Error("Errs = 0")
+	}
+}
+
+func TestDisco(t *testing.T) {
+	c, f := newTestConn(t, Config{})
+
+	f.feed(t, udpPkt(0, ctlPkt(ctlDisco)))
+	select {
+	case <-c.Closed():
+	case <-time.After(testTimeout):
+		t.Fatal("Conn not closed")
+	}
+	if err := c.WhyClosed(); err != nil {
+		t.Fatalf("WhyClosed() = %v, want nil", err)
+	}
+	if _, err := c.Recv(); err != net.ErrClosed {
+		t.Fatalf("Recv() = %v, want %v", err, net.ErrClosed)
+	}
+}
+
+func TestPipeNet(t *testing.T) {
+	// run sends n numbered UDP packets through a pipeNet configured by cfg
+	// and returns the numbers received until nothing arrives for 100ms.
+	run := func(cfg PipeConfig, n int) (got []int, elapsed time.Duration) {
+		pn := &pipeNet{cfg: cfg, rand: rand.New(rand.NewSource(cfg.Seed))}
+		dest := make(chan pipePkt, pipeQueueLen)
+		closed := make(chan struct{})
+		defer close(closed)
+
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			pn.send(dest, closed, u16(uint16(i)), pipeAddr(1))
+		}
+		for {
+			select {
+			case pkt := <-dest:
+				if got == nil {
+					elapsed = time.Since(start)
+				}
+				got = append(got, int(be.Uint16(pkt.buf.data)))
+				pkt.buf.release()
+			case <-time.After(100 * time.Millisecond):
+				return
+			}
+		}
+	}
+	sorted := func(xs []int) bool {
+		for i := 1; i < len(xs); i++ {
+			if xs[i] < xs[i-1] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if got, _ := run(PipeConfig{}, 100); len(got) != 100 || !sorted(got) {
+		t.Errorf("perfect: got %v", got)
+	}
+	if got, _ := run(PipeConfig{}, 2*pipeQueueLen); len(got) != pipeQueueLen {
+		t.Errorf("full queue: got %d packets, want %d", len(got), pipeQueueLen)
+	}
+
+	if got, _ := run(PipeConfig{Loss: 1}, 100); len(got) != 0 {
+		t.Errorf("Loss 1: got %d packets", len(got))
+	}
+	if got, _ := run(PipeConfig{Loss: 0.5, Seed: 1}, 100); len(got) < 25 || len(got) > 75 {
+		t.Errorf("Loss 0.5: got %d packets", len(got))
+	}
+
+	if got, _ := run(PipeConfig{Dup: 1}, 100); len(got) != 200 || !sorted(got) {
+		t.Errorf("Dup 1: got %v", got)
+	}
+
+	latency := 20 * time.Millisecond
+	if got, elapsed := run(PipeConfig{Latency: latency}, 10); len(got) != 10 || elapsed < latency {
+		t.Errorf("Latency: got %d packets after %v", len(got), elapsed)
+	}
+
+	got, _ := run(PipeConfig{Latency: time.Millisecond, Reorder: 0.2, Seed: 1}, 100)
+	if len(got) != 100 || sorted(got) {
+		t.Errorf("Reorder 0.2: got %v", got)
+	}
+}
+
+func TestPipeLossy(t *testing.T) {
+	cfg := Config{
+		ResendTimeout:    20 * time.Millisecond,
+		MinResendTimeout: 10 * time.Millisecond,
+		MaxResendTimeout: 50 * time.Millisecond,
+	}
+	clt, srv, err := PipeConn(cfg, PipeConfig{
+		Loss:    0.1,
+		Dup:     0.1,
+		Reorder: 0.1,
+		Jitter:  time.Millisecond,
+		Seed:    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clt.Close()
+
+	big := bytes.Repeat([]byte("0123456789"), 300)
+	go func() {
+		for i := 0; i < 50; i++ {
+			data := big[:i*60]
+			if _, err := clt.Send(Pkt{Reader: bytes.NewReader(data)}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		got, _ := recvData(t, srv)
+		if want := big[:i*60]; !bytes.Equal(got, want) {
+			t.Fatalf("%d: got %d bytes, want %d", i, len(got), len(want))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := clt.CloseGracefully(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	var allow, calls int32
+	l, dial := Pipe(Config{
+		Migrate: func(c *Conn, addr net.Addr, pkt []byte) bool {
+			atomic.AddInt32(&calls, 1)
+			return atomic.LoadInt32(&allow) == 1
+		},
+	}, Config{}, PipeConfig{})
+	defer l.Close()
+
+	clt := dial()
+	defer clt.Close()
+
+	send := func(c *Conn, data string, unrel bool) {
+		t.Helper()
+		_, err := c.Send(Pkt{
+			Reader:  bytes.NewReader([]byte(data)),
+			PktInfo: PktInfo{Unrel: unrel},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(c *Conn, want string) {
+		t.Helper()
+		if got, _ := recvData(t, c); string(got) != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+
+	send(clt, "hello", false)
+	srv, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(srv, "hello")
+
+	// Make sure the client got its PeerID.
+	send(srv, "hi", false)
+	expect(clt, "hi")
+
+	clt.udpConn.(*pipeClt).rebind()
+
+	send(clt, "rejected", true)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if c, err := l.AcceptContext(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("accepted %v, %v; want no new Conn", c, err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("Migrate called %d times, want 1", calls)
+	}
+
+	atomic.StoreInt32(&allow, 1)
+	send(clt, "moved", false)
+	expect(srv, "moved")
+	if got, want := srv.RemoteAddr(), clt.LocalAddr(); got != want {
+		t.Errorf("server Conn has address %v, want %v", got, want)
+	}
+
+	send(srv, "back", false)
+	expect(clt, "back")
+
+	if MigrateSameIP(srv, srv.RemoteAddr(), nil) {
+		t.Error("MigrateSameIP allowed migrating a Conn that is not quiet")
+	}
+}
+
+func TestListenMulti(t *testing.T) {
+	var pcs []net.PacketConn
+	for i := 0; i < 2; i++ {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer pc.Close()
+		pcs = append(pcs, pc)
+	}
+
+	l := ListenMulti(Config{}, pcs...)
+	defer l.Close()
+
+	addrs := l.Addrs()
+	ids := make(map[PeerID]bool)
+	for i, addr := range addrs {
+		conn, err := net.Dial("udp", addr.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		clt := Connect(conn)
+		defer clt.Close()
+
+		if _, err := clt.Send(Pkt{Reader: bytes.NewReader([]byte("hi"))}); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+		srv, err := l.AcceptContext(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := recvData(t, srv); string(got) != "hi" {
+			t.Fatalf("got %q, want %q", got, "hi")
+		}
+
+		if got := srv.LocalAddr(); got.String() != addr.String() {
+			t.Errorf("%d: Conn uses %v, want %v", i, got, addr)
+		}
+		if ids[srv.ID()] {
+			t.Errorf("%d: PeerID %d used twice", i, srv.ID())
+		}
+		ids[srv.ID()] = true
+	}
+}
+
+func TestMaxBytesPerSec(t *testing.T) {
+	const rate = 20 << 10
+	c, f := newTestConn(t, Config{
+		MaxBytesPerSec: rate,
+		Burst:          UDPPktSize,
+	})
+
+	const n = 10
+	data := make([]byte, UDPPktSize/2)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		go c.Send(Pkt{
+			Reader:  bytes.NewReader(data),
+			PktInfo: PktInfo{Unrel: true},
+		})
+	}
+	sent := 0
+	for i := 0; i < n; i++ {
+		sent += len(f.next(t))
+	}
+
+	// Only the burst may be sent at once.
+	want := time.Duration(float64(sent-UDPPktSize) / rate * float64(time.Second))
+	if d := time.Since(start); d < want*9/10 {
+		t.Errorf("sent %d bytes in %v, want at least %v", sent, d, want)
+	}
+}
+
+func TestCtlIgnoresMaxBytesPerSec(t *testing.T) {
+	// Any other packet would wait about 100ms.
+	c, f := newTestConn(t, Config{MaxBytesPerSec: 100, Burst: 1})
+
+	for _, pi := range []PktInfo{{Unrel: true}, {}} {
+		start := time.Now()
+		_, err := c.sendRaw(func(buf []byte) int {
+			return copy(buf, ctlPkt(ctlPing))
+		}, pi)(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.next(t)
+		if d := time.Since(start); d >= 50*time.Millisecond {
+			t.Errorf("%+v: ping delayed by %v", pi, d)
+		}
+	}
+}
+
+func TestSendContextWindowFull(t *testing.T) {
+	c, f := newTestConn(t, Config{RelWindow: 1})
+
+	if _, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("first"))}); err != nil {
+		t.Fatal(err)
+	}
+	f.next(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.SendContext(ctx, Pkt{Reader: bytes.NewReader([]byte("cancelled"))}); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if n := c.Stats().Chans[0].AcksOutstanding; n != 1 {
+		t.Errorf("AcksOutstanding = %d, want 1", n)
+	}
+
+	// The cancelled packet used no seqnum.
+	f.feed(t, udpPkt(0, ackPkt(initSeqnum)))
+	if _, err := c.Send(Pkt{Reader: bytes.NewReader([]byte("second"))}); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		pkt := f.next(t)
+		if bytes.Contains(pkt, []byte("cancelled")) {
+			t.Fatal("cancelled packet sent")
+		}
+		if bytes.Contains(pkt, []byte("second")) {
+			if sn := seqnum(be.Uint16(pkt[8:10])); sn != initSeqnum+1 {
+				t.Errorf("seqnum %d, want %d", sn, initSeqnum+1)
+			}
+			break
+		}
+	}
+}
+
+func TestSendContextRateLimited(t *testing.T) {
+	// Every packet waits about 100ms for MaxBytesPerSec.
+	c, f := newTestConn(t, Config{MaxBytesPerSec: 100, Burst: 1})
+
+	for _, pi := range []PktInfo{{Unrel: true}, {}} {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := c.SendContext(ctx, Pkt{
+			Reader:  bytes.NewReader([]byte("cancelled")),
+			PktInfo: pi,
+		})
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Fatalf("%+v: got %v, want %v", pi, err, context.DeadlineExceeded)
+		}
+	}
+	if n := c.Stats().Chans[0].AcksOutstanding; n != 0 {
+		t.Errorf("AcksOutstanding = %d, want 0", n)
+	}
+
+	select {
+	case pkt := <-f.out:
+		t.Fatalf("cancelled packet sent: %x", pkt)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestChanWeights(t *testing.T) {
+	c, f := newTestConn(t, Config{
+		MaxBytesPerSec: 200 << 10,
+		Burst:          UDPPktSize,
+	})
+
+	// Bulk transfers on channel 2.
+	bulk := make([]byte, 20*UDPPktSize)
+	for i := 0; i < 8; i++ {
+		go c.Send(Pkt{
+			Reader:  bytes.NewReader(bulk),
+			PktInfo: PktInfo{Channel: 2, Unrel: true},
+		})
+	}
+	for i := 0; i < 10; i++ {
+		f.next(t)
+	}
+	for len(f.out) > 0 {
+		<-f.out
+	}
+
+	go c.Send(Pkt{
+		Reader:  bytes.NewReader([]byte("move")),
+		PktInfo: PktInfo{Channel: 0, Unrel: true},
+	})
+
+	// Channel 0 has to wait for at most one packet of channel 2,
+	// not for every bulk transfer.
+	n := 0
+	for f.next(t)[6] != 0 {
+		n++
+	}
+	if n > 2 {
+		t.Errorf("%d packets of channel 2 were sent first", n)
+	}
+}
@@ -0,0 +1,179 @@
+package rudp
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// An outPkt is a UDP packet waiting in a sched.
+type outPkt struct {
+	buf  []byte
+	ch   Channel
+	ctx  context.Context
+	done chan error // Receives the result of sending buf.
+}
+
+var outPkts = sync.Pool{
+	New: func() interface{} {
+		return &outPkt{done: make(chan error, 1)}
+	},
+}
+
+// A sched queues UDP packets per Channel and interleaves them
+// using deficit round robin, weighted by Config.ChanWeights,
+// so that no Channel can starve the others.
+type sched struct {
+	mu      sync.Mutex
+	queues  [ChannelCount][]*outPkt
+	quantum [ChannelCount]int
+	deficit [ChannelCount]int
+	cur     Channel
+	queued  int
+	busy    bool // Whether a UDP packet is being written.
+
+	wake chan struct{}
+}
+
+func (s *sched) init(cfg Config) {
+	for i, w := range cfg.ChanWeights {
+		s.quantum[i] = w * cfg.MaxUDPPktSize
+	}
+	s.cur = ChannelCount - 1
+	s.wake = make(chan struct{}, 1)
+}
+
+// sendUDP queues the UDP packet buf on ch
+// and waits until it has been sent by Conn.sendUDPPkts.
+func (c *Conn) sendUDP(ctx context.Context, buf []byte, ch Channel) error {
+	s := &c.sched
+
+	s.mu.Lock()
+	if c.rate == nil && s.queued == 0 && !s.busy {
+		// Nothing to interleave with.
+		s.busy = true
+		s.mu.Unlock()
+
+		err := c.write(buf, ch)
+		s.idle()
+		return err
+	}
+	s.mu.Unlock()
+
+	op := outPkts.Get().(*outPkt)
+	op.buf, op.ch, op.ctx = buf, ch, ctx
+	defer func() {
+		op.buf, op.ctx = nil, nil
+		outPkts.Put(op)
+	}()
+
+	s.mu.Lock()
+	s.queues[ch] = append(s.queues[ch], op)
+	s.queued++
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	var err error
+	select {
+	case err := <-op.done:
+		return err
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-c.Closed():
+		err = net.ErrClosed
+	}
+
+	if s.remove(op, ch) {
+		return err
+	}
+	// Already being sent.
+	return <-op.done
+}
+
+// remove removes op from the queue of ch
+// and reports whether it was still queued.
+func (s *sched) remove(op *outPkt, ch Channel) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := s.queues[ch]
+	for i := range q {
+		if q[i] == op {
+			copy(q[i:], q[i+1:])
+			q[len(q)-1] = nil
+			s.queues[ch] = q[:len(q)-1]
+			s.queued--
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the next UDP packet to be sent or nil if none are queued.
+func (s *sched) next() *outPkt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.queued == 0 {
+		return nil
+	}
+
+	for {
+		q := s.queues[s.cur]
+		if len(q) > 0 && s.deficit[s.cur] >= len(q[0].buf) {
+			op := q[0]
+			copy(q, q[1:])
+			q[len(q)-1] = nil
+			s.queues[s.cur] = q[:len(q)-1]
+			s.deficit[s.cur] -= len(op.buf)
+			s.queued--
+			s.busy = true
+			return op
+		}
+
+		s.cur = (s.cur + 1) % ChannelCount
+		if len(s.queues[s.cur]) > 0 {
+			s.deficit[s.cur] += s.quantum[s.cur]
+		} else {
+			s.deficit[s.cur] = 0
+		}
+	}
+}
+
+// idle marks the end of writing a UDP packet.
+func (s *sched) idle() {
+	s.mu.Lock()
+	s.busy = false
+	s.mu.Unlock()
+}
+
+// sendUDPPkts sends the UDP packets queued by sendUDP
+// until the Conn is closed.
+func (c *Conn) sendUDPPkts() {
+	s := &c.sched
+	for {
+		op := s.next()
+		if op == nil {
+			select {
+			case <-s.wake:
+				continue
+			case <-c.Closed():
+				return
+			}
+		}
+
+		var err error
+		if c.rate != nil {
+			err = c.rate.wait(op.ctx, len(op.buf), c.Closed())
+		}
+		if err == nil {
+			err = c.write(op.buf, op.ch)
+		}
+		s.idle()
+		op.done <- err
+	}
+}
@@ -1,7 +1,7 @@
 package rudp
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -24,12 +24,28 @@ func (e TooBigChError) Error() string {
 // Ack is closed when the packet is acknowledged.
 // Ack is nil if pkt.Unrel is true or err != nil.
 func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
+	return c.SendContext(context.Background(), pkt)
+}
+
+// SendContext is like Send but returns ctx.Err() if ctx is done
+// before the Pkt could be sent, e.g. because the reliable window is full
+// or the rate limit is reached.
+// If ctx is done while a split packet is being sent,
+// only some of its chunks may have been sent and the peer never
+// receives the Pkt. The peer drops an incomplete unreliable split packet
+// after its ConnTimeout, but keeps the chunks of a reliable one
+// until the Conn is closed, where they count towards its MaxSplitMem.
+// Callers that cancel large reliable Pkts should close the Conn.
+func (c *Conn) SendContext(ctx context.Context, pkt Pkt) (ack <-chan struct{}, err error) {
 	if pkt.Channel >= ChannelCount {
 		return nil, TooBigChError(pkt.Channel)
 	}
 
-	var e error
-	send := c.sendRaw(func(buf []byte) int {
+	var (
+		e   error
+		pre []byte // Already read data that didn't fit in one UDP packet.
+	)
+	send := c.sendRawOnce(func(buf []byte) int {
 		buf[0] = uint8(rawOrig)
 
 		nn := 1
@@ -46,15 +62,13 @@ func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 			return nn
 		}
 
-		pkt.Reader = io.MultiReader(
-			bytes.NewReader([]byte(buf[1:nn])),
-			pkt.Reader,
-		)
+		// The buffer is never sent, so it isn't released either.
+		pre = buf[1:nn]
 		return nn
 	}, pkt.PktInfo)
 	if e != nil {
 		if e == io.EOF {
-			return send()
+			return send(ctx)
 		}
 		return nil, e
 	}
@@ -63,7 +77,7 @@ func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 		sn seqnum
 		i  uint16
 
-		sends []func() (<-chan struct{}, error)
+		sends []sendFunc
 	)
 
 	for {
@@ -71,10 +85,17 @@ func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 			b []byte
 			e error
 		)
-		send := c.sendRaw(func(buf []byte) int {
+		send := c.sendRawOnce(func(buf []byte) int {
 			buf[0] = uint8(rawSplit)
 
-			n, err := io.ReadFull(pkt, buf[7:])
+			n := copy(buf[7:], pre)
+			pre = pre[n:]
+
+			m, err := io.ReadFull(pkt, buf[7+n:])
+			n += m
+			if err == io.EOF && n > 0 {
+				err = nil
+			}
 			if err != nil && err != io.ErrUnexpectedEOF {
 				e = err
 				return 0
@@ -96,10 +117,10 @@ func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 			return nil, e
 		}
 
-		sends = append(sends, func() (<-chan struct{}, error) {
+		sends = append(sends, func(ctx context.Context) (<-chan struct{}, error) {
 			be.PutUint16(b[1:3], uint16(sn))
 			be.PutUint16(b[3:5], i)
-			return send()
+			return send(ctx)
 		})
 	}
 
@@ -113,7 +134,7 @@ func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 	var wg sync.WaitGroup
 
 	for _, send := range sends {
-		ack, err := send()
+		ack, err := send(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -138,28 +159,15 @@ func (c *Conn) Send(pkt Pkt) (ack <-chan struct{}, err error) {
 	return nil, nil
 }
 
-func (c *Conn) sendRaw(read func([]byte) int, pi PktInfo) func() (<-chan struct{}, error) {
-	if pi.Unrel {
-		buf := make([]byte, maxUDPPktSize)
-		be.PutUint32(buf[0:4], protoID)
-		c.mu.RLock()
-		be.PutUint16(buf[4:6], uint16(c.remoteID))
-		c.mu.RUnlock()
-		buf[6] = uint8(pi.Channel)
-		buf = buf[:7+read(buf[7:])]
-
-		return func() (<-chan struct{}, error) {
-			if _, err := c.udpConn.Write(buf); err != nil {
-				c.close(err)
-				return nil, net.ErrClosed
-			}
-
-			c.ping.Reset(PingTimeout)
-			if atomic.LoadUint32(&c.closing) == 1 {
-				c.ping.Stop()
-			}
+// A sendFunc sends a UDP packet prepared by sendRaw.
+// Ack is closed when the packet is acknowledged if it is reliable.
+type sendFunc func(ctx context.Context) (ack <-chan struct{}, err error)
 
-			return nil, nil
+func (c *Conn) sendRaw(read func([]byte) int, pi PktInfo) sendFunc {
+	if pi.Unrel {
+		buf := c.prepUDP(make([]byte, c.cfg.MaxUDPPktSize), read, pi)
+		return func(ctx context.Context) (<-chan struct{}, error) {
+			return nil, c.writeUDP(ctx, buf, pi)
 		}
 	}
 
@@ -171,7 +179,7 @@ func (c *Conn) sendRaw(read func([]byte) int, pi PktInfo) func() (<-chan struct{
 		return 3 + read(buf[3:])
 	}, pi)
 
-	return func() (<-chan struct{}, error) {
+	return func(ctx context.Context) (<-chan struct{}, error) {
 		ch := &c.chans[pi.Channel]
 
 		ch.outRelMu.Lock()
@@ -179,37 +187,56 @@ func (c *Conn) sendRaw(read func([]byte) int, pi PktInfo) func() (<-chan struct{
 
 		sn := ch.outRelSN
 		be.PutUint16(snBuf, uint16(sn))
-		for ; sn-ch.outRelWin >= 0x8000; ch.outRelWin++ {
+		for ; int(sn-ch.outRelWin) >= c.cfg.RelWindow; ch.outRelWin++ {
 			if ack, ok := ch.ackChans.Load(ch.outRelWin); ok {
 				select {
 				case <-ack.(chan struct{}):
 				case <-c.Closed():
+				case <-ctx.Done():
+					return nil, ctx.Err()
 				}
 			}
 		}
 
+		st := &c.stats.Chans[pi.Channel]
+
 		ack := make(chan struct{})
 		ch.ackChans.Store(sn, ack)
+		inc(&st.AcksOutstanding)
 
-		if _, err := send(); err != nil {
+		if _, err := send(ctx); err != nil {
 			if ack, ok := ch.ackChans.LoadAndDelete(sn); ok {
 				close(ack.(chan struct{}))
+				dec(&st.AcksOutstanding)
 			}
 			return nil, err
 		}
 		ch.outRelSN++
 
+		// send may have waited for the sched or MaxBytesPerSec,
+		// which must not count towards the round-trip time.
+		sent := time.Now()
+
 		go func() {
-			t := time.NewTimer(500 * time.Millisecond)
+			rto := c.resendTimeout()
+			t := time.NewTimer(rto)
 			defer t.Stop()
 
+			resent := false
 			for {
 				select {
 				case <-ack:
+					// Karn's algorithm: acks of resent packets are ambiguous.
+					if !resent {
+						c.gotRTT(time.Since(sent))
+					}
 					return
 				case <-t.C:
-					send()
-					t.Reset(500 * time.Millisecond)
+					send(context.Background())
+					inc(&st.Resends)
+					resent = true
+					rto = c.backoff(rto)
+					t.Reset(rto)
 				case <-c.Closed():
 					return
 				}
@@ -219,3 +246,66 @@ func (c *Conn) sendRaw(read func([]byte) int, pi PktInfo) func() (<-chan struct{
 		return ack, nil
 	}
 }
+
+// sendRawOnce is like sendRaw but the returned sendFunc must be called
+// at most once, which allows unreliable packets to use pooled buffers.
+func (c *Conn) sendRawOnce(read func([]byte) int, pi PktInfo) sendFunc {
+	if !pi.Unrel {
+		return c.sendRaw(read, pi)
+	}
+
+	b := newUDPBuf(c.cfg.MaxUDPPktSize)
+	b.data = c.prepUDP(b.data, read, pi)
+	return func(ctx context.Context) (<-chan struct{}, error) {
+		defer b.release()
+		return nil, c.writeUDP(ctx, b.data, pi)
+	}
+}
+
+// prepUDP writes the UDP packet header to buf,
+// calls read to fill in the rest and returns the UDP packet.
+func (c *Conn) prepUDP(buf []byte, read func([]byte) int, pi PktInfo) []byte {
+	be.PutUint32(buf[0:4], protoID)
+	c.mu.RLock()
+	be.PutUint16(buf[4:6], uint16(c.remoteID))
+	c.mu.RUnlock()
+	buf[6] = uint8(pi.Channel)
+	return buf[:7+read(buf[7:])]
+}
+
+// writeUDP sends the UDP packet buf to the Conn.
+// Control packets like acks, reliable or not, are written immediately,
+// others are queued by the Conn's sched.
+func (c *Conn) writeUDP(ctx context.Context, buf []byte, pi PktInfo) error {
+	if isCtl(buf[7:]) {
+		return c.write(buf, pi.Channel)
+	}
+	return c.sendUDP(ctx, buf, pi.Channel)
+}
+
+// isCtl reports whether the raw packet raw is a control packet.
+func isCtl(raw []byte) bool {
+	if len(raw) > 3 && rawType(raw[0]) == rawRel {
+		raw = raw[3:]
+	}
+	return len(raw) > 0 && rawType(raw[0]) == rawCtl
+}
+
+// write writes the UDP packet buf to the Conn.
+func (c *Conn) write(buf []byte, ch Channel) error {
+	if _, err := c.udpConn.Write(buf); err != nil {
+		c.close(err)
+		return net.ErrClosed
+	}
+
+	st := &c.stats.Chans[ch]
+	inc(&st.PktsSent)
+	add(&st.BytesSent, len(buf))
+
+	c.ping.Reset(c.cfg.PingTimeout)
+	if atomic.LoadUint32(&c.closing) == 1 {
+		c.ping.Stop()
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package rudp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Errors reported when a split packet exceeds a limit set in Config.
+// ErrSplitBufFull is also reported when MaxListenerSplitMem is exceeded
+// by a Conn using more than its share of it.
+// The split packet is dropped; if it is reliable, the Conn is closed
+// because the peer considers it delivered.
+var (
+	ErrTooManySplits = errors.New("too many incomplete split packets")
+	ErrSplitBufFull  = errors.New("split packet buffer full")
+	ErrSplitTooBig   = errors.New("split packet too big")
+)
+
+func isSplitLimit(err error) bool {
+	return err == ErrTooManySplits || err == ErrSplitBufFull || err == ErrSplitTooBig
+}
+
+type inSplit struct {
+	lentReader // Reads the chunks once all were received.
+
+	got     int
+	size    int // Sum of chunk lengths.
+	mem     int // Bytes charged to Conn.splitMem.
+	timeout *time.Timer
+}
+
+const (
+	sliceSize = int(unsafe.Sizeof([]byte(nil)))
+	ptrSize   = int(unsafe.Sizeof((*udpBuf)(nil)))
+)
+
+// addChunk adds chunk i of n, which is part of buf, to the split packet sn
+// and returns a reader of all chunks once the split packet is complete.
+// It reports whether buf is still in use and must not be released.
+func (c *Conn) addChunk(pi PktInfo, sn seqnum, n, i uint16, chunk []byte, buf *udpBuf) (r *lentReader, kept bool, err error) {
+	ch := &c.chans[pi.Channel]
+	st := &c.stats.Chans[pi.Channel]
+
+	ch.inSplitsMu.Lock()
+	defer ch.inSplitsMu.Unlock()
+
+	s := ch.inSplits[sn]
+	if s == nil {
+		// Once closing, close has dropped all splits
+		// and uncharged their memory for good.
+		if atomic.LoadUint32(&c.closing) == 1 {
+			return nil, false, net.ErrClosed
+		}
+		if len(ch.inSplits) >= c.cfg.MaxSplitsPerChan {
+			return nil, false, ErrTooManySplits
+		}
+
+		mem := int(n) * (sliceSize + ptrSize)
+		if !c.chargeSplitMem(mem) {
+			return nil, false, ErrSplitBufFull
+		}
+
+		s = &inSplit{mem: mem}
+		s.chunks = make(net.Buffers, n)
+		s.bufs = make([]*udpBuf, n)
+		if pi.Unrel {
+			s.timeout = time.AfterFunc(c.cfg.ConnTimeout, func() {
+				ch.inSplitsMu.Lock()
+				defer ch.inSplitsMu.Unlock()
+
+				if ch.inSplits[sn] == s {
+					c.dropSplit(ch, sn)
+					inc(&st.SplitsTimedOut)
+				}
+			})
+		}
+		ch.inSplits[sn] = s
+	}
+
+	if int(n) != len(s.chunks) {
+		return nil, false, fmt.Errorf("chunk count changed from %d to %d", len(s.chunks), n)
+	}
+
+	if s.chunks[i] == nil {
+		max := c.cfg.MaxRelPktSize
+		if pi.Unrel {
+			max = c.cfg.MaxUnrelPktSize
+		}
+		if s.size+len(chunk) > max {
+			c.dropSplit(ch, sn)
+			return nil, false, ErrSplitTooBig
+		}
+
+		// The chunk keeps the whole rest of its UDP packet alive.
+		if !c.chargeSplitMem(cap(chunk)) {
+			c.dropSplit(ch, sn)
+			return nil, false, ErrSplitBufFull
+		}
+		s.mem += cap(chunk)
+
+		s.chunks[i] = chunk
+		s.bufs[i] = buf
+		kept = true
+		s.got++
+		s.size += len(chunk)
+	}
+
+	if s.got < len(s.chunks) {
+		if s.timeout != nil && s.timeout.Stop() {
+			s.timeout.Reset(c.cfg.ConnTimeout)
+		}
+		return nil, kept, nil
+	}
+
+	c.removeSplit(ch, sn)
+	return &s.lentReader, kept, nil
+}
+
+// removeSplit removes the split packet sn and uncharges its memory.
+// ch.inSplitsMu must be locked.
+func (c *Conn) removeSplit(ch *pktChan, sn seqnum) *inSplit {
+	s := ch.inSplits[sn]
+	if s.timeout != nil {
+		s.timeout.Stop()
+	}
+	delete(ch.inSplits, sn)
+	c.unchargeSplitMem(s.mem)
+	return s
+}
+
+// dropSplit is like removeSplit but also releases the chunks' udpBufs.
+func (c *Conn) dropSplit(ch *pktChan, sn seqnum) {
+	for _, b := range c.removeSplit(ch, sn).bufs {
+		b.release()
+	}
+}
+
+// chargeSplitMem charges n bytes to the Conn and its Listener, if any,
+// and reports whether this is within their limits.
+// Once the Listener's limit is reached, Conns within their share of it
+// may still charge up to their share, so a few Conns
+// holding the Listener's memory can't starve the others.
+// If not within the limits, nothing is charged.
+func (c *Conn) chargeSplitMem(n int) bool {
+	mem := atomic.AddInt64(&c.splitMem, int64(n))
+	if mem > int64(c.cfg.MaxSplitMem) {
+		atomic.AddInt64(&c.splitMem, -int64(n))
+		return false
+	}
+	if c.ln != nil && atomic.AddInt64(&c.ln.splitMem, int64(n)) > int64(c.cfg.MaxListenerSplitMem) &&
+		mem > c.ln.splitMemShare() {
+		c.unchargeSplitMem(n)
+		return false
+	}
+	return true
+}
+
+func (c *Conn) unchargeSplitMem(n int) {
+	atomic.AddInt64(&c.splitMem, -int64(n))
+	if c.ln != nil {
+		atomic.AddInt64(&c.ln.splitMem, -int64(n))
+	}
+}
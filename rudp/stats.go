@@ -0,0 +1,116 @@
+package rudp
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// ChanStats are statistics about a Channel.
+// Pkts and Bytes count UDP packets including headers.
+type ChanStats struct {
+	PktsSent   uint64
+	BytesSent  uint64
+	PktsRecvd  uint64
+	BytesRecvd uint64
+
+	// Resends is the number of reliable packets that were resent
+	// because they were not acknowledged in time.
+	Resends uint64
+
+	// DupsDropped is the number of reliable packets
+	// that were dropped because they had already been received.
+	DupsDropped uint64
+
+	// DupAcksDropped is the number of acks that were not sent
+	// because Config.AckDelay found the same seqnum acknowledged
+	// or about to be.
+	DupAcksDropped uint64
+
+	SplitsDone     uint64
+	SplitsTimedOut uint64
+
+	// AcksOutstanding is the number of sent reliable packets
+	// that have not been acknowledged yet.
+	AcksOutstanding uint64
+}
+
+// Stats are statistics about a Conn or all Conns of a Listener.
+type Stats struct {
+	Chans [ChannelCount]ChanStats
+
+	// Errs is the number of errors encountered while receiving.
+	Errs uint64
+}
+
+// Stats returns a snapshot of the Conn's statistics.
+func (c *Conn) Stats() Stats {
+	return c.stats.load()
+}
+
+// Stats returns the sum of the statistics of all Conns
+// created by the Listener, including closed ones.
+// AcksOutstanding only includes open Conns.
+func (l *Listener) Stats() Stats {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	s := l.closedStats
+	for c := range l.open {
+		s.add(c.Stats())
+	}
+	return s
+}
+
+// Var returns an expvar.Var that publishes the Conn's statistics.
+func (c *Conn) Var() expvar.Var {
+	return expvar.Func(func() interface{} { return c.Stats() })
+}
+
+// Var returns an expvar.Var that publishes the Listener's statistics.
+func (l *Listener) Var() expvar.Var {
+	return expvar.Func(func() interface{} { return l.Stats() })
+}
+
+func (s *Stats) load() Stats {
+	var t Stats
+	for i := range s.Chans {
+		c, d := &s.Chans[i], &t.Chans[i]
+		for _, f := range [...]struct{ src, dst *uint64 }{
+			{&c.PktsSent, &d.PktsSent},
+			{&c.BytesSent, &d.BytesSent},
+			{&c.PktsRecvd, &d.PktsRecvd},
+			{&c.BytesRecvd, &d.BytesRecvd},
+			{&c.Resends, &d.Resends},
+			{&c.DupsDropped, &d.DupsDropped},
+			{&c.DupAcksDropped, &d.DupAcksDropped},
+			{&c.SplitsDone, &d.SplitsDone},
+			{&c.SplitsTimedOut, &d.SplitsTimedOut},
+			{&c.AcksOutstanding, &d.AcksOutstanding},
+		} {
+			*f.dst = atomic.LoadUint64(f.src)
+		}
+	}
+	t.Errs = atomic.LoadUint64(&s.Errs)
+	return t
+}
+
+func (s *Stats) add(t Stats) {
+	for i := range s.Chans {
+		c, d := &s.Chans[i], t.Chans[i]
+		c.PktsSent += d.PktsSent
+		c.BytesSent += d.BytesSent
+		c.PktsRecvd += d.PktsRecvd
+		c.BytesRecvd += d.BytesRecvd
+		c.Resends += d.Resends
+		c.DupsDropped += d.DupsDropped
+		c.DupAcksDropped += d.DupAcksDropped
+		c.SplitsDone += d.SplitsDone
+		c.SplitsTimedOut += d.SplitsTimedOut
+		c.AcksOutstanding += d.AcksOutstanding
+	}
+	s.Errs += t.Errs
+}
+
+func inc(x *uint64)        { atomic.AddUint64(x, 1) }
+func dec(x *uint64)        { atomic.AddUint64(x, ^uint64(0)) }
+func add(x *uint64, n int) { atomic.AddUint64(x, uint64(n)) }
@@ -0,0 +1,59 @@
+package rudp
+
+import (
+	"net"
+	"time"
+)
+
+// A Capture is a UDP packet seen by Config.Tap.
+type Capture struct {
+	// Sent is true for sent and false for received packets.
+	Sent bool
+
+	Time          time.Time
+	Local, Remote net.Addr
+
+	// Data is the UDP payload.
+	// It must not be modified or retained after Tap returns.
+	Data []byte
+}
+
+// tapConn calls tap with every packet sent or received through udpConn.
+type tapConn struct {
+	udpConn
+	tap func(Capture)
+}
+
+func tapped(uc udpConn, tap func(Capture)) udpConn {
+	if tap == nil {
+		return uc
+	}
+	return tapConn{uc, tap}
+}
+
+func (tc tapConn) recvUDP() (*udpBuf, error) {
+	buf, err := tc.udpConn.recvUDP()
+	if err == nil {
+		tc.tap(Capture{
+			Time:   time.Now(),
+			Local:  tc.LocalAddr(),
+			Remote: tc.RemoteAddr(),
+			Data:   buf.data,
+		})
+	}
+	return buf, err
+}
+
+func (tc tapConn) Write(pkt []byte) (int, error) {
+	n, err := tc.udpConn.Write(pkt)
+	if err == nil {
+		tc.tap(Capture{
+			Sent:   true,
+			Time:   time.Now(),
+			Local:  tc.LocalAddr(),
+			Remote: tc.RemoteAddr(),
+			Data:   pkt,
+		})
+	}
+	return n, err
+}
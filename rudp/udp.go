@@ -2,10 +2,8 @@ package rudp
 
 import "net"
 
-const maxUDPPktSize = 512
-
 type udpConn interface {
-	recvUDP() ([]byte, error)
+	recvUDP() (*udpBuf, error)
 	Write([]byte) (int, error)
 	Close() error
 	LocalAddr() net.Addr
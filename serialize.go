@@ -52,13 +52,13 @@ func read16(r io.Reader) uint16 { return be.Uint16(readBuf(r, 2)) }
 func read32(r io.Reader) uint32 { return be.Uint32(readBuf(r, 4)) }
 func read64(r io.Reader) uint64 { return be.Uint64(readBuf(r, 8)) }
 
-func (obj *ToSrvNil) serialize(w io.Writer) {
+func (obj *ToSrvNil) serialize(w io.Writer, ver uint16) {
 }
 
-func (obj *ToSrvNil) deserialize(r io.Reader) {
+func (obj *ToSrvNil) deserialize(r io.Reader, ver uint16) {
 }
 
-func (obj *ToSrvInit) serialize(w io.Writer) {
+func (obj *ToSrvInit) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			SerializeVer             uint8
@@ -80,7 +80,7 @@ func (obj *ToSrvInit) serialize(w io.Writer) {
 
 			//mt:opt
 			SendFullItemMeta bool
-		}))(obj)).SupportedCompression).serialize(w)
+		}))(obj)).SupportedCompression).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -168,7 +168,7 @@ func (obj *ToSrvInit) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvInit) deserialize(r io.Reader) {
+func (obj *ToSrvInit) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			SerializeVer             uint8
@@ -190,7 +190,7 @@ func (obj *ToSrvInit) deserialize(r io.Reader) {
 
 			//mt:opt
 			SendFullItemMeta bool
-		}))(obj)).SupportedCompression).deserialize(r)
+		}))(obj)).SupportedCompression).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -266,7 +266,7 @@ func (obj *ToSrvInit) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvInit2) serialize(w io.Writer) {
+func (obj *ToSrvInit2) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Lang string
 	}))(obj)).Lang))) > math.MaxUint16 {
@@ -286,7 +286,7 @@ func (obj *ToSrvInit2) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvInit2) deserialize(r io.Reader) {
+func (obj *ToSrvInit2) deserialize(r io.Reader, ver uint16) {
 	var local3 []uint8
 	var local4 uint16
 	{
@@ -303,7 +303,7 @@ func (obj *ToSrvInit2) deserialize(r io.Reader) {
 	}))(obj)).Lang) = string(local3)
 }
 
-func (obj *ToSrvJoinModChan) serialize(w io.Writer) {
+func (obj *ToSrvJoinModChan) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Channel string
 	}))(obj)).Channel))) > math.MaxUint16 {
@@ -323,7 +323,7 @@ func (obj *ToSrvJoinModChan) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvJoinModChan) deserialize(r io.Reader) {
+func (obj *ToSrvJoinModChan) deserialize(r io.Reader, ver uint16) {
 	var local5 []uint8
 	var local6 uint16
 	{
@@ -340,7 +340,7 @@ func (obj *ToSrvJoinModChan) deserialize(r io.Reader) {
 	}))(obj)).Channel) = string(local5)
 }
 
-func (obj *ToSrvLeaveModChan) serialize(w io.Writer) {
+func (obj *ToSrvLeaveModChan) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Channel string
 	}))(obj)).Channel))) > math.MaxUint16 {
@@ -360,7 +360,7 @@ func (obj *ToSrvLeaveModChan) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvLeaveModChan) deserialize(r io.Reader) {
+func (obj *ToSrvLeaveModChan) deserialize(r io.Reader, ver uint16) {
 	var local7 []uint8
 	var local8 uint16
 	{
@@ -377,7 +377,7 @@ func (obj *ToSrvLeaveModChan) deserialize(r io.Reader) {
 	}))(obj)).Channel) = string(local7)
 }
 
-func (obj *ToSrvMsgModChan) serialize(w io.Writer) {
+func (obj *ToSrvMsgModChan) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Channel string
 		Msg     string
@@ -420,7 +420,7 @@ func (obj *ToSrvMsgModChan) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvMsgModChan) deserialize(r io.Reader) {
+func (obj *ToSrvMsgModChan) deserialize(r io.Reader, ver uint16) {
 	var local9 []uint8
 	var local10 uint16
 	{
@@ -453,11 +453,11 @@ func (obj *ToSrvMsgModChan) deserialize(r io.Reader) {
 	}))(obj)).Msg) = string(local11)
 }
 
-func (obj *ToSrvPlayerPos) serialize(w io.Writer) {
+func (obj *ToSrvPlayerPos) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos PlayerPos
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -466,11 +466,11 @@ func (obj *ToSrvPlayerPos) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvPlayerPos) deserialize(r io.Reader) {
+func (obj *ToSrvPlayerPos) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos PlayerPos
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -479,7 +479,7 @@ func (obj *ToSrvPlayerPos) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvGotBlks) serialize(w io.Writer) {
+func (obj *ToSrvGotBlks) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		//mt:len8
 		Blks [][3]int16
@@ -512,7 +512,7 @@ func (obj *ToSrvGotBlks) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvGotBlks) deserialize(r io.Reader) {
+func (obj *ToSrvGotBlks) deserialize(r io.Reader, ver uint16) {
 	var local15 uint8
 	{
 		p := &local15
@@ -541,7 +541,7 @@ func (obj *ToSrvGotBlks) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvDeletedBlks) serialize(w io.Writer) {
+func (obj *ToSrvDeletedBlks) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		//mt:len8
 		Blks [][3]int16
@@ -574,7 +574,7 @@ func (obj *ToSrvDeletedBlks) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvDeletedBlks) deserialize(r io.Reader) {
+func (obj *ToSrvDeletedBlks) deserialize(r io.Reader, ver uint16) {
 	var local20 uint8
 	{
 		p := &local20
@@ -603,7 +603,7 @@ func (obj *ToSrvDeletedBlks) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvInvAction) serialize(w io.Writer) {
+func (obj *ToSrvInvAction) serialize(w io.Writer, ver uint16) {
 	{
 		_, err := w.Write(([]byte((*(*(struct {
 			//mt:raw
@@ -613,7 +613,7 @@ func (obj *ToSrvInvAction) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvInvAction) deserialize(r io.Reader) {
+func (obj *ToSrvInvAction) deserialize(r io.Reader, ver uint16) {
 	var local23 []uint8
 	{
 		var err error
@@ -626,7 +626,7 @@ func (obj *ToSrvInvAction) deserialize(r io.Reader) {
 	}))(obj)).Action) = string(local23)
 }
 
-func (obj *ToSrvChatMsg) serialize(w io.Writer) {
+func (obj *ToSrvChatMsg) serialize(w io.Writer, ver uint16) {
 	local24 := utf16.Encode([]rune((*(*(struct {
 		//mt:utf16
 		Msg string
@@ -646,7 +646,7 @@ func (obj *ToSrvChatMsg) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvChatMsg) deserialize(r io.Reader) {
+func (obj *ToSrvChatMsg) deserialize(r io.Reader, ver uint16) {
 	var local26 []uint16
 	var local27 uint16
 	{
@@ -666,7 +666,7 @@ func (obj *ToSrvChatMsg) deserialize(r io.Reader) {
 	}))(obj)).Msg = string(utf16.Decode(local26))
 }
 
-func (obj *ToSrvFallDmg) serialize(w io.Writer) {
+func (obj *ToSrvFallDmg) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Amount uint16
@@ -675,7 +675,7 @@ func (obj *ToSrvFallDmg) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvFallDmg) deserialize(r io.Reader) {
+func (obj *ToSrvFallDmg) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Amount uint16
@@ -684,7 +684,7 @@ func (obj *ToSrvFallDmg) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvSelectItem) serialize(w io.Writer) {
+func (obj *ToSrvSelectItem) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Slot uint16
@@ -693,7 +693,7 @@ func (obj *ToSrvSelectItem) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvSelectItem) deserialize(r io.Reader) {
+func (obj *ToSrvSelectItem) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Slot uint16
@@ -702,13 +702,13 @@ func (obj *ToSrvSelectItem) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvRespawn) serialize(w io.Writer) {
+func (obj *ToSrvRespawn) serialize(w io.Writer, ver uint16) {
 }
 
-func (obj *ToSrvRespawn) deserialize(r io.Reader) {
+func (obj *ToSrvRespawn) deserialize(r io.Reader, ver uint16) {
 }
 
-func (obj *ToSrvInteract) serialize(w io.Writer) {
+func (obj *ToSrvInteract) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Action   Interaction
@@ -717,7 +717,7 @@ func (obj *ToSrvInteract) serialize(w io.Writer) {
 			Pointed PointedThing
 			//mt:end
 			Pos PlayerPos
-		}))(obj)).Action).serialize(w)
+		}))(obj)).Action).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -747,7 +747,7 @@ func (obj *ToSrvInteract) serialize(w io.Writer) {
 				//mt:end
 				Pos PlayerPos
 			}))(obj)).Pointed
-			chk(writePointedThing(w, x))
+			chk(writePointedThing(w, x, ver))
 		}
 		{
 			buf := w
@@ -773,7 +773,7 @@ func (obj *ToSrvInteract) serialize(w io.Writer) {
 			Pointed PointedThing
 			//mt:end
 			Pos PlayerPos
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -782,7 +782,7 @@ func (obj *ToSrvInteract) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvInteract) deserialize(r io.Reader) {
+func (obj *ToSrvInteract) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Action   Interaction
@@ -791,7 +791,7 @@ func (obj *ToSrvInteract) deserialize(r io.Reader) {
 			Pointed PointedThing
 			//mt:end
 			Pos PlayerPos
-		}))(obj)).Action).deserialize(r)
+		}))(obj)).Action).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -827,7 +827,7 @@ func (obj *ToSrvInteract) deserialize(r io.Reader) {
 			}))(obj)).Pointed
 			{
 				var err error
-				*p, err = readPointedThing(r)
+				*p, err = readPointedThing(r, ver)
 				chk(err)
 			}
 		}
@@ -843,7 +843,7 @@ func (obj *ToSrvInteract) deserialize(r io.Reader) {
 			Pointed PointedThing
 			//mt:end
 			Pos PlayerPos
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -852,7 +852,7 @@ func (obj *ToSrvInteract) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvRemovedSounds) serialize(w io.Writer) {
+func (obj *ToSrvRemovedSounds) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		IDs []SoundID
 	}))(obj)).IDs)) > math.MaxUint16 {
@@ -870,7 +870,7 @@ func (obj *ToSrvRemovedSounds) serialize(w io.Writer) {
 		if err := pcall(func() {
 			(((*(*(struct {
 				IDs []SoundID
-			}))(obj)).IDs)[local29]).serialize(w)
+			}))(obj)).IDs)[local29]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -880,7 +880,7 @@ func (obj *ToSrvRemovedSounds) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvRemovedSounds) deserialize(r io.Reader) {
+func (obj *ToSrvRemovedSounds) deserialize(r io.Reader, ver uint16) {
 	var local30 uint16
 	{
 		p := &local30
@@ -895,7 +895,7 @@ func (obj *ToSrvRemovedSounds) deserialize(r io.Reader) {
 		if err := pcall(func() {
 			(((*(*(struct {
 				IDs []SoundID
-			}))(obj)).IDs)[local31]).deserialize(r)
+			}))(obj)).IDs)[local31]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -905,7 +905,7 @@ func (obj *ToSrvRemovedSounds) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvNodeMetaFields) serialize(w io.Writer) {
+func (obj *ToSrvNodeMetaFields) serialize(w io.Writer, ver uint16) {
 	for local32 := range (*(*(struct {
 		Pos      [3]int16
 		Formname string
@@ -968,7 +968,7 @@ func (obj *ToSrvNodeMetaFields) serialize(w io.Writer) {
 				Pos      [3]int16
 				Formname string
 				Fields   []Field
-			}))(obj)).Fields)[local33]).serialize(w)
+			}))(obj)).Fields)[local33]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -978,7 +978,7 @@ func (obj *ToSrvNodeMetaFields) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvNodeMetaFields) deserialize(r io.Reader) {
+func (obj *ToSrvNodeMetaFields) deserialize(r io.Reader, ver uint16) {
 	for local34 := range (*(*(struct {
 		Pos      [3]int16
 		Formname string
@@ -1029,7 +1029,7 @@ func (obj *ToSrvNodeMetaFields) deserialize(r io.Reader) {
 				Pos      [3]int16
 				Formname string
 				Fields   []Field
-			}))(obj)).Fields)[local38]).deserialize(r)
+			}))(obj)).Fields)[local38]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -1039,7 +1039,7 @@ func (obj *ToSrvNodeMetaFields) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvInvFields) serialize(w io.Writer) {
+func (obj *ToSrvInvFields) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Formname string
 		Fields   []Field
@@ -1081,7 +1081,7 @@ func (obj *ToSrvInvFields) serialize(w io.Writer) {
 			(((*(*(struct {
 				Formname string
 				Fields   []Field
-			}))(obj)).Fields)[local39]).serialize(w)
+			}))(obj)).Fields)[local39]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -1091,7 +1091,7 @@ func (obj *ToSrvInvFields) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvInvFields) deserialize(r io.Reader) {
+func (obj *ToSrvInvFields) deserialize(r io.Reader, ver uint16) {
 	var local40 []uint8
 	var local41 uint16
 	{
@@ -1124,7 +1124,7 @@ func (obj *ToSrvInvFields) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Formname string
 				Fields   []Field
-			}))(obj)).Fields)[local43]).deserialize(r)
+			}))(obj)).Fields)[local43]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -1134,7 +1134,7 @@ func (obj *ToSrvInvFields) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvReqMedia) serialize(w io.Writer) {
+func (obj *ToSrvReqMedia) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Filenames []string
 	}))(obj)).Filenames)) > math.MaxUint16 {
@@ -1169,7 +1169,7 @@ func (obj *ToSrvReqMedia) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvReqMedia) deserialize(r io.Reader) {
+func (obj *ToSrvReqMedia) deserialize(r io.Reader, ver uint16) {
 	var local45 uint16
 	{
 		p := &local45
@@ -1198,7 +1198,7 @@ func (obj *ToSrvReqMedia) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvCltReady) serialize(w io.Writer) {
+func (obj *ToSrvCltReady) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			// Version information.
@@ -1280,7 +1280,7 @@ func (obj *ToSrvCltReady) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvCltReady) deserialize(r io.Reader) {
+func (obj *ToSrvCltReady) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			// Version information.
@@ -1351,7 +1351,59 @@ func (obj *ToSrvCltReady) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvFirstSRP) serialize(w io.Writer) {
+func (obj *ToSrvHaveMedia) serialize(w io.Writer, ver uint16) {
+	if len(((*(*(struct {
+		//mt:len8
+		Tokens []uint32
+	}))(obj)).Tokens)) > math.MaxUint8 {
+		chk(ErrTooLong)
+	}
+	{
+		x := uint8(len(((*(*(struct {
+			//mt:len8
+			Tokens []uint32
+		}))(obj)).Tokens)))
+		write8(w, uint8(x))
+	}
+	for local51 := range (*(*(struct {
+		//mt:len8
+		Tokens []uint32
+	}))(obj)).Tokens {
+		{
+			x := ((*(*(struct {
+				//mt:len8
+				Tokens []uint32
+			}))(obj)).Tokens)[local51]
+			write32(w, uint32(x))
+		}
+	}
+}
+
+func (obj *ToSrvHaveMedia) deserialize(r io.Reader, ver uint16) {
+	var local52 uint8
+	{
+		p := &local52
+		*p = read8(r)
+	}
+	((*(*(struct {
+		//mt:len8
+		Tokens []uint32
+	}))(obj)).Tokens) = make([]uint32, local52)
+	for local53 := range (*(*(struct {
+		//mt:len8
+		Tokens []uint32
+	}))(obj)).Tokens {
+		{
+			p := &((*(*(struct {
+				//mt:len8
+				Tokens []uint32
+			}))(obj)).Tokens)[local53]
+			*p = read32(r)
+		}
+	}
+}
+
+func (obj *ToSrvFirstSRP) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Salt        []byte
 		Verifier    []byte
@@ -1412,17 +1464,17 @@ func (obj *ToSrvFirstSRP) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvFirstSRP) deserialize(r io.Reader) {
-	var local51 uint16
+func (obj *ToSrvFirstSRP) deserialize(r io.Reader, ver uint16) {
+	var local54 uint16
 	{
-		p := &local51
+		p := &local54
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Salt        []byte
 		Verifier    []byte
 		EmptyPasswd bool
-	}))(obj)).Salt) = make([]byte, local51)
+	}))(obj)).Salt) = make([]byte, local54)
 	{
 		_, err := io.ReadFull(r, ((*(*(struct {
 			Salt        []byte
@@ -1431,16 +1483,16 @@ func (obj *ToSrvFirstSRP) deserialize(r io.Reader) {
 		}))(obj)).Salt)[:])
 		chk(err)
 	}
-	var local52 uint16
+	var local55 uint16
 	{
-		p := &local52
+		p := &local55
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Salt        []byte
 		Verifier    []byte
 		EmptyPasswd bool
-	}))(obj)).Verifier) = make([]byte, local52)
+	}))(obj)).Verifier) = make([]byte, local55)
 	{
 		_, err := io.ReadFull(r, ((*(*(struct {
 			Salt        []byte
@@ -1466,7 +1518,7 @@ func (obj *ToSrvFirstSRP) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvSRPBytesA) serialize(w io.Writer) {
+func (obj *ToSrvSRPBytesA) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		A      []byte
 		NoSHA1 bool
@@ -1500,16 +1552,16 @@ func (obj *ToSrvSRPBytesA) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvSRPBytesA) deserialize(r io.Reader) {
-	var local53 uint16
+func (obj *ToSrvSRPBytesA) deserialize(r io.Reader, ver uint16) {
+	var local56 uint16
 	{
-		p := &local53
+		p := &local56
 		*p = read16(r)
 	}
 	((*(*(struct {
 		A      []byte
 		NoSHA1 bool
-	}))(obj)).A) = make([]byte, local53)
+	}))(obj)).A) = make([]byte, local56)
 	{
 		_, err := io.ReadFull(r, ((*(*(struct {
 			A      []byte
@@ -1533,7 +1585,7 @@ func (obj *ToSrvSRPBytesA) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToSrvSRPBytesM) serialize(w io.Writer) {
+func (obj *ToSrvSRPBytesM) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		M []byte
 	}))(obj)).M)) > math.MaxUint16 {
@@ -1553,15 +1605,15 @@ func (obj *ToSrvSRPBytesM) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToSrvSRPBytesM) deserialize(r io.Reader) {
-	var local54 uint16
+func (obj *ToSrvSRPBytesM) deserialize(r io.Reader, ver uint16) {
+	var local57 uint16
 	{
-		p := &local54
+		p := &local57
 		*p = read16(r)
 	}
 	((*(*(struct {
 		M []byte
-	}))(obj)).M) = make([]byte, local54)
+	}))(obj)).M) = make([]byte, local57)
 	{
 		_, err := io.ReadFull(r, ((*(*(struct {
 			M []byte
@@ -1570,7 +1622,7 @@ func (obj *ToSrvSRPBytesM) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltHello) serialize(w io.Writer) {
+func (obj *ToCltHello) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			SerializeVer uint8
@@ -1588,7 +1640,7 @@ func (obj *ToCltHello) serialize(w io.Writer) {
 			ProtoVer     uint16
 			AuthMethods
 			Username string
-		}))(obj)).Compression).serialize(w)
+		}))(obj)).Compression).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1612,7 +1664,7 @@ func (obj *ToCltHello) serialize(w io.Writer) {
 			ProtoVer     uint16
 			AuthMethods
 			Username string
-		}))(obj)).AuthMethods).serialize(w)
+		}))(obj)).AuthMethods).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1650,7 +1702,7 @@ func (obj *ToCltHello) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltHello) deserialize(r io.Reader) {
+func (obj *ToCltHello) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			SerializeVer uint8
@@ -1668,7 +1720,7 @@ func (obj *ToCltHello) deserialize(r io.Reader) {
 			ProtoVer     uint16
 			AuthMethods
 			Username string
-		}))(obj)).Compression).deserialize(r)
+		}))(obj)).Compression).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1692,22 +1744,22 @@ func (obj *ToCltHello) deserialize(r io.Reader) {
 			ProtoVer     uint16
 			AuthMethods
 			Username string
-		}))(obj)).AuthMethods).deserialize(r)
+		}))(obj)).AuthMethods).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AuthMethods", err))
 	}
-	var local55 []uint8
-	var local56 uint16
+	var local58 []uint8
+	var local59 uint16
 	{
-		p := &local56
+		p := &local59
 		*p = read16(r)
 	}
-	(local55) = make([]uint8, local56)
+	(local58) = make([]uint8, local59)
 	{
-		_, err := io.ReadFull(r, (local55)[:])
+		_, err := io.ReadFull(r, (local58)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -1716,10 +1768,10 @@ func (obj *ToCltHello) deserialize(r io.Reader) {
 		ProtoVer     uint16
 		AuthMethods
 		Username string
-	}))(obj)).Username) = string(local55)
+	}))(obj)).Username) = string(local58)
 }
 
-func (obj *ToCltAcceptAuth) serialize(w io.Writer) {
+func (obj *ToCltAcceptAuth) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			// The client does the equivalent of
@@ -1730,7 +1782,7 @@ func (obj *ToCltAcceptAuth) serialize(w io.Writer) {
 			MapSeed         uint64
 			SendInterval    float32
 			SudoAuthMethods AuthMethods
-		}))(obj)).PlayerPos).serialize(w)
+		}))(obj)).PlayerPos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1773,7 +1825,7 @@ func (obj *ToCltAcceptAuth) serialize(w io.Writer) {
 			MapSeed         uint64
 			SendInterval    float32
 			SudoAuthMethods AuthMethods
-		}))(obj)).SudoAuthMethods).serialize(w)
+		}))(obj)).SudoAuthMethods).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1782,7 +1834,7 @@ func (obj *ToCltAcceptAuth) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAcceptAuth) deserialize(r io.Reader) {
+func (obj *ToCltAcceptAuth) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			// The client does the equivalent of
@@ -1793,7 +1845,7 @@ func (obj *ToCltAcceptAuth) deserialize(r io.Reader) {
 			MapSeed         uint64
 			SendInterval    float32
 			SudoAuthMethods AuthMethods
-		}))(obj)).PlayerPos).deserialize(r)
+		}))(obj)).PlayerPos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1836,7 +1888,7 @@ func (obj *ToCltAcceptAuth) deserialize(r io.Reader) {
 			MapSeed         uint64
 			SendInterval    float32
 			SudoAuthMethods AuthMethods
-		}))(obj)).SudoAuthMethods).deserialize(r)
+		}))(obj)).SudoAuthMethods).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1845,11 +1897,11 @@ func (obj *ToCltAcceptAuth) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAcceptSudoMode) serialize(w io.Writer) {
+func (obj *ToCltAcceptSudoMode) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			SudoAuthMethods AuthMethods
-		}))(obj)).SudoAuthMethods).serialize(w)
+		}))(obj)).SudoAuthMethods).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1857,19 +1909,19 @@ func (obj *ToCltAcceptSudoMode) serialize(w io.Writer) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AuthMethods", err))
 	}
 	{
-		local57 := [15]byte{}
+		local60 := [15]byte{}
 		{
-			_, err := w.Write((local57)[:])
+			_, err := w.Write((local60)[:])
 			chk(err)
 		}
 	}
 }
 
-func (obj *ToCltAcceptSudoMode) deserialize(r io.Reader) {
+func (obj *ToCltAcceptSudoMode) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			SudoAuthMethods AuthMethods
-		}))(obj)).SudoAuthMethods).deserialize(r)
+		}))(obj)).SudoAuthMethods).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1877,25 +1929,25 @@ func (obj *ToCltAcceptSudoMode) deserialize(r io.Reader) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AuthMethods", err))
 	}
 	{
-		var local58 [15]byte
-		local59 := [15]byte{}
+		var local61 [15]byte
+		local62 := [15]byte{}
 		{
-			_, err := io.ReadFull(r, (local58)[:])
+			_, err := io.ReadFull(r, (local61)[:])
 			chk(err)
 		}
-		if local58 != local59 {
-			chk(fmt.Errorf("const %v: %v", "[15]byte{}", local58))
+		if local61 != local62 {
+			chk(fmt.Errorf("const %v: %v", "[15]byte{}", local61))
 		}
 	}
 }
 
-func (obj *ToCltDenySudoMode) serialize(w io.Writer) {
+func (obj *ToCltDenySudoMode) serialize(w io.Writer, ver uint16) {
 }
 
-func (obj *ToCltDenySudoMode) deserialize(r io.Reader) {
+func (obj *ToCltDenySudoMode) deserialize(r io.Reader, ver uint16) {
 }
 
-func (obj *ToCltKick) serialize(w io.Writer) {
+func (obj *ToCltKick) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Reason KickReason
@@ -1905,7 +1957,7 @@ func (obj *ToCltKick) serialize(w io.Writer) {
 
 			//mt:if dr := %s.Reason; dr == Shutdown || dr == Crash
 			Reconnect bool
-		}))(obj)).Reason).serialize(w)
+		}))(obj)).Reason).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -1996,7 +2048,7 @@ func (obj *ToCltKick) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltKick) deserialize(r io.Reader) {
+func (obj *ToCltKick) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Reason KickReason
@@ -2006,7 +2058,7 @@ func (obj *ToCltKick) deserialize(r io.Reader) {
 
 			//mt:if dr := %s.Reason; dr == Shutdown || dr == Crash
 			Reconnect bool
-		}))(obj)).Reason).deserialize(r)
+		}))(obj)).Reason).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2033,15 +2085,15 @@ func (obj *ToCltKick) deserialize(r io.Reader) {
 		//mt:if dr := %s.Reason; dr == Shutdown || dr == Crash
 		Reconnect bool
 	}))(obj)).Reason; dr == Custom || dr == Shutdown || dr == Crash {
-		var local60 []uint8
-		var local61 uint16
+		var local63 []uint8
+		var local64 uint16
 		{
-			p := &local61
+			p := &local64
 			*p = read16(r)
 		}
-		(local60) = make([]uint8, local61)
+		(local63) = make([]uint8, local64)
 		{
-			_, err := io.ReadFull(r, (local60)[:])
+			_, err := io.ReadFull(r, (local63)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -2052,7 +2104,7 @@ func (obj *ToCltKick) deserialize(r io.Reader) {
 
 			//mt:if dr := %s.Reason; dr == Shutdown || dr == Crash
 			Reconnect bool
-		}))(obj)).Custom) = string(local60)
+		}))(obj)).Custom) = string(local63)
 	}
 	if dr := (*(*(struct {
 		Reason KickReason
@@ -2085,8 +2137,8 @@ func (obj *ToCltKick) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltBlkData) serialize(w io.Writer) {
-	for local62 := range (*(*(struct {
+func (obj *ToCltBlkData) serialize(w io.Writer, ver uint16) {
+	for local65 := range (*(*(struct {
 		Blkpos [3]int16
 		Blk    MapBlk
 	}))(obj)).Blkpos {
@@ -2094,7 +2146,7 @@ func (obj *ToCltBlkData) serialize(w io.Writer) {
 			x := ((*(*(struct {
 				Blkpos [3]int16
 				Blk    MapBlk
-			}))(obj)).Blkpos)[local62]
+			}))(obj)).Blkpos)[local65]
 			write16(w, uint16(x))
 		}
 	}
@@ -2102,7 +2154,7 @@ func (obj *ToCltBlkData) serialize(w io.Writer) {
 		((*(*(struct {
 			Blkpos [3]int16
 			Blk    MapBlk
-		}))(obj)).Blk).serialize(w)
+		}))(obj)).Blk).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2111,8 +2163,8 @@ func (obj *ToCltBlkData) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltBlkData) deserialize(r io.Reader) {
-	for local63 := range (*(*(struct {
+func (obj *ToCltBlkData) deserialize(r io.Reader, ver uint16) {
+	for local66 := range (*(*(struct {
 		Blkpos [3]int16
 		Blk    MapBlk
 	}))(obj)).Blkpos {
@@ -2120,7 +2172,7 @@ func (obj *ToCltBlkData) deserialize(r io.Reader) {
 			p := &((*(*(struct {
 				Blkpos [3]int16
 				Blk    MapBlk
-			}))(obj)).Blkpos)[local63]
+			}))(obj)).Blkpos)[local66]
 			*p = int16(read16(r))
 		}
 	}
@@ -2128,7 +2180,7 @@ func (obj *ToCltBlkData) deserialize(r io.Reader) {
 		((*(*(struct {
 			Blkpos [3]int16
 			Blk    MapBlk
-		}))(obj)).Blk).deserialize(r)
+		}))(obj)).Blk).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2137,8 +2189,8 @@ func (obj *ToCltBlkData) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAddNode) serialize(w io.Writer) {
-	for local64 := range (*(*(struct {
+func (obj *ToCltAddNode) serialize(w io.Writer, ver uint16) {
+	for local67 := range (*(*(struct {
 		Pos [3]int16
 		Node
 		KeepMeta bool
@@ -2148,7 +2200,7 @@ func (obj *ToCltAddNode) serialize(w io.Writer) {
 				Pos [3]int16
 				Node
 				KeepMeta bool
-			}))(obj)).Pos)[local64]
+			}))(obj)).Pos)[local67]
 			write16(w, uint16(x))
 		}
 	}
@@ -2157,7 +2209,7 @@ func (obj *ToCltAddNode) serialize(w io.Writer) {
 			Pos [3]int16
 			Node
 			KeepMeta bool
-		}))(obj)).Node).serialize(w)
+		}))(obj)).Node).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2178,8 +2230,8 @@ func (obj *ToCltAddNode) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAddNode) deserialize(r io.Reader) {
-	for local65 := range (*(*(struct {
+func (obj *ToCltAddNode) deserialize(r io.Reader, ver uint16) {
+	for local68 := range (*(*(struct {
 		Pos [3]int16
 		Node
 		KeepMeta bool
@@ -2189,7 +2241,7 @@ func (obj *ToCltAddNode) deserialize(r io.Reader) {
 				Pos [3]int16
 				Node
 				KeepMeta bool
-			}))(obj)).Pos)[local65]
+			}))(obj)).Pos)[local68]
 			*p = int16(read16(r))
 		}
 	}
@@ -2198,7 +2250,7 @@ func (obj *ToCltAddNode) deserialize(r io.Reader) {
 			Pos [3]int16
 			Node
 			KeepMeta bool
-		}))(obj)).Node).deserialize(r)
+		}))(obj)).Node).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2222,33 +2274,33 @@ func (obj *ToCltAddNode) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltRemoveNode) serialize(w io.Writer) {
-	for local66 := range (*(*(struct {
+func (obj *ToCltRemoveNode) serialize(w io.Writer, ver uint16) {
+	for local69 := range (*(*(struct {
 		Pos [3]int16
 	}))(obj)).Pos {
 		{
 			x := ((*(*(struct {
 				Pos [3]int16
-			}))(obj)).Pos)[local66]
+			}))(obj)).Pos)[local69]
 			write16(w, uint16(x))
 		}
 	}
 }
 
-func (obj *ToCltRemoveNode) deserialize(r io.Reader) {
-	for local67 := range (*(*(struct {
+func (obj *ToCltRemoveNode) deserialize(r io.Reader, ver uint16) {
+	for local70 := range (*(*(struct {
 		Pos [3]int16
 	}))(obj)).Pos {
 		{
 			p := &((*(*(struct {
 				Pos [3]int16
-			}))(obj)).Pos)[local67]
+			}))(obj)).Pos)[local70]
 			*p = int16(read16(r))
 		}
 	}
 }
 
-func (obj *ToCltInv) serialize(w io.Writer) {
+func (obj *ToCltInv) serialize(w io.Writer, ver uint16) {
 	{
 		_, err := w.Write(([]byte((*(*(struct {
 			//mt:raw
@@ -2258,20 +2310,20 @@ func (obj *ToCltInv) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltInv) deserialize(r io.Reader) {
-	var local68 []uint8
+func (obj *ToCltInv) deserialize(r io.Reader, ver uint16) {
+	var local71 []uint8
 	{
 		var err error
-		(local68), err = io.ReadAll(r)
+		(local71), err = io.ReadAll(r)
 		chk(err)
 	}
 	((*(*(struct {
 		//mt:raw
 		Inv string
-	}))(obj)).Inv) = string(local68)
+	}))(obj)).Inv) = string(local71)
 }
 
-func (obj *ToCltTimeOfDay) serialize(w io.Writer) {
+func (obj *ToCltTimeOfDay) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Time  uint16  // %24000
@@ -2288,7 +2340,7 @@ func (obj *ToCltTimeOfDay) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltTimeOfDay) deserialize(r io.Reader) {
+func (obj *ToCltTimeOfDay) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Time  uint16  // %24000
@@ -2305,7 +2357,7 @@ func (obj *ToCltTimeOfDay) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltCSMRestrictionFlags) serialize(w io.Writer) {
+func (obj *ToCltCSMRestrictionFlags) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Flags CSMRestrictionFlags
@@ -2313,7 +2365,7 @@ func (obj *ToCltCSMRestrictionFlags) serialize(w io.Writer) {
 			// MapRange is the maximum distance from the player CSMs can read the map
 			// if Flags&LimitMapRange != 0.
 			MapRange uint32
-		}))(obj)).Flags).serialize(w)
+		}))(obj)).Flags).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2332,7 +2384,7 @@ func (obj *ToCltCSMRestrictionFlags) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltCSMRestrictionFlags) deserialize(r io.Reader) {
+func (obj *ToCltCSMRestrictionFlags) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Flags CSMRestrictionFlags
@@ -2340,7 +2392,7 @@ func (obj *ToCltCSMRestrictionFlags) deserialize(r io.Reader) {
 			// MapRange is the maximum distance from the player CSMs can read the map
 			// if Flags&LimitMapRange != 0.
 			MapRange uint32
-		}))(obj)).Flags).deserialize(r)
+		}))(obj)).Flags).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2359,11 +2411,11 @@ func (obj *ToCltCSMRestrictionFlags) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAddPlayerVel) serialize(w io.Writer) {
+func (obj *ToCltAddPlayerVel) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Vel Vec
-		}))(obj)).Vel).serialize(w)
+		}))(obj)).Vel).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2372,11 +2424,11 @@ func (obj *ToCltAddPlayerVel) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAddPlayerVel) deserialize(r io.Reader) {
+func (obj *ToCltAddPlayerVel) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Vel Vec
-		}))(obj)).Vel).deserialize(r)
+		}))(obj)).Vel).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2385,11 +2437,11 @@ func (obj *ToCltAddPlayerVel) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltMediaPush) serialize(w io.Writer) {
+func (obj *ToCltMediaPush) serialize(w io.Writer, ver uint16) {
 	{
-		local69 := uint16(sha1.Size)
+		local72 := uint16(sha1.Size)
 		{
-			x := local69
+			x := local72
 			write16(w, uint16(x))
 		}
 	}
@@ -2400,6 +2452,10 @@ func (obj *ToCltMediaPush) serialize(w io.Writer) {
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
 		}))(obj)).SHA1)[:])
@@ -2411,6 +2467,10 @@ func (obj *ToCltMediaPush) serialize(w io.Writer) {
 		Filename    string
 		ShouldCache bool
 
+		//mt:if ver >= 40
+		CallbackToken uint32
+
+		//mt:if ver < 40
 		//mt:len32
 		Data []byte
 	}))(obj)).Filename))) > math.MaxUint16 {
@@ -2423,6 +2483,10 @@ func (obj *ToCltMediaPush) serialize(w io.Writer) {
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
 		}))(obj)).Filename))))
@@ -2435,6 +2499,10 @@ func (obj *ToCltMediaPush) serialize(w io.Writer) {
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
 		}))(obj)).Filename))[:])
@@ -2447,6 +2515,10 @@ func (obj *ToCltMediaPush) serialize(w io.Writer) {
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
 		}))(obj)).ShouldCache
@@ -2456,53 +2528,85 @@ func (obj *ToCltMediaPush) serialize(w io.Writer) {
 			write8(w, 0)
 		}
 	}
-	if len(((*(*(struct {
-		//mt:const uint16(sha1.Size)
-		SHA1        [sha1.Size]byte
-		Filename    string
-		ShouldCache bool
+	if ver >= 40 {
+		{
+			x := (*(*(struct {
+				//mt:const uint16(sha1.Size)
+				SHA1        [sha1.Size]byte
+				Filename    string
+				ShouldCache bool
 
-		//mt:len32
-		Data []byte
-	}))(obj)).Data)) > math.MaxUint32 {
-		chk(ErrTooLong)
-	}
-	{
-		x := uint32(len(((*(*(struct {
-			//mt:const uint16(sha1.Size)
-			SHA1        [sha1.Size]byte
-			Filename    string
-			ShouldCache bool
+				//mt:if ver >= 40
+				CallbackToken uint32
 
-			//mt:len32
-			Data []byte
-		}))(obj)).Data)))
-		write32(w, uint32(x))
+				//mt:if ver < 40
+				//mt:len32
+				Data []byte
+			}))(obj)).CallbackToken
+			write32(w, uint32(x))
+		}
 	}
-	{
-		_, err := w.Write(((*(*(struct {
+	if ver < 40 {
+		if len(((*(*(struct {
 			//mt:const uint16(sha1.Size)
 			SHA1        [sha1.Size]byte
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
-		}))(obj)).Data)[:])
-		chk(err)
+		}))(obj)).Data)) > math.MaxUint32 {
+			chk(ErrTooLong)
+		}
+		{
+			x := uint32(len(((*(*(struct {
+				//mt:const uint16(sha1.Size)
+				SHA1        [sha1.Size]byte
+				Filename    string
+				ShouldCache bool
+
+				//mt:if ver >= 40
+				CallbackToken uint32
+
+				//mt:if ver < 40
+				//mt:len32
+				Data []byte
+			}))(obj)).Data)))
+			write32(w, uint32(x))
+		}
+		{
+			_, err := w.Write(((*(*(struct {
+				//mt:const uint16(sha1.Size)
+				SHA1        [sha1.Size]byte
+				Filename    string
+				ShouldCache bool
+
+				//mt:if ver >= 40
+				CallbackToken uint32
+
+				//mt:if ver < 40
+				//mt:len32
+				Data []byte
+			}))(obj)).Data)[:])
+			chk(err)
+		}
 	}
 }
 
-func (obj *ToCltMediaPush) deserialize(r io.Reader) {
+func (obj *ToCltMediaPush) deserialize(r io.Reader, ver uint16) {
 	{
-		var local70 uint16
-		local71 := uint16(sha1.Size)
+		var local73 uint16
+		local74 := uint16(sha1.Size)
 		{
-			p := &local70
+			p := &local73
 			*p = read16(r)
 		}
-		if local70 != local71 {
-			chk(fmt.Errorf("const %v: %v", "uint16(sha1.Size)", local70))
+		if local73 != local74 {
+			chk(fmt.Errorf("const %v: %v", "uint16(sha1.Size)", local73))
 		}
 	}
 	{
@@ -2512,20 +2616,24 @@ func (obj *ToCltMediaPush) deserialize(r io.Reader) {
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
 		}))(obj)).SHA1)[:])
 		chk(err)
 	}
-	var local72 []uint8
-	var local73 uint16
+	var local75 []uint8
+	var local76 uint16
 	{
-		p := &local73
+		p := &local76
 		*p = read16(r)
 	}
-	(local72) = make([]uint8, local73)
+	(local75) = make([]uint8, local76)
 	{
-		_, err := io.ReadFull(r, (local72)[:])
+		_, err := io.ReadFull(r, (local75)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -2534,9 +2642,13 @@ func (obj *ToCltMediaPush) deserialize(r io.Reader) {
 		Filename    string
 		ShouldCache bool
 
+		//mt:if ver >= 40
+		CallbackToken uint32
+
+		//mt:if ver < 40
 		//mt:len32
 		Data []byte
-	}))(obj)).Filename) = string(local72)
+	}))(obj)).Filename) = string(local75)
 	{
 		p := &(*(*(struct {
 			//mt:const uint16(sha1.Size)
@@ -2544,6 +2656,10 @@ func (obj *ToCltMediaPush) deserialize(r io.Reader) {
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
 		}))(obj)).ShouldCache
@@ -2556,39 +2672,67 @@ func (obj *ToCltMediaPush) deserialize(r io.Reader) {
 			chk(fmt.Errorf("invalid bool: %d", n))
 		}
 	}
-	var local74 uint32
-	{
-		p := &local74
-		*p = read32(r)
-	}
-	((*(*(struct {
-		//mt:const uint16(sha1.Size)
-		SHA1        [sha1.Size]byte
-		Filename    string
-		ShouldCache bool
+	if ver >= 40 {
+		{
+			p := &(*(*(struct {
+				//mt:const uint16(sha1.Size)
+				SHA1        [sha1.Size]byte
+				Filename    string
+				ShouldCache bool
 
-		//mt:len32
-		Data []byte
-	}))(obj)).Data) = make([]byte, local74)
-	{
-		_, err := io.ReadFull(r, ((*(*(struct {
+				//mt:if ver >= 40
+				CallbackToken uint32
+
+				//mt:if ver < 40
+				//mt:len32
+				Data []byte
+			}))(obj)).CallbackToken
+			*p = read32(r)
+		}
+	}
+	if ver < 40 {
+		var local77 uint32
+		{
+			p := &local77
+			*p = read32(r)
+		}
+		((*(*(struct {
 			//mt:const uint16(sha1.Size)
 			SHA1        [sha1.Size]byte
 			Filename    string
 			ShouldCache bool
 
+			//mt:if ver >= 40
+			CallbackToken uint32
+
+			//mt:if ver < 40
 			//mt:len32
 			Data []byte
-		}))(obj)).Data)[:])
-		chk(err)
+		}))(obj)).Data) = make([]byte, local77)
+		{
+			_, err := io.ReadFull(r, ((*(*(struct {
+				//mt:const uint16(sha1.Size)
+				SHA1        [sha1.Size]byte
+				Filename    string
+				ShouldCache bool
+
+				//mt:if ver >= 40
+				CallbackToken uint32
+
+				//mt:if ver < 40
+				//mt:len32
+				Data []byte
+			}))(obj)).Data)[:])
+			chk(err)
+		}
 	}
 }
 
-func (obj *ToCltChatMsg) serialize(w io.Writer) {
+func (obj *ToCltChatMsg) serialize(w io.Writer, ver uint16) {
 	{
-		local75 := uint8(1)
+		local78 := uint8(1)
 		{
-			x := local75
+			x := local78
 			write8(w, uint8(x))
 		}
 	}
@@ -2600,14 +2744,14 @@ func (obj *ToCltChatMsg) serialize(w io.Writer) {
 			Sender, Text string
 
 			Timestamp int64 // Unix time.
-		}))(obj)).Type).serialize(w)
+		}))(obj)).Type).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ChatMsgType", err))
 	}
-	local76 := utf16.Encode([]rune((*(*(struct {
+	local79 := utf16.Encode([]rune((*(*(struct {
 		Type ChatMsgType
 
 		//mt:utf16
@@ -2615,20 +2759,20 @@ func (obj *ToCltChatMsg) serialize(w io.Writer) {
 
 		Timestamp int64 // Unix time.
 	}))(obj)).Sender))
-	if len((local76)) > math.MaxUint16 {
+	if len((local79)) > math.MaxUint16 {
 		chk(ErrTooLong)
 	}
 	{
-		x := uint16(len((local76)))
+		x := uint16(len((local79)))
 		write16(w, uint16(x))
 	}
-	for local77 := range local76 {
+	for local80 := range local79 {
 		{
-			x := (local76)[local77]
+			x := (local79)[local80]
 			write16(w, uint16(x))
 		}
 	}
-	local78 := utf16.Encode([]rune((*(*(struct {
+	local81 := utf16.Encode([]rune((*(*(struct {
 		Type ChatMsgType
 
 		//mt:utf16
@@ -2636,16 +2780,16 @@ func (obj *ToCltChatMsg) serialize(w io.Writer) {
 
 		Timestamp int64 // Unix time.
 	}))(obj)).Text))
-	if len((local78)) > math.MaxUint16 {
+	if len((local81)) > math.MaxUint16 {
 		chk(ErrTooLong)
 	}
 	{
-		x := uint16(len((local78)))
+		x := uint16(len((local81)))
 		write16(w, uint16(x))
 	}
-	for local79 := range local78 {
+	for local82 := range local81 {
 		{
-			x := (local78)[local79]
+			x := (local81)[local82]
 			write16(w, uint16(x))
 		}
 	}
@@ -2662,16 +2806,16 @@ func (obj *ToCltChatMsg) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltChatMsg) deserialize(r io.Reader) {
+func (obj *ToCltChatMsg) deserialize(r io.Reader, ver uint16) {
 	{
-		var local80 uint8
-		local81 := uint8(1)
+		var local83 uint8
+		local84 := uint8(1)
 		{
-			p := &local80
+			p := &local83
 			*p = read8(r)
 		}
-		if local80 != local81 {
-			chk(fmt.Errorf("const %v: %v", "uint8(1)", local80))
+		if local83 != local84 {
+			chk(fmt.Errorf("const %v: %v", "uint8(1)", local83))
 		}
 	}
 	if err := pcall(func() {
@@ -2682,23 +2826,23 @@ func (obj *ToCltChatMsg) deserialize(r io.Reader) {
 			Sender, Text string
 
 			Timestamp int64 // Unix time.
-		}))(obj)).Type).deserialize(r)
+		}))(obj)).Type).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ChatMsgType", err))
 	}
-	var local82 []uint16
-	var local83 uint16
+	var local85 []uint16
+	var local86 uint16
 	{
-		p := &local83
+		p := &local86
 		*p = read16(r)
 	}
-	(local82) = make([]uint16, local83)
-	for local84 := range local82 {
+	(local85) = make([]uint16, local86)
+	for local87 := range local85 {
 		{
-			p := &(local82)[local84]
+			p := &(local85)[local87]
 			*p = read16(r)
 		}
 	}
@@ -2709,17 +2853,17 @@ func (obj *ToCltChatMsg) deserialize(r io.Reader) {
 		Sender, Text string
 
 		Timestamp int64 // Unix time.
-	}))(obj)).Sender = string(utf16.Decode(local82))
-	var local85 []uint16
-	var local86 uint16
+	}))(obj)).Sender = string(utf16.Decode(local85))
+	var local88 []uint16
+	var local89 uint16
 	{
-		p := &local86
+		p := &local89
 		*p = read16(r)
 	}
-	(local85) = make([]uint16, local86)
-	for local87 := range local85 {
+	(local88) = make([]uint16, local89)
+	for local90 := range local88 {
 		{
-			p := &(local85)[local87]
+			p := &(local88)[local90]
 			*p = read16(r)
 		}
 	}
@@ -2730,7 +2874,7 @@ func (obj *ToCltChatMsg) deserialize(r io.Reader) {
 		Sender, Text string
 
 		Timestamp int64 // Unix time.
-	}))(obj)).Text = string(utf16.Decode(local85))
+	}))(obj)).Text = string(utf16.Decode(local88))
 	{
 		p := &(*(*(struct {
 			Type ChatMsgType
@@ -2744,7 +2888,7 @@ func (obj *ToCltChatMsg) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAORmAdd) serialize(w io.Writer) {
+func (obj *ToCltAORmAdd) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
@@ -2758,7 +2902,7 @@ func (obj *ToCltAORmAdd) serialize(w io.Writer) {
 		}))(obj)).Remove)))
 		write16(w, uint16(x))
 	}
-	for local88 := range (*(*(struct {
+	for local91 := range (*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
 	}))(obj)).Remove {
@@ -2766,7 +2910,7 @@ func (obj *ToCltAORmAdd) serialize(w io.Writer) {
 			(((*(*(struct {
 				Remove []AOID
 				Add    []AOAdd
-			}))(obj)).Remove)[local88]).serialize(w)
+			}))(obj)).Remove)[local91]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -2787,7 +2931,7 @@ func (obj *ToCltAORmAdd) serialize(w io.Writer) {
 		}))(obj)).Add)))
 		write16(w, uint16(x))
 	}
-	for local89 := range (*(*(struct {
+	for local92 := range (*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
 	}))(obj)).Add {
@@ -2795,7 +2939,7 @@ func (obj *ToCltAORmAdd) serialize(w io.Writer) {
 			(((*(*(struct {
 				Remove []AOID
 				Add    []AOAdd
-			}))(obj)).Add)[local89]).serialize(w)
+			}))(obj)).Add)[local92]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -2805,17 +2949,17 @@ func (obj *ToCltAORmAdd) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAORmAdd) deserialize(r io.Reader) {
-	var local90 uint16
+func (obj *ToCltAORmAdd) deserialize(r io.Reader, ver uint16) {
+	var local93 uint16
 	{
-		p := &local90
+		p := &local93
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
-	}))(obj)).Remove) = make([]AOID, local90)
-	for local91 := range (*(*(struct {
+	}))(obj)).Remove) = make([]AOID, local93)
+	for local94 := range (*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
 	}))(obj)).Remove {
@@ -2823,7 +2967,7 @@ func (obj *ToCltAORmAdd) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Remove []AOID
 				Add    []AOAdd
-			}))(obj)).Remove)[local91]).deserialize(r)
+			}))(obj)).Remove)[local94]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -2831,16 +2975,16 @@ func (obj *ToCltAORmAdd) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AOID", err))
 		}
 	}
-	var local92 uint16
+	var local95 uint16
 	{
-		p := &local92
+		p := &local95
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
-	}))(obj)).Add) = make([]AOAdd, local92)
-	for local93 := range (*(*(struct {
+	}))(obj)).Add) = make([]AOAdd, local95)
+	for local96 := range (*(*(struct {
 		Remove []AOID
 		Add    []AOAdd
 	}))(obj)).Add {
@@ -2848,7 +2992,7 @@ func (obj *ToCltAORmAdd) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Remove []AOID
 				Add    []AOAdd
-			}))(obj)).Add)[local93]).deserialize(r)
+			}))(obj)).Add)[local96]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -2858,8 +3002,8 @@ func (obj *ToCltAORmAdd) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAOMsgs) serialize(w io.Writer) {
-	for local94 := range (*(*(struct {
+func (obj *ToCltAOMsgs) serialize(w io.Writer, ver uint16) {
+	for local97 := range (*(*(struct {
 		//mt:raw
 		Msgs []IDAOMsg
 	}))(obj)).Msgs {
@@ -2867,7 +3011,7 @@ func (obj *ToCltAOMsgs) serialize(w io.Writer) {
 			(((*(*(struct {
 				//mt:raw
 				Msgs []IDAOMsg
-			}))(obj)).Msgs)[local94]).serialize(w)
+			}))(obj)).Msgs)[local97]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -2877,12 +3021,12 @@ func (obj *ToCltAOMsgs) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAOMsgs) deserialize(r io.Reader) {
+func (obj *ToCltAOMsgs) deserialize(r io.Reader, ver uint16) {
 	for {
-		var local95 IDAOMsg
+		var local98 IDAOMsg
 		err := pcall(func() {
 			if err := pcall(func() {
-				(local95).deserialize(r)
+				(local98).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -2899,12 +3043,12 @@ func (obj *ToCltAOMsgs) deserialize(r io.Reader) {
 		}))(obj)).Msgs) = append(((*(*(struct {
 			//mt:raw
 			Msgs []IDAOMsg
-		}))(obj)).Msgs), local95)
+		}))(obj)).Msgs), local98)
 		chk(err)
 	}
 }
 
-func (obj *ToCltHP) serialize(w io.Writer) {
+func (obj *ToCltHP) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			HP uint16
@@ -2913,7 +3057,7 @@ func (obj *ToCltHP) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltHP) deserialize(r io.Reader) {
+func (obj *ToCltHP) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			HP uint16
@@ -2922,12 +3066,12 @@ func (obj *ToCltHP) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltMovePlayer) serialize(w io.Writer) {
+func (obj *ToCltMovePlayer) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos
 			Pitch, Yaw float32
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2950,12 +3094,12 @@ func (obj *ToCltMovePlayer) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltMovePlayer) deserialize(r io.Reader) {
+func (obj *ToCltMovePlayer) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos
 			Pitch, Yaw float32
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -2978,47 +3122,47 @@ func (obj *ToCltMovePlayer) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltLegacyKick) serialize(w io.Writer) {
-	local96 := utf16.Encode([]rune((*(*(struct {
+func (obj *ToCltLegacyKick) serialize(w io.Writer, ver uint16) {
+	local99 := utf16.Encode([]rune((*(*(struct {
 		//mt:utf16
 		Reason string
 	}))(obj)).Reason))
-	if len((local96)) > math.MaxUint16 {
+	if len((local99)) > math.MaxUint16 {
 		chk(ErrTooLong)
 	}
 	{
-		x := uint16(len((local96)))
+		x := uint16(len((local99)))
 		write16(w, uint16(x))
 	}
-	for local97 := range local96 {
+	for local100 := range local99 {
 		{
-			x := (local96)[local97]
+			x := (local99)[local100]
 			write16(w, uint16(x))
 		}
 	}
 }
 
-func (obj *ToCltLegacyKick) deserialize(r io.Reader) {
-	var local98 []uint16
-	var local99 uint16
+func (obj *ToCltLegacyKick) deserialize(r io.Reader, ver uint16) {
+	var local101 []uint16
+	var local102 uint16
 	{
-		p := &local99
+		p := &local102
 		*p = read16(r)
 	}
-	(local98) = make([]uint16, local99)
-	for local100 := range local98 {
+	(local101) = make([]uint16, local102)
+	for local103 := range local101 {
 		{
-			p := &(local98)[local100]
+			p := &(local101)[local103]
 			*p = read16(r)
 		}
 	}
 	(*(*(struct {
 		//mt:utf16
 		Reason string
-	}))(obj)).Reason = string(utf16.Decode(local98))
+	}))(obj)).Reason = string(utf16.Decode(local101))
 }
 
-func (obj *ToCltFOV) serialize(w io.Writer) {
+func (obj *ToCltFOV) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			FOV            float32
@@ -3049,7 +3193,7 @@ func (obj *ToCltFOV) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltFOV) deserialize(r io.Reader) {
+func (obj *ToCltFOV) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			FOV            float32
@@ -3083,7 +3227,7 @@ func (obj *ToCltFOV) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltDeathScreen) serialize(w io.Writer) {
+func (obj *ToCltDeathScreen) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			PointCam bool
@@ -3099,7 +3243,7 @@ func (obj *ToCltDeathScreen) serialize(w io.Writer) {
 		((*(*(struct {
 			PointCam bool
 			PointAt  Pos
-		}))(obj)).PointAt).serialize(w)
+		}))(obj)).PointAt).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -3108,7 +3252,7 @@ func (obj *ToCltDeathScreen) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltDeathScreen) deserialize(r io.Reader) {
+func (obj *ToCltDeathScreen) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			PointCam bool
@@ -3127,7 +3271,7 @@ func (obj *ToCltDeathScreen) deserialize(r io.Reader) {
 		((*(*(struct {
 			PointCam bool
 			PointAt  Pos
-		}))(obj)).PointAt).deserialize(r)
+		}))(obj)).PointAt).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -3136,7 +3280,7 @@ func (obj *ToCltDeathScreen) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltMedia) serialize(w io.Writer) {
+func (obj *ToCltMedia) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			// N is the total number of ToCltMedia packets.
@@ -3200,7 +3344,7 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 		}))(obj)).Files)))
 		write32(w, uint32(x))
 	}
-	for local101 := range (*(*(struct {
+	for local104 := range (*(*(struct {
 		// N is the total number of ToCltMedia packets.
 		// I is the index of this packet.
 		N, I uint16
@@ -3225,7 +3369,7 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 				//mt:len32
 				Data []byte
 			}
-		}))(obj)).Files)[local101]).Name))) > math.MaxUint16 {
+		}))(obj)).Files)[local104]).Name))) > math.MaxUint16 {
 			chk(ErrTooLong)
 		}
 		{
@@ -3241,7 +3385,7 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 					//mt:len32
 					Data []byte
 				}
-			}))(obj)).Files)[local101]).Name))))
+			}))(obj)).Files)[local104]).Name))))
 			write16(w, uint16(x))
 		}
 		{
@@ -3257,7 +3401,7 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 					//mt:len32
 					Data []byte
 				}
-			}))(obj)).Files)[local101]).Name))[:])
+			}))(obj)).Files)[local104]).Name))[:])
 			chk(err)
 		}
 		if len(((((*(*(struct {
@@ -3272,7 +3416,7 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 				//mt:len32
 				Data []byte
 			}
-		}))(obj)).Files)[local101]).Data)) > math.MaxUint32 {
+		}))(obj)).Files)[local104]).Data)) > math.MaxUint32 {
 			chk(ErrTooLong)
 		}
 		{
@@ -3288,7 +3432,7 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 					//mt:len32
 					Data []byte
 				}
-			}))(obj)).Files)[local101]).Data)))
+			}))(obj)).Files)[local104]).Data)))
 			write32(w, uint32(x))
 		}
 		{
@@ -3304,13 +3448,13 @@ func (obj *ToCltMedia) serialize(w io.Writer) {
 					//mt:len32
 					Data []byte
 				}
-			}))(obj)).Files)[local101]).Data)[:])
+			}))(obj)).Files)[local104]).Data)[:])
 			chk(err)
 		}
 	}
 }
 
-func (obj *ToCltMedia) deserialize(r io.Reader) {
+func (obj *ToCltMedia) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			// N is the total number of ToCltMedia packets.
@@ -3343,9 +3487,9 @@ func (obj *ToCltMedia) deserialize(r io.Reader) {
 		}))(obj)).I
 		*p = read16(r)
 	}
-	var local102 uint32
+	var local105 uint32
 	{
-		p := &local102
+		p := &local105
 		*p = read32(r)
 	}
 	((*(*(struct {
@@ -3363,8 +3507,8 @@ func (obj *ToCltMedia) deserialize(r io.Reader) {
 	}))(obj)).Files) = make([]struct {
 		Name string
 		Data []byte
-	}, local102)
-	for local103 := range (*(*(struct {
+	}, local105)
+	for local106 := range (*(*(struct {
 		// N is the total number of ToCltMedia packets.
 		// I is the index of this packet.
 		N, I uint16
@@ -3377,15 +3521,15 @@ func (obj *ToCltMedia) deserialize(r io.Reader) {
 			Data []byte
 		}
 	}))(obj)).Files {
-		var local104 []uint8
-		var local105 uint16
+		var local107 []uint8
+		var local108 uint16
 		{
-			p := &local105
+			p := &local108
 			*p = read16(r)
 		}
-		(local104) = make([]uint8, local105)
+		(local107) = make([]uint8, local108)
 		{
-			_, err := io.ReadFull(r, (local104)[:])
+			_, err := io.ReadFull(r, (local107)[:])
 			chk(err)
 		}
 		((((*(*(struct {
@@ -3400,10 +3544,10 @@ func (obj *ToCltMedia) deserialize(r io.Reader) {
 				//mt:len32
 				Data []byte
 			}
-		}))(obj)).Files)[local103]).Name) = string(local104)
-		var local106 uint32
+		}))(obj)).Files)[local106]).Name) = string(local107)
+		var local109 uint32
 		{
-			p := &local106
+			p := &local109
 			*p = read32(r)
 		}
 		((((*(*(struct {
@@ -3418,7 +3562,7 @@ func (obj *ToCltMedia) deserialize(r io.Reader) {
 				//mt:len32
 				Data []byte
 			}
-		}))(obj)).Files)[local103]).Data) = make([]byte, local106)
+		}))(obj)).Files)[local106]).Data) = make([]byte, local109)
 		{
 			_, err := io.ReadFull(r, ((((*(*(struct {
 				// N is the total number of ToCltMedia packets.
@@ -3432,22 +3576,22 @@ func (obj *ToCltMedia) deserialize(r io.Reader) {
 					//mt:len32
 					Data []byte
 				}
-			}))(obj)).Files)[local103]).Data)[:])
+			}))(obj)).Files)[local106]).Data)[:])
 			chk(err)
 		}
 	}
 }
 
-func (obj *ToCltNodeDefs) serialize(w io.Writer) {
+func (obj *ToCltNodeDefs) serialize(w io.Writer, ver uint16) {
 	{
 		ow := w
 		w := new(bytes.Buffer)
 		{
 			w := zlib.NewWriter(w)
 			{
-				local107 := uint8(1)
+				local110 := uint8(1)
 				{
-					x := local107
+					x := local110
 					write8(w, uint8(x))
 				}
 			}
@@ -3464,7 +3608,7 @@ func (obj *ToCltNodeDefs) serialize(w io.Writer) {
 					write16(w, uint16(len(x)))
 					var b bytes.Buffer
 					for i := range x {
-						x[i].serialize(&b)
+						x[i].serialize(&b, ver)
 					}
 					if b.Len() > math.MaxUint32 {
 						chk(ErrTooLong)
@@ -3494,7 +3638,7 @@ func (obj *ToCltNodeDefs) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltNodeDefs) deserialize(r io.Reader) {
+func (obj *ToCltNodeDefs) deserialize(r io.Reader, ver uint16) {
 	{
 		var n uint32
 		{
@@ -3506,14 +3650,14 @@ func (obj *ToCltNodeDefs) deserialize(r io.Reader) {
 			r, err := zlib.NewReader(byteReader{r})
 			chk(err)
 			{
-				var local108 uint8
-				local109 := uint8(1)
+				var local111 uint8
+				local112 := uint8(1)
 				{
-					p := &local108
+					p := &local111
 					*p = read8(r)
 				}
-				if local108 != local109 {
-					chk(fmt.Errorf("const %v: %v", "uint8(1)", local108))
+				if local111 != local112 {
+					chk(fmt.Errorf("const %v: %v", "uint8(1)", local111))
 				}
 			}
 			{
@@ -3526,7 +3670,7 @@ func (obj *ToCltNodeDefs) deserialize(r io.Reader) {
 					*p = make([]NodeDef, read16(r))
 					r := &io.LimitedReader{R: r, N: int64(read32(r))}
 					for i := range *p {
-						(*p)[i].deserialize(r)
+						(*p)[i].deserialize(r, ver)
 					}
 					if r.N > 0 {
 						chk(fmt.Errorf("%d bytes of trailing data", r.N))
@@ -3541,7 +3685,7 @@ func (obj *ToCltNodeDefs) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
+func (obj *ToCltAnnounceMedia) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Files []struct {
 			Name       string
@@ -3561,7 +3705,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 		}))(obj)).Files)))
 		write16(w, uint16(x))
 	}
-	for local110 := range (*(*(struct {
+	for local113 := range (*(*(struct {
 		Files []struct {
 			Name       string
 			Base64SHA1 string
@@ -3574,7 +3718,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 				Base64SHA1 string
 			}
 			URL string
-		}))(obj)).Files)[local110]).Name))) > math.MaxUint16 {
+		}))(obj)).Files)[local113]).Name))) > math.MaxUint16 {
 			chk(ErrTooLong)
 		}
 		{
@@ -3584,7 +3728,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 					Base64SHA1 string
 				}
 				URL string
-			}))(obj)).Files)[local110]).Name))))
+			}))(obj)).Files)[local113]).Name))))
 			write16(w, uint16(x))
 		}
 		{
@@ -3594,7 +3738,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 					Base64SHA1 string
 				}
 				URL string
-			}))(obj)).Files)[local110]).Name))[:])
+			}))(obj)).Files)[local113]).Name))[:])
 			chk(err)
 		}
 		if len(([]byte((((*(*(struct {
@@ -3603,7 +3747,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 				Base64SHA1 string
 			}
 			URL string
-		}))(obj)).Files)[local110]).Base64SHA1))) > math.MaxUint16 {
+		}))(obj)).Files)[local113]).Base64SHA1))) > math.MaxUint16 {
 			chk(ErrTooLong)
 		}
 		{
@@ -3613,7 +3757,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 					Base64SHA1 string
 				}
 				URL string
-			}))(obj)).Files)[local110]).Base64SHA1))))
+			}))(obj)).Files)[local113]).Base64SHA1))))
 			write16(w, uint16(x))
 		}
 		{
@@ -3623,7 +3767,7 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 					Base64SHA1 string
 				}
 				URL string
-			}))(obj)).Files)[local110]).Base64SHA1))[:])
+			}))(obj)).Files)[local113]).Base64SHA1))[:])
 			chk(err)
 		}
 	}
@@ -3658,10 +3802,10 @@ func (obj *ToCltAnnounceMedia) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAnnounceMedia) deserialize(r io.Reader) {
-	var local111 uint16
+func (obj *ToCltAnnounceMedia) deserialize(r io.Reader, ver uint16) {
+	var local114 uint16
 	{
-		p := &local111
+		p := &local114
 		*p = read16(r)
 	}
 	((*(*(struct {
@@ -3673,23 +3817,23 @@ func (obj *ToCltAnnounceMedia) deserialize(r io.Reader) {
 	}))(obj)).Files) = make([]struct {
 		Name       string
 		Base64SHA1 string
-	}, local111)
-	for local112 := range (*(*(struct {
+	}, local114)
+	for local115 := range (*(*(struct {
 		Files []struct {
 			Name       string
 			Base64SHA1 string
 		}
 		URL string
 	}))(obj)).Files {
-		var local113 []uint8
-		var local114 uint16
+		var local116 []uint8
+		var local117 uint16
 		{
-			p := &local114
+			p := &local117
 			*p = read16(r)
 		}
-		(local113) = make([]uint8, local114)
+		(local116) = make([]uint8, local117)
 		{
-			_, err := io.ReadFull(r, (local113)[:])
+			_, err := io.ReadFull(r, (local116)[:])
 			chk(err)
 		}
 		((((*(*(struct {
@@ -3698,16 +3842,16 @@ func (obj *ToCltAnnounceMedia) deserialize(r io.Reader) {
 				Base64SHA1 string
 			}
 			URL string
-		}))(obj)).Files)[local112]).Name) = string(local113)
-		var local115 []uint8
-		var local116 uint16
+		}))(obj)).Files)[local115]).Name) = string(local116)
+		var local118 []uint8
+		var local119 uint16
 		{
-			p := &local116
+			p := &local119
 			*p = read16(r)
 		}
-		(local115) = make([]uint8, local116)
+		(local118) = make([]uint8, local119)
 		{
-			_, err := io.ReadFull(r, (local115)[:])
+			_, err := io.ReadFull(r, (local118)[:])
 			chk(err)
 		}
 		((((*(*(struct {
@@ -3716,17 +3860,17 @@ func (obj *ToCltAnnounceMedia) deserialize(r io.Reader) {
 				Base64SHA1 string
 			}
 			URL string
-		}))(obj)).Files)[local112]).Base64SHA1) = string(local115)
+		}))(obj)).Files)[local115]).Base64SHA1) = string(local118)
 	}
-	var local117 []uint8
-	var local118 uint16
+	var local120 []uint8
+	var local121 uint16
 	{
-		p := &local118
+		p := &local121
 		*p = read16(r)
 	}
-	(local117) = make([]uint8, local118)
+	(local120) = make([]uint8, local121)
 	{
-		_, err := io.ReadFull(r, (local117)[:])
+		_, err := io.ReadFull(r, (local120)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -3735,19 +3879,19 @@ func (obj *ToCltAnnounceMedia) deserialize(r io.Reader) {
 			Base64SHA1 string
 		}
 		URL string
-	}))(obj)).URL) = string(local117)
+	}))(obj)).URL) = string(local120)
 }
 
-func (obj *ToCltItemDefs) serialize(w io.Writer) {
+func (obj *ToCltItemDefs) serialize(w io.Writer, ver uint16) {
 	{
 		ow := w
 		w := new(bytes.Buffer)
 		{
 			w := zlib.NewWriter(w)
 			{
-				local119 := uint8(0)
+				local122 := uint8(0)
 				{
-					x := local119
+					x := local122
 					write8(w, uint8(x))
 				}
 			}
@@ -3764,7 +3908,7 @@ func (obj *ToCltItemDefs) serialize(w io.Writer) {
 				}))(obj)).Defs)))
 				write16(w, uint16(x))
 			}
-			for local120 := range (*(*(struct {
+			for local123 := range (*(*(struct {
 				Defs    []ItemDef
 				Aliases []struct{ Alias, Orig string }
 			}))(obj)).Defs {
@@ -3772,7 +3916,7 @@ func (obj *ToCltItemDefs) serialize(w io.Writer) {
 					(((*(*(struct {
 						Defs    []ItemDef
 						Aliases []struct{ Alias, Orig string }
-					}))(obj)).Defs)[local120]).serialize(w)
+					}))(obj)).Defs)[local123]).serialize(w, ver)
 				}); err != nil {
 					if err == io.EOF {
 						chk(io.EOF)
@@ -3793,48 +3937,48 @@ func (obj *ToCltItemDefs) serialize(w io.Writer) {
 				}))(obj)).Aliases)))
 				write16(w, uint16(x))
 			}
-			for local121 := range (*(*(struct {
+			for local124 := range (*(*(struct {
 				Defs    []ItemDef
 				Aliases []struct{ Alias, Orig string }
 			}))(obj)).Aliases {
 				if len(([]byte((((*(*(struct {
 					Defs    []ItemDef
 					Aliases []struct{ Alias, Orig string }
-				}))(obj)).Aliases)[local121]).Alias))) > math.MaxUint16 {
+				}))(obj)).Aliases)[local124]).Alias))) > math.MaxUint16 {
 					chk(ErrTooLong)
 				}
 				{
 					x := uint16(len(([]byte((((*(*(struct {
 						Defs    []ItemDef
 						Aliases []struct{ Alias, Orig string }
-					}))(obj)).Aliases)[local121]).Alias))))
+					}))(obj)).Aliases)[local124]).Alias))))
 					write16(w, uint16(x))
 				}
 				{
 					_, err := w.Write(([]byte((((*(*(struct {
 						Defs    []ItemDef
 						Aliases []struct{ Alias, Orig string }
-					}))(obj)).Aliases)[local121]).Alias))[:])
+					}))(obj)).Aliases)[local124]).Alias))[:])
 					chk(err)
 				}
 				if len(([]byte((((*(*(struct {
 					Defs    []ItemDef
 					Aliases []struct{ Alias, Orig string }
-				}))(obj)).Aliases)[local121]).Orig))) > math.MaxUint16 {
+				}))(obj)).Aliases)[local124]).Orig))) > math.MaxUint16 {
 					chk(ErrTooLong)
 				}
 				{
 					x := uint16(len(([]byte((((*(*(struct {
 						Defs    []ItemDef
 						Aliases []struct{ Alias, Orig string }
-					}))(obj)).Aliases)[local121]).Orig))))
+					}))(obj)).Aliases)[local124]).Orig))))
 					write16(w, uint16(x))
 				}
 				{
 					_, err := w.Write(([]byte((((*(*(struct {
 						Defs    []ItemDef
 						Aliases []struct{ Alias, Orig string }
-					}))(obj)).Aliases)[local121]).Orig))[:])
+					}))(obj)).Aliases)[local124]).Orig))[:])
 					chk(err)
 				}
 			}
@@ -3858,7 +4002,7 @@ func (obj *ToCltItemDefs) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltItemDefs) deserialize(r io.Reader) {
+func (obj *ToCltItemDefs) deserialize(r io.Reader, ver uint16) {
 	{
 		var n uint32
 		{
@@ -3870,26 +4014,26 @@ func (obj *ToCltItemDefs) deserialize(r io.Reader) {
 			r, err := zlib.NewReader(byteReader{r})
 			chk(err)
 			{
-				var local122 uint8
-				local123 := uint8(0)
+				var local125 uint8
+				local126 := uint8(0)
 				{
-					p := &local122
+					p := &local125
 					*p = read8(r)
 				}
-				if local122 != local123 {
-					chk(fmt.Errorf("const %v: %v", "uint8(0)", local122))
+				if local125 != local126 {
+					chk(fmt.Errorf("const %v: %v", "uint8(0)", local125))
 				}
 			}
-			var local124 uint16
+			var local127 uint16
 			{
-				p := &local124
+				p := &local127
 				*p = read16(r)
 			}
 			((*(*(struct {
 				Defs    []ItemDef
 				Aliases []struct{ Alias, Orig string }
-			}))(obj)).Defs) = make([]ItemDef, local124)
-			for local125 := range (*(*(struct {
+			}))(obj)).Defs) = make([]ItemDef, local127)
+			for local128 := range (*(*(struct {
 				Defs    []ItemDef
 				Aliases []struct{ Alias, Orig string }
 			}))(obj)).Defs {
@@ -3897,7 +4041,7 @@ func (obj *ToCltItemDefs) deserialize(r io.Reader) {
 					(((*(*(struct {
 						Defs    []ItemDef
 						Aliases []struct{ Alias, Orig string }
-					}))(obj)).Defs)[local125]).deserialize(r)
+					}))(obj)).Defs)[local128]).deserialize(r, ver)
 				}); err != nil {
 					if err == io.EOF {
 						chk(io.EOF)
@@ -3905,9 +4049,9 @@ func (obj *ToCltItemDefs) deserialize(r io.Reader) {
 					chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ItemDef", err))
 				}
 			}
-			var local126 uint16
+			var local129 uint16
 			{
-				p := &local126
+				p := &local129
 				*p = read16(r)
 			}
 			((*(*(struct {
@@ -3916,41 +4060,41 @@ func (obj *ToCltItemDefs) deserialize(r io.Reader) {
 			}))(obj)).Aliases) = make([]struct {
 				Alias string
 				Orig  string
-			}, local126)
-			for local127 := range (*(*(struct {
+			}, local129)
+			for local130 := range (*(*(struct {
 				Defs    []ItemDef
 				Aliases []struct{ Alias, Orig string }
 			}))(obj)).Aliases {
-				var local128 []uint8
-				var local129 uint16
+				var local131 []uint8
+				var local132 uint16
 				{
-					p := &local129
+					p := &local132
 					*p = read16(r)
 				}
-				(local128) = make([]uint8, local129)
+				(local131) = make([]uint8, local132)
 				{
-					_, err := io.ReadFull(r, (local128)[:])
+					_, err := io.ReadFull(r, (local131)[:])
 					chk(err)
 				}
 				((((*(*(struct {
 					Defs    []ItemDef
 					Aliases []struct{ Alias, Orig string }
-				}))(obj)).Aliases)[local127]).Alias) = string(local128)
-				var local130 []uint8
-				var local131 uint16
+				}))(obj)).Aliases)[local130]).Alias) = string(local131)
+				var local133 []uint8
+				var local134 uint16
 				{
-					p := &local131
+					p := &local134
 					*p = read16(r)
 				}
-				(local130) = make([]uint8, local131)
+				(local133) = make([]uint8, local134)
 				{
-					_, err := io.ReadFull(r, (local130)[:])
+					_, err := io.ReadFull(r, (local133)[:])
 					chk(err)
 				}
 				((((*(*(struct {
 					Defs    []ItemDef
 					Aliases []struct{ Alias, Orig string }
-				}))(obj)).Aliases)[local127]).Orig) = string(local130)
+				}))(obj)).Aliases)[local130]).Orig) = string(local133)
 			}
 			chk(r.Close())
 		}
@@ -3960,7 +4104,7 @@ func (obj *ToCltItemDefs) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltPlaySound) serialize(w io.Writer) {
+func (obj *ToCltPlaySound) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID      SoundID
@@ -3973,7 +4117,7 @@ func (obj *ToCltPlaySound) serialize(w io.Writer) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4051,7 +4195,7 @@ func (obj *ToCltPlaySound) serialize(w io.Writer) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).SrcType).serialize(w)
+		}))(obj)).SrcType).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4070,7 +4214,7 @@ func (obj *ToCltPlaySound) serialize(w io.Writer) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4089,7 +4233,7 @@ func (obj *ToCltPlaySound) serialize(w io.Writer) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).SrcAOID).serialize(w)
+		}))(obj)).SrcAOID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4166,7 +4310,7 @@ func (obj *ToCltPlaySound) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltPlaySound) deserialize(r io.Reader) {
+func (obj *ToCltPlaySound) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID      SoundID
@@ -4179,22 +4323,22 @@ func (obj *ToCltPlaySound) deserialize(r io.Reader) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.SoundID", err))
 	}
-	var local132 []uint8
-	var local133 uint16
+	var local135 []uint8
+	var local136 uint16
 	{
-		p := &local133
+		p := &local136
 		*p = read16(r)
 	}
-	(local132) = make([]uint8, local133)
+	(local135) = make([]uint8, local136)
 	{
-		_, err := io.ReadFull(r, (local132)[:])
+		_, err := io.ReadFull(r, (local135)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -4208,7 +4352,7 @@ func (obj *ToCltPlaySound) deserialize(r io.Reader) {
 		Fade      float32
 		Pitch     float32
 		Ephemeral bool
-	}))(obj)).Name) = string(local132)
+	}))(obj)).Name) = string(local135)
 	{
 		p := &(*(*(struct {
 			ID      SoundID
@@ -4236,7 +4380,7 @@ func (obj *ToCltPlaySound) deserialize(r io.Reader) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).SrcType).deserialize(r)
+		}))(obj)).SrcType).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4255,7 +4399,7 @@ func (obj *ToCltPlaySound) deserialize(r io.Reader) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4274,7 +4418,7 @@ func (obj *ToCltPlaySound) deserialize(r io.Reader) {
 			Fade      float32
 			Pitch     float32
 			Ephemeral bool
-		}))(obj)).SrcAOID).deserialize(r)
+		}))(obj)).SrcAOID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4357,11 +4501,11 @@ func (obj *ToCltPlaySound) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltStopSound) serialize(w io.Writer) {
+func (obj *ToCltStopSound) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID SoundID
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4370,11 +4514,11 @@ func (obj *ToCltStopSound) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltStopSound) deserialize(r io.Reader) {
+func (obj *ToCltStopSound) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID SoundID
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -4383,7 +4527,7 @@ func (obj *ToCltStopSound) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltPrivs) serialize(w io.Writer) {
+func (obj *ToCltPrivs) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Privs []string
 	}))(obj)).Privs)) > math.MaxUint16 {
@@ -4395,59 +4539,59 @@ func (obj *ToCltPrivs) serialize(w io.Writer) {
 		}))(obj)).Privs)))
 		write16(w, uint16(x))
 	}
-	for local134 := range (*(*(struct {
+	for local137 := range (*(*(struct {
 		Privs []string
 	}))(obj)).Privs {
 		if len(([]byte(((*(*(struct {
 			Privs []string
-		}))(obj)).Privs)[local134]))) > math.MaxUint16 {
+		}))(obj)).Privs)[local137]))) > math.MaxUint16 {
 			chk(ErrTooLong)
 		}
 		{
 			x := uint16(len(([]byte(((*(*(struct {
 				Privs []string
-			}))(obj)).Privs)[local134]))))
+			}))(obj)).Privs)[local137]))))
 			write16(w, uint16(x))
 		}
 		{
 			_, err := w.Write(([]byte(((*(*(struct {
 				Privs []string
-			}))(obj)).Privs)[local134]))[:])
+			}))(obj)).Privs)[local137]))[:])
 			chk(err)
 		}
 	}
 }
 
-func (obj *ToCltPrivs) deserialize(r io.Reader) {
-	var local135 uint16
+func (obj *ToCltPrivs) deserialize(r io.Reader, ver uint16) {
+	var local138 uint16
 	{
-		p := &local135
+		p := &local138
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Privs []string
-	}))(obj)).Privs) = make([]string, local135)
-	for local136 := range (*(*(struct {
+	}))(obj)).Privs) = make([]string, local138)
+	for local139 := range (*(*(struct {
 		Privs []string
 	}))(obj)).Privs {
-		var local137 []uint8
-		var local138 uint16
+		var local140 []uint8
+		var local141 uint16
 		{
-			p := &local138
+			p := &local141
 			*p = read16(r)
 		}
-		(local137) = make([]uint8, local138)
+		(local140) = make([]uint8, local141)
 		{
-			_, err := io.ReadFull(r, (local137)[:])
+			_, err := io.ReadFull(r, (local140)[:])
 			chk(err)
 		}
 		(((*(*(struct {
 			Privs []string
-		}))(obj)).Privs)[local136]) = string(local137)
+		}))(obj)).Privs)[local139]) = string(local140)
 	}
 }
 
-func (obj *ToCltInvFormspec) serialize(w io.Writer) {
+func (obj *ToCltInvFormspec) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		//mt:len32
 		Formspec string
@@ -4470,25 +4614,25 @@ func (obj *ToCltInvFormspec) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltInvFormspec) deserialize(r io.Reader) {
-	var local139 []uint8
-	var local140 uint32
+func (obj *ToCltInvFormspec) deserialize(r io.Reader, ver uint16) {
+	var local142 []uint8
+	var local143 uint32
 	{
-		p := &local140
+		p := &local143
 		*p = read32(r)
 	}
-	(local139) = make([]uint8, local140)
+	(local142) = make([]uint8, local143)
 	{
-		_, err := io.ReadFull(r, (local139)[:])
+		_, err := io.ReadFull(r, (local142)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		//mt:len32
 		Formspec string
-	}))(obj)).Formspec) = string(local139)
+	}))(obj)).Formspec) = string(local142)
 }
 
-func (obj *ToCltDetachedInv) serialize(w io.Writer) {
+func (obj *ToCltDetachedInv) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Name string
 		Keep bool
@@ -4560,16 +4704,16 @@ func (obj *ToCltDetachedInv) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltDetachedInv) deserialize(r io.Reader) {
-	var local141 []uint8
-	var local142 uint16
+func (obj *ToCltDetachedInv) deserialize(r io.Reader, ver uint16) {
+	var local144 []uint8
+	var local145 uint16
 	{
-		p := &local142
+		p := &local145
 		*p = read16(r)
 	}
-	(local141) = make([]uint8, local142)
+	(local144) = make([]uint8, local145)
 	{
-		_, err := io.ReadFull(r, (local141)[:])
+		_, err := io.ReadFull(r, (local144)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -4579,7 +4723,7 @@ func (obj *ToCltDetachedInv) deserialize(r io.Reader) {
 
 		//mt:raw
 		Inv string
-	}))(obj)).Name) = string(local141)
+	}))(obj)).Name) = string(local144)
 	{
 		p := &(*(*(struct {
 			Name string
@@ -4609,10 +4753,10 @@ func (obj *ToCltDetachedInv) deserialize(r io.Reader) {
 		}))(obj)).Len
 		*p = read16(r)
 	}
-	var local143 []uint8
+	var local146 []uint8
 	{
 		var err error
-		(local143), err = io.ReadAll(r)
+		(local146), err = io.ReadAll(r)
 		chk(err)
 	}
 	((*(*(struct {
@@ -4622,10 +4766,10 @@ func (obj *ToCltDetachedInv) deserialize(r io.Reader) {
 
 		//mt:raw
 		Inv string
-	}))(obj)).Inv) = string(local143)
+	}))(obj)).Inv) = string(local146)
 }
 
-func (obj *ToCltShowFormspec) serialize(w io.Writer) {
+func (obj *ToCltShowFormspec) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		//mt:len32
 		Formspec string
@@ -4680,16 +4824,16 @@ func (obj *ToCltShowFormspec) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltShowFormspec) deserialize(r io.Reader) {
-	var local144 []uint8
-	var local145 uint32
+func (obj *ToCltShowFormspec) deserialize(r io.Reader, ver uint16) {
+	var local147 []uint8
+	var local148 uint32
 	{
-		p := &local145
+		p := &local148
 		*p = read32(r)
 	}
-	(local144) = make([]uint8, local145)
+	(local147) = make([]uint8, local148)
 	{
-		_, err := io.ReadFull(r, (local144)[:])
+		_, err := io.ReadFull(r, (local147)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -4697,16 +4841,16 @@ func (obj *ToCltShowFormspec) deserialize(r io.Reader) {
 		Formspec string
 
 		Formname string
-	}))(obj)).Formspec) = string(local144)
-	var local146 []uint8
-	var local147 uint16
+	}))(obj)).Formspec) = string(local147)
+	var local149 []uint8
+	var local150 uint16
 	{
-		p := &local147
+		p := &local150
 		*p = read16(r)
 	}
-	(local146) = make([]uint8, local147)
+	(local149) = make([]uint8, local150)
 	{
-		_, err := io.ReadFull(r, (local146)[:])
+		_, err := io.ReadFull(r, (local149)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -4714,10 +4858,10 @@ func (obj *ToCltShowFormspec) deserialize(r io.Reader) {
 		Formspec string
 
 		Formname string
-	}))(obj)).Formname) = string(local146)
+	}))(obj)).Formname) = string(local149)
 }
 
-func (obj *ToCltMovement) serialize(w io.Writer) {
+func (obj *ToCltMovement) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			DefaultAccel, AirAccel, FastAccel,
@@ -4828,7 +4972,7 @@ func (obj *ToCltMovement) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltMovement) deserialize(r io.Reader) {
+func (obj *ToCltMovement) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			DefaultAccel, AirAccel, FastAccel,
@@ -4939,8 +5083,8 @@ func (obj *ToCltMovement) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
-	for local148 := range (*(*(struct {
+func (obj *ToCltSpawnParticle) serialize(w io.Writer, ver uint16) {
+	for local151 := range (*(*(struct {
 		Pos, Vel, Acc  [3]float32
 		ExpirationTime float32 // in seconds.
 		Size           float32
@@ -4976,11 +5120,11 @@ func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
 				NodeParam0  Content
 				NodeParam2  uint8
 				NodeTile    uint8
-			}))(obj)).Pos)[local148]
+			}))(obj)).Pos)[local151]
 			write32(w, math.Float32bits(x))
 		}
 	}
-	for local149 := range (*(*(struct {
+	for local152 := range (*(*(struct {
 		Pos, Vel, Acc  [3]float32
 		ExpirationTime float32 // in seconds.
 		Size           float32
@@ -5016,11 +5160,11 @@ func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
 				NodeParam0  Content
 				NodeParam2  uint8
 				NodeTile    uint8
-			}))(obj)).Vel)[local149]
+			}))(obj)).Vel)[local152]
 			write32(w, math.Float32bits(x))
 		}
 	}
-	for local150 := range (*(*(struct {
+	for local153 := range (*(*(struct {
 		Pos, Vel, Acc  [3]float32
 		ExpirationTime float32 // in seconds.
 		Size           float32
@@ -5056,7 +5200,7 @@ func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
 				NodeParam0  Content
 				NodeParam2  uint8
 				NodeTile    uint8
-			}))(obj)).Acc)[local150]
+			}))(obj)).Acc)[local153]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -5257,7 +5401,7 @@ func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
 			NodeParam0  Content
 			NodeParam2  uint8
 			NodeTile    uint8
-		}))(obj)).AnimParams).serialize(w)
+		}))(obj)).AnimParams).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -5328,7 +5472,7 @@ func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
 			NodeParam0  Content
 			NodeParam2  uint8
 			NodeTile    uint8
-		}))(obj)).NodeParam0).serialize(w)
+		}))(obj)).NodeParam0).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -5379,8 +5523,8 @@ func (obj *ToCltSpawnParticle) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
-	for local151 := range (*(*(struct {
+func (obj *ToCltSpawnParticle) deserialize(r io.Reader, ver uint16) {
+	for local154 := range (*(*(struct {
 		Pos, Vel, Acc  [3]float32
 		ExpirationTime float32 // in seconds.
 		Size           float32
@@ -5416,11 +5560,11 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 				NodeParam0  Content
 				NodeParam2  uint8
 				NodeTile    uint8
-			}))(obj)).Pos)[local151]
+			}))(obj)).Pos)[local154]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	for local152 := range (*(*(struct {
+	for local155 := range (*(*(struct {
 		Pos, Vel, Acc  [3]float32
 		ExpirationTime float32 // in seconds.
 		Size           float32
@@ -5456,11 +5600,11 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 				NodeParam0  Content
 				NodeParam2  uint8
 				NodeTile    uint8
-			}))(obj)).Vel)[local152]
+			}))(obj)).Vel)[local155]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	for local153 := range (*(*(struct {
+	for local156 := range (*(*(struct {
 		Pos, Vel, Acc  [3]float32
 		ExpirationTime float32 // in seconds.
 		Size           float32
@@ -5496,7 +5640,7 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 				NodeParam0  Content
 				NodeParam2  uint8
 				NodeTile    uint8
-			}))(obj)).Acc)[local153]
+			}))(obj)).Acc)[local156]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
@@ -5570,15 +5714,15 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 			chk(fmt.Errorf("invalid bool: %d", n))
 		}
 	}
-	var local154 []uint8
-	var local155 uint32
+	var local157 []uint8
+	var local158 uint32
 	{
-		p := &local155
+		p := &local158
 		*p = read32(r)
 	}
-	(local154) = make([]uint8, local155)
+	(local157) = make([]uint8, local158)
 	{
-		_, err := io.ReadFull(r, (local154)[:])
+		_, err := io.ReadFull(r, (local157)[:])
 		chk(err)
 	}
 	(*(*string)(&((*(*(struct {
@@ -5598,7 +5742,7 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 		NodeParam0  Content
 		NodeParam2  uint8
 		NodeTile    uint8
-	}))(obj)).Texture))) = string(local154)
+	}))(obj)).Texture))) = string(local157)
 	{
 		p := &(*(*(struct {
 			Pos, Vel, Acc  [3]float32
@@ -5673,7 +5817,7 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 			NodeParam0  Content
 			NodeParam2  uint8
 			NodeTile    uint8
-		}))(obj)).AnimParams).deserialize(r)
+		}))(obj)).AnimParams).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -5747,7 +5891,7 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 			NodeParam0  Content
 			NodeParam2  uint8
 			NodeTile    uint8
-		}))(obj)).NodeParam0).deserialize(r)
+		}))(obj)).NodeParam0).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -5798,7 +5942,7 @@ func (obj *ToCltSpawnParticle) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
+func (obj *ToCltAddParticleSpawner) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Amount         uint16
@@ -5849,7 +5993,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 		}))(obj)).Duration
 		write32(w, math.Float32bits(x))
 	}
-	for local156 := range (*(*(struct {
+	for local159 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -5871,7 +6015,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 		NodeParam2   uint8
 		NodeTile     uint8
 	}))(obj)).Pos {
-		for local157 := range ((*(*(struct {
+		for local160 := range ((*(*(struct {
 			Amount         uint16
 			Duration       float32
 			Pos, Vel, Acc  [2][3]float32
@@ -5892,7 +6036,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).Pos)[local156] {
+		}))(obj)).Pos)[local159] {
 			{
 				x := (((*(*(struct {
 					Amount         uint16
@@ -5915,12 +6059,12 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 					NodeParam0   Content
 					NodeParam2   uint8
 					NodeTile     uint8
-				}))(obj)).Pos)[local156])[local157]
+				}))(obj)).Pos)[local159])[local160]
 				write32(w, math.Float32bits(x))
 			}
 		}
 	}
-	for local158 := range (*(*(struct {
+	for local161 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -5942,7 +6086,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 		NodeParam2   uint8
 		NodeTile     uint8
 	}))(obj)).Vel {
-		for local159 := range ((*(*(struct {
+		for local162 := range ((*(*(struct {
 			Amount         uint16
 			Duration       float32
 			Pos, Vel, Acc  [2][3]float32
@@ -5963,7 +6107,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).Vel)[local158] {
+		}))(obj)).Vel)[local161] {
 			{
 				x := (((*(*(struct {
 					Amount         uint16
@@ -5986,12 +6130,12 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 					NodeParam0   Content
 					NodeParam2   uint8
 					NodeTile     uint8
-				}))(obj)).Vel)[local158])[local159]
+				}))(obj)).Vel)[local161])[local162]
 				write32(w, math.Float32bits(x))
 			}
 		}
 	}
-	for local160 := range (*(*(struct {
+	for local163 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6013,7 +6157,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 		NodeParam2   uint8
 		NodeTile     uint8
 	}))(obj)).Acc {
-		for local161 := range ((*(*(struct {
+		for local164 := range ((*(*(struct {
 			Amount         uint16
 			Duration       float32
 			Pos, Vel, Acc  [2][3]float32
@@ -6034,7 +6178,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).Acc)[local160] {
+		}))(obj)).Acc)[local163] {
 			{
 				x := (((*(*(struct {
 					Amount         uint16
@@ -6057,12 +6201,12 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 					NodeParam0   Content
 					NodeParam2   uint8
 					NodeTile     uint8
-				}))(obj)).Acc)[local160])[local161]
+				}))(obj)).Acc)[local163])[local164]
 				write32(w, math.Float32bits(x))
 			}
 		}
 	}
-	for local162 := range (*(*(struct {
+	for local165 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6106,11 +6250,11 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 				NodeParam0   Content
 				NodeParam2   uint8
 				NodeTile     uint8
-			}))(obj)).ExpirationTime)[local162]
+			}))(obj)).ExpirationTime)[local165]
 			write32(w, math.Float32bits(x))
 		}
 	}
-	for local163 := range (*(*(struct {
+	for local166 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6154,7 +6298,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 				NodeParam0   Content
 				NodeParam2   uint8
 				NodeTile     uint8
-			}))(obj)).Size)[local163]
+			}))(obj)).Size)[local166]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -6283,7 +6427,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -6370,7 +6514,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).AttachedAOID).serialize(w)
+		}))(obj)).AttachedAOID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -6399,7 +6543,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).AnimParams).serialize(w)
+		}))(obj)).AnimParams).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -6482,7 +6626,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).NodeParam0).serialize(w)
+		}))(obj)).NodeParam0).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -6541,7 +6685,7 @@ func (obj *ToCltAddParticleSpawner) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
+func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Amount         uint16
@@ -6592,7 +6736,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 		}))(obj)).Duration
 		*p = math.Float32frombits(read32(r))
 	}
-	for local164 := range (*(*(struct {
+	for local167 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6614,7 +6758,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 		NodeParam2   uint8
 		NodeTile     uint8
 	}))(obj)).Pos {
-		for local165 := range ((*(*(struct {
+		for local168 := range ((*(*(struct {
 			Amount         uint16
 			Duration       float32
 			Pos, Vel, Acc  [2][3]float32
@@ -6635,7 +6779,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).Pos)[local164] {
+		}))(obj)).Pos)[local167] {
 			{
 				p := &(((*(*(struct {
 					Amount         uint16
@@ -6658,12 +6802,12 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 					NodeParam0   Content
 					NodeParam2   uint8
 					NodeTile     uint8
-				}))(obj)).Pos)[local164])[local165]
+				}))(obj)).Pos)[local167])[local168]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
 	}
-	for local166 := range (*(*(struct {
+	for local169 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6685,7 +6829,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 		NodeParam2   uint8
 		NodeTile     uint8
 	}))(obj)).Vel {
-		for local167 := range ((*(*(struct {
+		for local170 := range ((*(*(struct {
 			Amount         uint16
 			Duration       float32
 			Pos, Vel, Acc  [2][3]float32
@@ -6706,7 +6850,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).Vel)[local166] {
+		}))(obj)).Vel)[local169] {
 			{
 				p := &(((*(*(struct {
 					Amount         uint16
@@ -6729,12 +6873,12 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 					NodeParam0   Content
 					NodeParam2   uint8
 					NodeTile     uint8
-				}))(obj)).Vel)[local166])[local167]
+				}))(obj)).Vel)[local169])[local170]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
 	}
-	for local168 := range (*(*(struct {
+	for local171 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6756,7 +6900,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 		NodeParam2   uint8
 		NodeTile     uint8
 	}))(obj)).Acc {
-		for local169 := range ((*(*(struct {
+		for local172 := range ((*(*(struct {
 			Amount         uint16
 			Duration       float32
 			Pos, Vel, Acc  [2][3]float32
@@ -6777,7 +6921,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).Acc)[local168] {
+		}))(obj)).Acc)[local171] {
 			{
 				p := &(((*(*(struct {
 					Amount         uint16
@@ -6800,12 +6944,12 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 					NodeParam0   Content
 					NodeParam2   uint8
 					NodeTile     uint8
-				}))(obj)).Acc)[local168])[local169]
+				}))(obj)).Acc)[local171])[local172]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
 	}
-	for local170 := range (*(*(struct {
+	for local173 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6849,11 +6993,11 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 				NodeParam0   Content
 				NodeParam2   uint8
 				NodeTile     uint8
-			}))(obj)).ExpirationTime)[local170]
+			}))(obj)).ExpirationTime)[local173]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	for local171 := range (*(*(struct {
+	for local174 := range (*(*(struct {
 		Amount         uint16
 		Duration       float32
 		Pos, Vel, Acc  [2][3]float32
@@ -6897,7 +7041,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 				NodeParam0   Content
 				NodeParam2   uint8
 				NodeTile     uint8
-			}))(obj)).Size)[local171]
+			}))(obj)).Size)[local174]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
@@ -6933,15 +7077,15 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			chk(fmt.Errorf("invalid bool: %d", n))
 		}
 	}
-	var local172 []uint8
-	var local173 uint32
+	var local175 []uint8
+	var local176 uint32
 	{
-		p := &local173
+		p := &local176
 		*p = read32(r)
 	}
-	(local172) = make([]uint8, local173)
+	(local175) = make([]uint8, local176)
 	{
-		_, err := io.ReadFull(r, (local172)[:])
+		_, err := io.ReadFull(r, (local175)[:])
 		chk(err)
 	}
 	(*(*string)(&((*(*(struct {
@@ -6965,7 +7109,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 		NodeParam0   Content
 		NodeParam2   uint8
 		NodeTile     uint8
-	}))(obj)).Texture))) = string(local172)
+	}))(obj)).Texture))) = string(local175)
 	if err := pcall(func() {
 		((*(*(struct {
 			Amount         uint16
@@ -6988,7 +7132,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7081,7 +7225,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).AttachedAOID).deserialize(r)
+		}))(obj)).AttachedAOID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7110,7 +7254,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).AnimParams).deserialize(r)
+		}))(obj)).AnimParams).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7196,7 +7340,7 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 			NodeParam0   Content
 			NodeParam2   uint8
 			NodeTile     uint8
-		}))(obj)).NodeParam0).deserialize(r)
+		}))(obj)).NodeParam0).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7255,12 +7399,12 @@ func (obj *ToCltAddParticleSpawner) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltAddHUD) serialize(w io.Writer) {
+func (obj *ToCltAddHUD) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID HUDID
 			HUD
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7271,7 +7415,7 @@ func (obj *ToCltAddHUD) serialize(w io.Writer) {
 		((*(*(struct {
 			ID HUDID
 			HUD
-		}))(obj)).HUD).serialize(w)
+		}))(obj)).HUD).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7280,12 +7424,12 @@ func (obj *ToCltAddHUD) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltAddHUD) deserialize(r io.Reader) {
+func (obj *ToCltAddHUD) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID HUDID
 			HUD
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7296,7 +7440,7 @@ func (obj *ToCltAddHUD) deserialize(r io.Reader) {
 		((*(*(struct {
 			ID HUDID
 			HUD
-		}))(obj)).HUD).deserialize(r)
+		}))(obj)).HUD).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7305,11 +7449,11 @@ func (obj *ToCltAddHUD) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltRmHUD) serialize(w io.Writer) {
+func (obj *ToCltRmHUD) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID HUDID
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7318,11 +7462,11 @@ func (obj *ToCltRmHUD) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltRmHUD) deserialize(r io.Reader) {
+func (obj *ToCltRmHUD) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID HUDID
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7331,7 +7475,7 @@ func (obj *ToCltRmHUD) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltChangeHUD) serialize(w io.Writer) {
+func (obj *ToCltChangeHUD) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID HUDID
@@ -7376,7 +7520,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7427,7 +7571,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).Field).serialize(w)
+		}))(obj)).Field).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -7524,7 +7668,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDPos {
-		for local174 := range (*(*(struct {
+		for local177 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -7612,7 +7756,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Pos)[local174]
+				}))(obj)).Pos)[local177]
 				write32(w, math.Float32bits(x))
 			}
 		}
@@ -7846,7 +7990,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDScale {
-		for local175 := range (*(*(struct {
+		for local178 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -7934,7 +8078,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Scale)[local175]
+				}))(obj)).Scale)[local178]
 				write32(w, math.Float32bits(x))
 			}
 		}
@@ -8444,7 +8588,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDAlign {
-		for local176 := range (*(*(struct {
+		for local179 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -8532,7 +8676,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Align)[local176]
+				}))(obj)).Align)[local179]
 				write32(w, math.Float32bits(x))
 			}
 		}
@@ -8581,7 +8725,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDOffset {
-		for local177 := range (*(*(struct {
+		for local180 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -8669,7 +8813,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Offset)[local177]
+				}))(obj)).Offset)[local180]
 				write32(w, math.Float32bits(x))
 			}
 		}
@@ -8762,7 +8906,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 				//mt:if %s.Field == HUDText2
 				Text2 string
-			}))(obj)).WorldPos).serialize(w)
+			}))(obj)).WorldPos).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -8814,7 +8958,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDSize {
-		for local178 := range (*(*(struct {
+		for local181 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -8902,7 +9046,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Size)[local178]
+				}))(obj)).Size)[local181]
 				write32(w, uint32(x))
 			}
 		}
@@ -9186,7 +9330,7 @@ func (obj *ToCltChangeHUD) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
+func (obj *ToCltChangeHUD) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID HUDID
@@ -9231,7 +9375,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -9282,7 +9426,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).Field).deserialize(r)
+		}))(obj)).Field).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -9379,7 +9523,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDPos {
-		for local179 := range (*(*(struct {
+		for local182 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -9467,7 +9611,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Pos)[local179]
+				}))(obj)).Pos)[local182]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
@@ -9516,15 +9660,15 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDName {
-		var local180 []uint8
-		var local181 uint16
+		var local183 []uint8
+		var local184 uint16
 		{
-			p := &local181
+			p := &local184
 			*p = read16(r)
 		}
-		(local180) = make([]uint8, local181)
+		(local183) = make([]uint8, local184)
 		{
-			_, err := io.ReadFull(r, (local180)[:])
+			_, err := io.ReadFull(r, (local183)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -9570,7 +9714,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).Name) = string(local180)
+		}))(obj)).Name) = string(local183)
 	}
 	if (*(*(struct {
 		ID HUDID
@@ -9616,7 +9760,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDScale {
-		for local182 := range (*(*(struct {
+		for local185 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -9704,7 +9848,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Scale)[local182]
+				}))(obj)).Scale)[local185]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
@@ -9753,15 +9897,15 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDText {
-		var local183 []uint8
-		var local184 uint16
+		var local186 []uint8
+		var local187 uint16
 		{
-			p := &local184
+			p := &local187
 			*p = read16(r)
 		}
-		(local183) = make([]uint8, local184)
+		(local186) = make([]uint8, local187)
 		{
-			_, err := io.ReadFull(r, (local183)[:])
+			_, err := io.ReadFull(r, (local186)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -9807,7 +9951,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).Text) = string(local183)
+		}))(obj)).Text) = string(local186)
 	}
 	if (*(*(struct {
 		ID HUDID
@@ -10129,7 +10273,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDAlign {
-		for local185 := range (*(*(struct {
+		for local188 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -10217,7 +10361,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Align)[local185]
+				}))(obj)).Align)[local188]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
@@ -10266,7 +10410,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDOffset {
-		for local186 := range (*(*(struct {
+		for local189 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -10354,7 +10498,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Offset)[local186]
+				}))(obj)).Offset)[local189]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
@@ -10447,7 +10591,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 				//mt:if %s.Field == HUDText2
 				Text2 string
-			}))(obj)).WorldPos).deserialize(r)
+			}))(obj)).WorldPos).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -10499,7 +10643,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDSize {
-		for local187 := range (*(*(struct {
+		for local190 := range (*(*(struct {
 			ID HUDID
 
 			Field HUDField
@@ -10587,7 +10731,7 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 					//mt:if %s.Field == HUDText2
 					Text2 string
-				}))(obj)).Size)[local187]
+				}))(obj)).Size)[local190]
 				*p = int32(read32(r))
 			}
 		}
@@ -10728,15 +10872,15 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 		//mt:if %s.Field == HUDText2
 		Text2 string
 	}))(obj)).Field == HUDText2 {
-		var local188 []uint8
-		var local189 uint16
+		var local191 []uint8
+		var local192 uint16
 		{
-			p := &local189
+			p := &local192
 			*p = read16(r)
 		}
-		(local188) = make([]uint8, local189)
+		(local191) = make([]uint8, local192)
 		{
-			_, err := io.ReadFull(r, (local188)[:])
+			_, err := io.ReadFull(r, (local191)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -10782,17 +10926,17 @@ func (obj *ToCltChangeHUD) deserialize(r io.Reader) {
 
 			//mt:if %s.Field == HUDText2
 			Text2 string
-		}))(obj)).Text2) = string(local188)
+		}))(obj)).Text2) = string(local191)
 	}
 }
 
-func (obj *ToCltHUDFlags) serialize(w io.Writer) {
+func (obj *ToCltHUDFlags) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			// &^= Mask
 			// |= Flags
 			Flags, Mask HUDFlags
-		}))(obj)).Flags).serialize(w)
+		}))(obj)).Flags).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -10804,7 +10948,7 @@ func (obj *ToCltHUDFlags) serialize(w io.Writer) {
 			// &^= Mask
 			// |= Flags
 			Flags, Mask HUDFlags
-		}))(obj)).Mask).serialize(w)
+		}))(obj)).Mask).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -10813,13 +10957,13 @@ func (obj *ToCltHUDFlags) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltHUDFlags) deserialize(r io.Reader) {
+func (obj *ToCltHUDFlags) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			// &^= Mask
 			// |= Flags
 			Flags, Mask HUDFlags
-		}))(obj)).Flags).deserialize(r)
+		}))(obj)).Flags).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -10831,7 +10975,7 @@ func (obj *ToCltHUDFlags) deserialize(r io.Reader) {
 			// &^= Mask
 			// |= Flags
 			Flags, Mask HUDFlags
-		}))(obj)).Mask).deserialize(r)
+		}))(obj)).Mask).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -10840,7 +10984,7 @@ func (obj *ToCltHUDFlags) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltSetHotbarParam) serialize(w io.Writer) {
+func (obj *ToCltSetHotbarParam) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Param HotbarParam
@@ -10851,7 +10995,7 @@ func (obj *ToCltSetHotbarParam) serialize(w io.Writer) {
 
 			//mt:if %s.Param != HotbarSize
 			Img Texture
-		}))(obj)).Param).serialize(w)
+		}))(obj)).Param).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -10869,9 +11013,9 @@ func (obj *ToCltSetHotbarParam) serialize(w io.Writer) {
 		Img Texture
 	}))(obj)).Param == HotbarSize {
 		{
-			local190 := uint16(4) // Size of Size field.
+			local193 := uint16(4) // Size of Size field.
 			{
-				x := local190
+				x := local193
 				write16(w, uint16(x))
 			}
 		}
@@ -10909,7 +11053,7 @@ func (obj *ToCltSetHotbarParam) serialize(w io.Writer) {
 
 				//mt:if %s.Param != HotbarSize
 				Img Texture
-			}))(obj)).Img).serialize(w)
+			}))(obj)).Img).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -10919,7 +11063,7 @@ func (obj *ToCltSetHotbarParam) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltSetHotbarParam) deserialize(r io.Reader) {
+func (obj *ToCltSetHotbarParam) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Param HotbarParam
@@ -10930,7 +11074,7 @@ func (obj *ToCltSetHotbarParam) deserialize(r io.Reader) {
 
 			//mt:if %s.Param != HotbarSize
 			Img Texture
-		}))(obj)).Param).deserialize(r)
+		}))(obj)).Param).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -10948,14 +11092,14 @@ func (obj *ToCltSetHotbarParam) deserialize(r io.Reader) {
 		Img Texture
 	}))(obj)).Param == HotbarSize {
 		{
-			var local191 uint16
-			local192 := uint16(4) // Size of Size field.
+			var local194 uint16
+			local195 := uint16(4) // Size of Size field.
 			{
-				p := &local191
+				p := &local194
 				*p = read16(r)
 			}
-			if local191 != local192 {
-				chk(fmt.Errorf("const %v: %v", "uint16(4) // Size of Size field.", local191))
+			if local194 != local195 {
+				chk(fmt.Errorf("const %v: %v", "uint16(4) // Size of Size field.", local194))
 			}
 		}
 		{
@@ -10992,7 +11136,7 @@ func (obj *ToCltSetHotbarParam) deserialize(r io.Reader) {
 
 				//mt:if %s.Param != HotbarSize
 				Img Texture
-			}))(obj)).Img).deserialize(r)
+			}))(obj)).Img).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -11002,7 +11146,7 @@ func (obj *ToCltSetHotbarParam) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltBreath) serialize(w io.Writer) {
+func (obj *ToCltBreath) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Breath uint16
@@ -11011,7 +11155,7 @@ func (obj *ToCltBreath) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltBreath) deserialize(r io.Reader) {
+func (obj *ToCltBreath) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Breath uint16
@@ -11020,7 +11164,7 @@ func (obj *ToCltBreath) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltSkyParams) serialize(w io.Writer) {
+func (obj *ToCltSkyParams) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			BgColor     color.NRGBA
@@ -11279,7 +11423,7 @@ func (obj *ToCltSkyParams) serialize(w io.Writer) {
 			}))(obj)).Textures)))
 			write16(w, uint16(x))
 		}
-		for local193 := range (*(*(struct {
+		for local196 := range (*(*(struct {
 			BgColor     color.NRGBA
 			Type        string
 			Clouds      bool
@@ -11313,7 +11457,7 @@ func (obj *ToCltSkyParams) serialize(w io.Writer) {
 					DawnSky, DawnHorizon,
 					NightSky, NightHorizon,
 					Indoor color.NRGBA
-				}))(obj)).Textures)[local193]).serialize(w)
+				}))(obj)).Textures)[local196]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -11482,7 +11626,7 @@ func (obj *ToCltSkyParams) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltSkyParams) deserialize(r io.Reader) {
+func (obj *ToCltSkyParams) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			BgColor     color.NRGBA
@@ -11503,15 +11647,15 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 		}))(obj)).BgColor
 		*p = color.NRGBA{A: read8(r), R: read8(r), G: read8(r), B: read8(r)}
 	}
-	var local194 []uint8
-	var local195 uint16
+	var local197 []uint8
+	var local198 uint16
 	{
-		p := &local195
+		p := &local198
 		*p = read16(r)
 	}
-	(local194) = make([]uint8, local195)
+	(local197) = make([]uint8, local198)
 	{
-		_, err := io.ReadFull(r, (local194)[:])
+		_, err := io.ReadFull(r, (local197)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -11530,7 +11674,7 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 		DawnSky, DawnHorizon,
 		NightSky, NightHorizon,
 		Indoor color.NRGBA
-	}))(obj)).Type) = string(local194)
+	}))(obj)).Type) = string(local197)
 	{
 		p := &(*(*(struct {
 			BgColor     color.NRGBA
@@ -11598,15 +11742,15 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 		}))(obj)).MoonFogTint
 		*p = color.NRGBA{A: read8(r), R: read8(r), G: read8(r), B: read8(r)}
 	}
-	var local196 []uint8
-	var local197 uint16
+	var local199 []uint8
+	var local200 uint16
 	{
-		p := &local197
+		p := &local200
 		*p = read16(r)
 	}
-	(local196) = make([]uint8, local197)
+	(local199) = make([]uint8, local200)
 	{
-		_, err := io.ReadFull(r, (local196)[:])
+		_, err := io.ReadFull(r, (local199)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -11625,7 +11769,7 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 		DawnSky, DawnHorizon,
 		NightSky, NightHorizon,
 		Indoor color.NRGBA
-	}))(obj)).FogTintType) = string(local196)
+	}))(obj)).FogTintType) = string(local199)
 	if (*(*(struct {
 		BgColor     color.NRGBA
 		Type        string
@@ -11643,9 +11787,9 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 		NightSky, NightHorizon,
 		Indoor color.NRGBA
 	}))(obj)).Type == "skybox" {
-		var local198 uint16
+		var local201 uint16
 		{
-			p := &local198
+			p := &local201
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -11664,8 +11808,8 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 			DawnSky, DawnHorizon,
 			NightSky, NightHorizon,
 			Indoor color.NRGBA
-		}))(obj)).Textures) = make([]Texture, local198)
-		for local199 := range (*(*(struct {
+		}))(obj)).Textures) = make([]Texture, local201)
+		for local202 := range (*(*(struct {
 			BgColor     color.NRGBA
 			Type        string
 			Clouds      bool
@@ -11699,7 +11843,7 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 					DawnSky, DawnHorizon,
 					NightSky, NightHorizon,
 					Indoor color.NRGBA
-				}))(obj)).Textures)[local199]).deserialize(r)
+				}))(obj)).Textures)[local202]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -11868,7 +12012,7 @@ func (obj *ToCltSkyParams) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltOverrideDayNightRatio) serialize(w io.Writer) {
+func (obj *ToCltOverrideDayNightRatio) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Override bool
@@ -11889,7 +12033,7 @@ func (obj *ToCltOverrideDayNightRatio) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltOverrideDayNightRatio) deserialize(r io.Reader) {
+func (obj *ToCltOverrideDayNightRatio) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Override bool
@@ -11913,8 +12057,8 @@ func (obj *ToCltOverrideDayNightRatio) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer) {
-	for local200 := range (*(*(struct {
+func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer, ver uint16) {
+	for local203 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).Idle {
@@ -11922,11 +12066,11 @@ func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer) {
 			x := ((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).Idle)[local200]
+			}))(obj)).Idle)[local203]
 			write32(w, uint32(x))
 		}
 	}
-	for local201 := range (*(*(struct {
+	for local204 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).Walk {
@@ -11934,11 +12078,11 @@ func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer) {
 			x := ((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).Walk)[local201]
+			}))(obj)).Walk)[local204]
 			write32(w, uint32(x))
 		}
 	}
-	for local202 := range (*(*(struct {
+	for local205 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).Dig {
@@ -11946,11 +12090,11 @@ func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer) {
 			x := ((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).Dig)[local202]
+			}))(obj)).Dig)[local205]
 			write32(w, uint32(x))
 		}
 	}
-	for local203 := range (*(*(struct {
+	for local206 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).WalkDig {
@@ -11958,7 +12102,7 @@ func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer) {
 			x := ((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).WalkDig)[local203]
+			}))(obj)).WalkDig)[local206]
 			write32(w, uint32(x))
 		}
 	}
@@ -11971,8 +12115,8 @@ func (obj *ToCltLocalPlayerAnim) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader) {
-	for local204 := range (*(*(struct {
+func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader, ver uint16) {
+	for local207 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).Idle {
@@ -11980,11 +12124,11 @@ func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader) {
 			p := &((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).Idle)[local204]
+			}))(obj)).Idle)[local207]
 			*p = int32(read32(r))
 		}
 	}
-	for local205 := range (*(*(struct {
+	for local208 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).Walk {
@@ -11992,11 +12136,11 @@ func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader) {
 			p := &((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).Walk)[local205]
+			}))(obj)).Walk)[local208]
 			*p = int32(read32(r))
 		}
 	}
-	for local206 := range (*(*(struct {
+	for local209 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).Dig {
@@ -12004,11 +12148,11 @@ func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader) {
 			p := &((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).Dig)[local206]
+			}))(obj)).Dig)[local209]
 			*p = int32(read32(r))
 		}
 	}
-	for local207 := range (*(*(struct {
+	for local210 := range (*(*(struct {
 		Idle, Walk, Dig, WalkDig [2]int32
 		Speed                    float32
 	}))(obj)).WalkDig {
@@ -12016,7 +12160,7 @@ func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader) {
 			p := &((*(*(struct {
 				Idle, Walk, Dig, WalkDig [2]int32
 				Speed                    float32
-			}))(obj)).WalkDig)[local207]
+			}))(obj)).WalkDig)[local210]
 			*p = int32(read32(r))
 		}
 	}
@@ -12029,11 +12173,11 @@ func (obj *ToCltLocalPlayerAnim) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltEyeOffset) serialize(w io.Writer) {
+func (obj *ToCltEyeOffset) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			First, Third Vec
-		}))(obj)).First).serialize(w)
+		}))(obj)).First).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12043,7 +12187,7 @@ func (obj *ToCltEyeOffset) serialize(w io.Writer) {
 	if err := pcall(func() {
 		((*(*(struct {
 			First, Third Vec
-		}))(obj)).Third).serialize(w)
+		}))(obj)).Third).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12052,11 +12196,11 @@ func (obj *ToCltEyeOffset) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltEyeOffset) deserialize(r io.Reader) {
+func (obj *ToCltEyeOffset) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			First, Third Vec
-		}))(obj)).First).deserialize(r)
+		}))(obj)).First).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12066,7 +12210,7 @@ func (obj *ToCltEyeOffset) deserialize(r io.Reader) {
 	if err := pcall(func() {
 		((*(*(struct {
 			First, Third Vec
-		}))(obj)).Third).deserialize(r)
+		}))(obj)).Third).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12075,11 +12219,11 @@ func (obj *ToCltEyeOffset) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltDelParticleSpawner) serialize(w io.Writer) {
+func (obj *ToCltDelParticleSpawner) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID ParticleSpawnerID
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12088,11 +12232,11 @@ func (obj *ToCltDelParticleSpawner) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltDelParticleSpawner) deserialize(r io.Reader) {
+func (obj *ToCltDelParticleSpawner) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID ParticleSpawnerID
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12101,7 +12245,7 @@ func (obj *ToCltDelParticleSpawner) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltCloudParams) serialize(w io.Writer) {
+func (obj *ToCltCloudParams) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Density      float32
@@ -12157,7 +12301,7 @@ func (obj *ToCltCloudParams) serialize(w io.Writer) {
 		}))(obj)).Thickness
 		write32(w, math.Float32bits(x))
 	}
-	for local208 := range (*(*(struct {
+	for local211 := range (*(*(struct {
 		Density      float32
 		DiffuseColor color.NRGBA
 		AmbientColor color.NRGBA
@@ -12173,13 +12317,13 @@ func (obj *ToCltCloudParams) serialize(w io.Writer) {
 				Height       float32
 				Thickness    float32
 				Speed        [2]float32
-			}))(obj)).Speed)[local208]
+			}))(obj)).Speed)[local211]
 			write32(w, math.Float32bits(x))
 		}
 	}
 }
 
-func (obj *ToCltCloudParams) deserialize(r io.Reader) {
+func (obj *ToCltCloudParams) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Density      float32
@@ -12235,7 +12379,7 @@ func (obj *ToCltCloudParams) deserialize(r io.Reader) {
 		}))(obj)).Thickness
 		*p = math.Float32frombits(read32(r))
 	}
-	for local209 := range (*(*(struct {
+	for local212 := range (*(*(struct {
 		Density      float32
 		DiffuseColor color.NRGBA
 		AmbientColor color.NRGBA
@@ -12251,19 +12395,19 @@ func (obj *ToCltCloudParams) deserialize(r io.Reader) {
 				Height       float32
 				Thickness    float32
 				Speed        [2]float32
-			}))(obj)).Speed)[local209]
+			}))(obj)).Speed)[local212]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
 }
 
-func (obj *ToCltFadeSound) serialize(w io.Writer) {
+func (obj *ToCltFadeSound) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID   SoundID
 			Step float32
 			Gain float32
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12288,13 +12432,13 @@ func (obj *ToCltFadeSound) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltFadeSound) deserialize(r io.Reader) {
+func (obj *ToCltFadeSound) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID   SoundID
 			Step float32
 			Gain float32
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12319,12 +12463,12 @@ func (obj *ToCltFadeSound) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltUpdatePlayerList) serialize(w io.Writer) {
+func (obj *ToCltUpdatePlayerList) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type    PlayerListUpdateType
 			Players []string
-		}))(obj)).Type).serialize(w)
+		}))(obj)).Type).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12344,77 +12488,77 @@ func (obj *ToCltUpdatePlayerList) serialize(w io.Writer) {
 		}))(obj)).Players)))
 		write16(w, uint16(x))
 	}
-	for local210 := range (*(*(struct {
+	for local213 := range (*(*(struct {
 		Type    PlayerListUpdateType
 		Players []string
 	}))(obj)).Players {
 		if len(([]byte(((*(*(struct {
 			Type    PlayerListUpdateType
 			Players []string
-		}))(obj)).Players)[local210]))) > math.MaxUint16 {
+		}))(obj)).Players)[local213]))) > math.MaxUint16 {
 			chk(ErrTooLong)
 		}
 		{
 			x := uint16(len(([]byte(((*(*(struct {
 				Type    PlayerListUpdateType
 				Players []string
-			}))(obj)).Players)[local210]))))
+			}))(obj)).Players)[local213]))))
 			write16(w, uint16(x))
 		}
 		{
 			_, err := w.Write(([]byte(((*(*(struct {
 				Type    PlayerListUpdateType
 				Players []string
-			}))(obj)).Players)[local210]))[:])
+			}))(obj)).Players)[local213]))[:])
 			chk(err)
 		}
 	}
 }
 
-func (obj *ToCltUpdatePlayerList) deserialize(r io.Reader) {
+func (obj *ToCltUpdatePlayerList) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type    PlayerListUpdateType
 			Players []string
-		}))(obj)).Type).deserialize(r)
+		}))(obj)).Type).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.PlayerListUpdateType", err))
 	}
-	var local211 uint16
+	var local214 uint16
 	{
-		p := &local211
+		p := &local214
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Type    PlayerListUpdateType
 		Players []string
-	}))(obj)).Players) = make([]string, local211)
-	for local212 := range (*(*(struct {
+	}))(obj)).Players) = make([]string, local214)
+	for local215 := range (*(*(struct {
 		Type    PlayerListUpdateType
 		Players []string
 	}))(obj)).Players {
-		var local213 []uint8
-		var local214 uint16
+		var local216 []uint8
+		var local217 uint16
 		{
-			p := &local214
+			p := &local217
 			*p = read16(r)
 		}
-		(local213) = make([]uint8, local214)
+		(local216) = make([]uint8, local217)
 		{
-			_, err := io.ReadFull(r, (local213)[:])
+			_, err := io.ReadFull(r, (local216)[:])
 			chk(err)
 		}
 		(((*(*(struct {
 			Type    PlayerListUpdateType
 			Players []string
-		}))(obj)).Players)[local212]) = string(local213)
+		}))(obj)).Players)[local215]) = string(local216)
 	}
 }
 
-func (obj *ToCltModChanMsg) serialize(w io.Writer) {
+func (obj *ToCltModChanMsg) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Channel string
 		Sender  string
@@ -12486,63 +12630,63 @@ func (obj *ToCltModChanMsg) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltModChanMsg) deserialize(r io.Reader) {
-	var local215 []uint8
-	var local216 uint16
+func (obj *ToCltModChanMsg) deserialize(r io.Reader, ver uint16) {
+	var local218 []uint8
+	var local219 uint16
 	{
-		p := &local216
+		p := &local219
 		*p = read16(r)
 	}
-	(local215) = make([]uint8, local216)
+	(local218) = make([]uint8, local219)
 	{
-		_, err := io.ReadFull(r, (local215)[:])
+		_, err := io.ReadFull(r, (local218)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Channel string
 		Sender  string
 		Msg     string
-	}))(obj)).Channel) = string(local215)
-	var local217 []uint8
-	var local218 uint16
+	}))(obj)).Channel) = string(local218)
+	var local220 []uint8
+	var local221 uint16
 	{
-		p := &local218
+		p := &local221
 		*p = read16(r)
 	}
-	(local217) = make([]uint8, local218)
+	(local220) = make([]uint8, local221)
 	{
-		_, err := io.ReadFull(r, (local217)[:])
+		_, err := io.ReadFull(r, (local220)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Channel string
 		Sender  string
 		Msg     string
-	}))(obj)).Sender) = string(local217)
-	var local219 []uint8
-	var local220 uint16
+	}))(obj)).Sender) = string(local220)
+	var local222 []uint8
+	var local223 uint16
 	{
-		p := &local220
+		p := &local223
 		*p = read16(r)
 	}
-	(local219) = make([]uint8, local220)
+	(local222) = make([]uint8, local223)
 	{
-		_, err := io.ReadFull(r, (local219)[:])
+		_, err := io.ReadFull(r, (local222)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Channel string
 		Sender  string
 		Msg     string
-	}))(obj)).Msg) = string(local219)
+	}))(obj)).Msg) = string(local222)
 }
 
-func (obj *ToCltModChanSig) serialize(w io.Writer) {
+func (obj *ToCltModChanSig) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Signal  ModChanSig
 			Channel string
-		}))(obj)).Signal).serialize(w)
+		}))(obj)).Signal).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12571,36 +12715,36 @@ func (obj *ToCltModChanSig) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltModChanSig) deserialize(r io.Reader) {
+func (obj *ToCltModChanSig) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Signal  ModChanSig
 			Channel string
-		}))(obj)).Signal).deserialize(r)
+		}))(obj)).Signal).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ModChanSig", err))
 	}
-	var local221 []uint8
-	var local222 uint16
+	var local224 []uint8
+	var local225 uint16
 	{
-		p := &local222
+		p := &local225
 		*p = read16(r)
 	}
-	(local221) = make([]uint8, local222)
+	(local224) = make([]uint8, local225)
 	{
-		_, err := io.ReadFull(r, (local221)[:])
+		_, err := io.ReadFull(r, (local224)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Signal  ModChanSig
 		Channel string
-	}))(obj)).Channel) = string(local221)
+	}))(obj)).Channel) = string(local224)
 }
 
-func (obj *ToCltNodeMetasChanged) serialize(w io.Writer) {
+func (obj *ToCltNodeMetasChanged) serialize(w io.Writer, ver uint16) {
 	{
 		ow := w
 		w := new(bytes.Buffer)
@@ -12639,7 +12783,7 @@ func (obj *ToCltNodeMetasChanged) serialize(w io.Writer) {
 						for _, n := range key {
 							write16(w, uint16(n))
 						}
-						chk(serialize(w, x[key]))
+						chk(serialize(w, x[key], ver))
 					}
 				}
 				chk(w.Close())
@@ -12663,7 +12807,7 @@ func (obj *ToCltNodeMetasChanged) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltNodeMetasChanged) deserialize(r io.Reader) {
+func (obj *ToCltNodeMetasChanged) deserialize(r io.Reader, ver uint16) {
 	{
 		var n uint32
 		{
@@ -12679,7 +12823,7 @@ func (obj *ToCltNodeMetasChanged) deserialize(r io.Reader) {
 			{
 				r, err := zlib.NewReader(byteReader{r})
 				chk(err)
-				switch ver := read8(r); ver {
+				switch metaVer := read8(r); metaVer {
 				case 0:
 					*p = nil
 				case 2:
@@ -12691,11 +12835,11 @@ func (obj *ToCltNodeMetasChanged) deserialize(r io.Reader) {
 							pos[i] = int16(read16(r))
 						}
 						nm := new(NodeMeta)
-						chk(deserialize(r, nm))
+						chk(deserialize(r, nm, ver))
 						(*p)[pos] = nm
 					}
 				default:
-					chk(fmt.Errorf("unsupported nodemetas version: %d", ver))
+					chk(fmt.Errorf("unsupported nodemetas version: %d", metaVer))
 				}
 				chk(r.Close())
 			}
@@ -12706,7 +12850,7 @@ func (obj *ToCltNodeMetasChanged) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltSunParams) serialize(w io.Writer) {
+func (obj *ToCltSunParams) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Visible bool
@@ -12730,7 +12874,7 @@ func (obj *ToCltSunParams) serialize(w io.Writer) {
 			Rise    Texture
 			Rising  bool
 			Size    float32
-		}))(obj)).Texture).serialize(w)
+		}))(obj)).Texture).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12745,7 +12889,7 @@ func (obj *ToCltSunParams) serialize(w io.Writer) {
 			Rise    Texture
 			Rising  bool
 			Size    float32
-		}))(obj)).ToneMap).serialize(w)
+		}))(obj)).ToneMap).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12760,7 +12904,7 @@ func (obj *ToCltSunParams) serialize(w io.Writer) {
 			Rise    Texture
 			Rising  bool
 			Size    float32
-		}))(obj)).Rise).serialize(w)
+		}))(obj)).Rise).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12795,7 +12939,7 @@ func (obj *ToCltSunParams) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltSunParams) deserialize(r io.Reader) {
+func (obj *ToCltSunParams) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Visible bool
@@ -12822,7 +12966,7 @@ func (obj *ToCltSunParams) deserialize(r io.Reader) {
 			Rise    Texture
 			Rising  bool
 			Size    float32
-		}))(obj)).Texture).deserialize(r)
+		}))(obj)).Texture).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12837,7 +12981,7 @@ func (obj *ToCltSunParams) deserialize(r io.Reader) {
 			Rise    Texture
 			Rising  bool
 			Size    float32
-		}))(obj)).ToneMap).deserialize(r)
+		}))(obj)).ToneMap).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12852,7 +12996,7 @@ func (obj *ToCltSunParams) deserialize(r io.Reader) {
 			Rise    Texture
 			Rising  bool
 			Size    float32
-		}))(obj)).Rise).deserialize(r)
+		}))(obj)).Rise).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12890,7 +13034,7 @@ func (obj *ToCltSunParams) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltMoonParams) serialize(w io.Writer) {
+func (obj *ToCltMoonParams) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Visible bool
@@ -12910,7 +13054,7 @@ func (obj *ToCltMoonParams) serialize(w io.Writer) {
 			Texture
 			ToneMap Texture
 			Size    float32
-		}))(obj)).Texture).serialize(w)
+		}))(obj)).Texture).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12923,7 +13067,7 @@ func (obj *ToCltMoonParams) serialize(w io.Writer) {
 			Texture
 			ToneMap Texture
 			Size    float32
-		}))(obj)).ToneMap).serialize(w)
+		}))(obj)).ToneMap).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12941,7 +13085,7 @@ func (obj *ToCltMoonParams) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltMoonParams) deserialize(r io.Reader) {
+func (obj *ToCltMoonParams) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Visible bool
@@ -12964,7 +13108,7 @@ func (obj *ToCltMoonParams) deserialize(r io.Reader) {
 			Texture
 			ToneMap Texture
 			Size    float32
-		}))(obj)).Texture).deserialize(r)
+		}))(obj)).Texture).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12977,7 +13121,7 @@ func (obj *ToCltMoonParams) deserialize(r io.Reader) {
 			Texture
 			ToneMap Texture
 			Size    float32
-		}))(obj)).ToneMap).deserialize(r)
+		}))(obj)).ToneMap).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -12995,7 +13139,7 @@ func (obj *ToCltMoonParams) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltStarParams) serialize(w io.Writer) {
+func (obj *ToCltStarParams) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Visible bool
@@ -13038,7 +13182,7 @@ func (obj *ToCltStarParams) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltStarParams) deserialize(r io.Reader) {
+func (obj *ToCltStarParams) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Visible bool
@@ -13084,7 +13228,7 @@ func (obj *ToCltStarParams) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltSRPBytesSaltB) serialize(w io.Writer) {
+func (obj *ToCltSRPBytesSaltB) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Salt, B []byte
 	}))(obj)).Salt)) > math.MaxUint16 {
@@ -13121,29 +13265,29 @@ func (obj *ToCltSRPBytesSaltB) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltSRPBytesSaltB) deserialize(r io.Reader) {
-	var local223 uint16
+func (obj *ToCltSRPBytesSaltB) deserialize(r io.Reader, ver uint16) {
+	var local226 uint16
 	{
-		p := &local223
+		p := &local226
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Salt, B []byte
-	}))(obj)).Salt) = make([]byte, local223)
+	}))(obj)).Salt) = make([]byte, local226)
 	{
 		_, err := io.ReadFull(r, ((*(*(struct {
 			Salt, B []byte
 		}))(obj)).Salt)[:])
 		chk(err)
 	}
-	var local224 uint16
+	var local227 uint16
 	{
-		p := &local224
+		p := &local227
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Salt, B []byte
-	}))(obj)).B) = make([]byte, local224)
+	}))(obj)).B) = make([]byte, local227)
 	{
 		_, err := io.ReadFull(r, ((*(*(struct {
 			Salt, B []byte
@@ -13152,7 +13296,7 @@ func (obj *ToCltSRPBytesSaltB) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToCltFormspecPrepend) serialize(w io.Writer) {
+func (obj *ToCltFormspecPrepend) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Prepend string
 	}))(obj)).Prepend))) > math.MaxUint16 {
@@ -13172,28 +13316,28 @@ func (obj *ToCltFormspecPrepend) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToCltFormspecPrepend) deserialize(r io.Reader) {
-	var local225 []uint8
-	var local226 uint16
+func (obj *ToCltFormspecPrepend) deserialize(r io.Reader, ver uint16) {
+	var local228 []uint8
+	var local229 uint16
 	{
-		p := &local226
+		p := &local229
 		*p = read16(r)
 	}
-	(local225) = make([]uint8, local226)
+	(local228) = make([]uint8, local229)
 	{
-		_, err := io.ReadFull(r, (local225)[:])
+		_, err := io.ReadFull(r, (local228)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Prepend string
-	}))(obj)).Prepend) = string(local225)
+	}))(obj)).Prepend) = string(local228)
 }
 
-func (obj *AOCmdProps) serialize(w io.Writer) {
+func (obj *AOCmdProps) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Props AOProps
-		}))(obj)).Props).serialize(w)
+		}))(obj)).Props).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13202,11 +13346,11 @@ func (obj *AOCmdProps) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdProps) deserialize(r io.Reader) {
+func (obj *AOCmdProps) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Props AOProps
-		}))(obj)).Props).deserialize(r)
+		}))(obj)).Props).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13215,11 +13359,11 @@ func (obj *AOCmdProps) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdPos) serialize(w io.Writer) {
+func (obj *AOCmdPos) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos AOPos
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13228,11 +13372,11 @@ func (obj *AOCmdPos) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdPos) deserialize(r io.Reader) {
+func (obj *AOCmdPos) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos AOPos
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13241,11 +13385,11 @@ func (obj *AOCmdPos) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdTextureMod) serialize(w io.Writer) {
+func (obj *AOCmdTextureMod) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Mod Texture // suffix
-		}))(obj)).Mod).serialize(w)
+		}))(obj)).Mod).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13254,11 +13398,11 @@ func (obj *AOCmdTextureMod) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdTextureMod) deserialize(r io.Reader) {
+func (obj *AOCmdTextureMod) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Mod Texture // suffix
-		}))(obj)).Mod).deserialize(r)
+		}))(obj)).Mod).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13267,11 +13411,11 @@ func (obj *AOCmdTextureMod) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdSprite) serialize(w io.Writer) {
+func (obj *AOCmdSprite) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Sprite AOSprite
-		}))(obj)).Sprite).serialize(w)
+		}))(obj)).Sprite).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13280,11 +13424,11 @@ func (obj *AOCmdSprite) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdSprite) deserialize(r io.Reader) {
+func (obj *AOCmdSprite) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Sprite AOSprite
-		}))(obj)).Sprite).deserialize(r)
+		}))(obj)).Sprite).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13293,7 +13437,7 @@ func (obj *AOCmdSprite) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdHP) serialize(w io.Writer) {
+func (obj *AOCmdHP) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			HP uint16
@@ -13302,7 +13446,7 @@ func (obj *AOCmdHP) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdHP) deserialize(r io.Reader) {
+func (obj *AOCmdHP) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			HP uint16
@@ -13311,7 +13455,7 @@ func (obj *AOCmdHP) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdArmorGroups) serialize(w io.Writer) {
+func (obj *AOCmdArmorGroups) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Armor []Group
 	}))(obj)).Armor)) > math.MaxUint16 {
@@ -13323,13 +13467,13 @@ func (obj *AOCmdArmorGroups) serialize(w io.Writer) {
 		}))(obj)).Armor)))
 		write16(w, uint16(x))
 	}
-	for local227 := range (*(*(struct {
+	for local230 := range (*(*(struct {
 		Armor []Group
 	}))(obj)).Armor {
 		if err := pcall(func() {
 			(((*(*(struct {
 				Armor []Group
-			}))(obj)).Armor)[local227]).serialize(w)
+			}))(obj)).Armor)[local230]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -13339,22 +13483,22 @@ func (obj *AOCmdArmorGroups) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdArmorGroups) deserialize(r io.Reader) {
-	var local228 uint16
+func (obj *AOCmdArmorGroups) deserialize(r io.Reader, ver uint16) {
+	var local231 uint16
 	{
-		p := &local228
+		p := &local231
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Armor []Group
-	}))(obj)).Armor) = make([]Group, local228)
-	for local229 := range (*(*(struct {
+	}))(obj)).Armor) = make([]Group, local231)
+	for local232 := range (*(*(struct {
 		Armor []Group
 	}))(obj)).Armor {
 		if err := pcall(func() {
 			(((*(*(struct {
 				Armor []Group
-			}))(obj)).Armor)[local229]).deserialize(r)
+			}))(obj)).Armor)[local232]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -13364,11 +13508,11 @@ func (obj *AOCmdArmorGroups) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdAnim) serialize(w io.Writer) {
+func (obj *AOCmdAnim) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Anim AOAnim
-		}))(obj)).Anim).serialize(w)
+		}))(obj)).Anim).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13377,11 +13521,11 @@ func (obj *AOCmdAnim) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdAnim) deserialize(r io.Reader) {
+func (obj *AOCmdAnim) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Anim AOAnim
-		}))(obj)).Anim).deserialize(r)
+		}))(obj)).Anim).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13390,7 +13534,7 @@ func (obj *AOCmdAnim) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdBonePos) serialize(w io.Writer) {
+func (obj *AOCmdBonePos) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Bone string
 		Pos  AOBonePos
@@ -13415,7 +13559,7 @@ func (obj *AOCmdBonePos) serialize(w io.Writer) {
 		((*(*(struct {
 			Bone string
 			Pos  AOBonePos
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13424,27 +13568,27 @@ func (obj *AOCmdBonePos) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdBonePos) deserialize(r io.Reader) {
-	var local230 []uint8
-	var local231 uint16
+func (obj *AOCmdBonePos) deserialize(r io.Reader, ver uint16) {
+	var local233 []uint8
+	var local234 uint16
 	{
-		p := &local231
+		p := &local234
 		*p = read16(r)
 	}
-	(local230) = make([]uint8, local231)
+	(local233) = make([]uint8, local234)
 	{
-		_, err := io.ReadFull(r, (local230)[:])
+		_, err := io.ReadFull(r, (local233)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Bone string
 		Pos  AOBonePos
-	}))(obj)).Bone) = string(local230)
+	}))(obj)).Bone) = string(local233)
 	if err := pcall(func() {
 		((*(*(struct {
 			Bone string
 			Pos  AOBonePos
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13453,11 +13597,11 @@ func (obj *AOCmdBonePos) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdAttach) serialize(w io.Writer) {
+func (obj *AOCmdAttach) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Attach AOAttach
-		}))(obj)).Attach).serialize(w)
+		}))(obj)).Attach).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13466,11 +13610,11 @@ func (obj *AOCmdAttach) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdAttach) deserialize(r io.Reader) {
+func (obj *AOCmdAttach) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Attach AOAttach
-		}))(obj)).Attach).deserialize(r)
+		}))(obj)).Attach).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13479,11 +13623,11 @@ func (obj *AOCmdAttach) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdPhysOverride) serialize(w io.Writer) {
+func (obj *AOCmdPhysOverride) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Phys AOPhysOverride
-		}))(obj)).Phys).serialize(w)
+		}))(obj)).Phys).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13492,11 +13636,11 @@ func (obj *AOCmdPhysOverride) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdPhysOverride) deserialize(r io.Reader) {
+func (obj *AOCmdPhysOverride) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Phys AOPhysOverride
-		}))(obj)).Phys).deserialize(r)
+		}))(obj)).Phys).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13505,11 +13649,11 @@ func (obj *AOCmdPhysOverride) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOCmdSpawnInfant) serialize(w io.Writer) {
+func (obj *AOCmdSpawnInfant) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13517,9 +13661,9 @@ func (obj *AOCmdSpawnInfant) serialize(w io.Writer) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AOID", err))
 	}
 	{
-		local232 := genericCAO
+		local235 := genericCAO
 		if err := pcall(func() {
-			(local232).serialize(w)
+			(local235).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -13529,11 +13673,11 @@ func (obj *AOCmdSpawnInfant) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdSpawnInfant) deserialize(r io.Reader) {
+func (obj *AOCmdSpawnInfant) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13541,23 +13685,23 @@ func (obj *AOCmdSpawnInfant) deserialize(r io.Reader) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AOID", err))
 	}
 	{
-		var local233 aoType
-		local234 := genericCAO
+		var local236 aoType
+		local237 := genericCAO
 		if err := pcall(func() {
-			(local233).deserialize(r)
+			(local236).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.aoType", err))
 		}
-		if local233 != local234 {
-			chk(fmt.Errorf("const %v: %v", "genericCAO", local233))
+		if local236 != local237 {
+			chk(fmt.Errorf("const %v: %v", "genericCAO", local236))
 		}
 	}
 }
 
-func (obj *AOCmdAnimSpeed) serialize(w io.Writer) {
+func (obj *AOCmdAnimSpeed) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Speed float32
@@ -13566,7 +13710,7 @@ func (obj *AOCmdAnimSpeed) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOCmdAnimSpeed) deserialize(r io.Reader) {
+func (obj *AOCmdAnimSpeed) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Speed float32
@@ -13575,7 +13719,7 @@ func (obj *AOCmdAnimSpeed) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *NodeMeta) serialize(w io.Writer) {
+func (obj *NodeMeta) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		//mt:len32
 		Fields []NodeMetaField
@@ -13593,7 +13737,7 @@ func (obj *NodeMeta) serialize(w io.Writer) {
 		}))(obj)).Fields)))
 		write32(w, uint32(x))
 	}
-	for local235 := range (*(*(struct {
+	for local238 := range (*(*(struct {
 		//mt:len32
 		Fields []NodeMetaField
 
@@ -13605,7 +13749,7 @@ func (obj *NodeMeta) serialize(w io.Writer) {
 				Fields []NodeMetaField
 
 				Inv Inv
-			}))(obj)).Fields)[local235]).serialize(w)
+			}))(obj)).Fields)[local238]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -13621,10 +13765,10 @@ func (obj *NodeMeta) serialize(w io.Writer) {
 	}))(obj)).Inv).Serialize(w))
 }
 
-func (obj *NodeMeta) deserialize(r io.Reader) {
-	var local236 uint32
+func (obj *NodeMeta) deserialize(r io.Reader, ver uint16) {
+	var local239 uint32
 	{
-		p := &local236
+		p := &local239
 		*p = read32(r)
 	}
 	((*(*(struct {
@@ -13632,8 +13776,8 @@ func (obj *NodeMeta) deserialize(r io.Reader) {
 		Fields []NodeMetaField
 
 		Inv Inv
-	}))(obj)).Fields) = make([]NodeMetaField, local236)
-	for local237 := range (*(*(struct {
+	}))(obj)).Fields) = make([]NodeMetaField, local239)
+	for local240 := range (*(*(struct {
 		//mt:len32
 		Fields []NodeMetaField
 
@@ -13645,7 +13789,7 @@ func (obj *NodeMeta) deserialize(r io.Reader) {
 				Fields []NodeMetaField
 
 				Inv Inv
-			}))(obj)).Fields)[local237]).deserialize(r)
+			}))(obj)).Fields)[local240]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -13661,7 +13805,7 @@ func (obj *NodeMeta) deserialize(r io.Reader) {
 	}))(obj)).Inv).Deserialize(r))
 }
 
-func (obj *MinimapMode) serialize(w io.Writer) {
+func (obj *MinimapMode) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type  MinimapType
@@ -13669,7 +13813,7 @@ func (obj *MinimapMode) serialize(w io.Writer) {
 			Size  uint16
 			Texture
 			Scale uint16
-		}))(obj)).Type).serialize(w)
+		}))(obj)).Type).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13722,7 +13866,7 @@ func (obj *MinimapMode) serialize(w io.Writer) {
 			Size  uint16
 			Texture
 			Scale uint16
-		}))(obj)).Texture).serialize(w)
+		}))(obj)).Texture).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13741,7 +13885,7 @@ func (obj *MinimapMode) serialize(w io.Writer) {
 	}
 }
 
-func (obj *MinimapMode) deserialize(r io.Reader) {
+func (obj *MinimapMode) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type  MinimapType
@@ -13749,22 +13893,22 @@ func (obj *MinimapMode) deserialize(r io.Reader) {
 			Size  uint16
 			Texture
 			Scale uint16
-		}))(obj)).Type).deserialize(r)
+		}))(obj)).Type).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.MinimapType", err))
 	}
-	var local238 []uint8
-	var local239 uint16
+	var local241 []uint8
+	var local242 uint16
 	{
-		p := &local239
+		p := &local242
 		*p = read16(r)
 	}
-	(local238) = make([]uint8, local239)
+	(local241) = make([]uint8, local242)
 	{
-		_, err := io.ReadFull(r, (local238)[:])
+		_, err := io.ReadFull(r, (local241)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -13773,7 +13917,7 @@ func (obj *MinimapMode) deserialize(r io.Reader) {
 		Size  uint16
 		Texture
 		Scale uint16
-	}))(obj)).Label) = string(local238)
+	}))(obj)).Label) = string(local241)
 	{
 		p := &(*(*(struct {
 			Type  MinimapType
@@ -13791,7 +13935,7 @@ func (obj *MinimapMode) deserialize(r io.Reader) {
 			Size  uint16
 			Texture
 			Scale uint16
-		}))(obj)).Texture).deserialize(r)
+		}))(obj)).Texture).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13810,7 +13954,7 @@ func (obj *MinimapMode) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *NodeDef) serialize(w io.Writer) {
+func (obj *NodeDef) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Param0 Content
@@ -13874,7 +14018,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).Param0).serialize(w)
+		}))(obj)).Param0).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -13885,9 +14029,9 @@ func (obj *NodeDef) serialize(w io.Writer) {
 		ow := w
 		w := new(bytes.Buffer)
 		{
-			local240 := uint8(13)
+			local243 := uint8(13)
 			{
-				x := local240
+				x := local243
 				write8(w, uint8(x))
 			}
 		}
@@ -14219,7 +14363,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 			}))(obj)).Groups)))
 			write16(w, uint16(x))
 		}
-		for local241 := range (*(*(struct {
+		for local244 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -14345,7 +14489,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).Groups)[local241]).serialize(w)
+				}))(obj)).Groups)[local244]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -14416,7 +14560,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).P1Type).serialize(w)
+			}))(obj)).P1Type).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -14486,7 +14630,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).P2Type).serialize(w)
+			}))(obj)).P2Type).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -14556,7 +14700,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DrawType).serialize(w)
+			}))(obj)).DrawType).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -14827,13 +14971,13 @@ func (obj *NodeDef) serialize(w io.Writer) {
 			write32(w, math.Float32bits(x))
 		}
 		{
-			local242 := uint8(6)
+			local245 := uint8(6)
 			{
-				x := local242
+				x := local245
 				write8(w, uint8(x))
 			}
 		}
-		for local243 := range (*(*(struct {
+		for local246 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -14959,7 +15103,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).Tiles)[local243]).serialize(w)
+				}))(obj)).Tiles)[local246]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -14967,7 +15111,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.TileDef", err))
 			}
 		}
-		for local244 := range (*(*(struct {
+		for local247 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -15093,7 +15237,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).OverlayTiles)[local244]).serialize(w)
+				}))(obj)).OverlayTiles)[local247]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -15102,13 +15246,13 @@ func (obj *NodeDef) serialize(w io.Writer) {
 			}
 		}
 		{
-			local245 := uint8(6)
+			local248 := uint8(6)
 			{
-				x := local245
+				x := local248
 				write8(w, uint8(x))
 			}
 		}
-		for local246 := range (*(*(struct {
+		for local249 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -15234,7 +15378,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).SpecialTiles)[local246]).serialize(w)
+				}))(obj)).SpecialTiles)[local249]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -15371,7 +15515,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).Palette).serialize(w)
+			}))(obj)).Palette).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -15441,7 +15585,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).Waving).serialize(w)
+			}))(obj)).Waving).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -15645,7 +15789,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 			}))(obj)).ConnectTo)))
 			write16(w, uint16(x))
 		}
-		for local247 := range (*(*(struct {
+		for local250 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -15771,7 +15915,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).ConnectTo)[local247]).serialize(w)
+				}))(obj)).ConnectTo)[local250]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -16736,7 +16880,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).LiquidType).serialize(w)
+			}))(obj)).LiquidType).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -17538,7 +17682,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DrawBox).serialize(w)
+			}))(obj)).DrawBox).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -17608,7 +17752,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).ColBox).serialize(w)
+			}))(obj)).ColBox).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -17678,7 +17822,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).SelBox).serialize(w)
+			}))(obj)).SelBox).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -17748,7 +17892,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).FootstepSnd).serialize(w)
+			}))(obj)).FootstepSnd).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -17818,7 +17962,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DiggingSnd).serialize(w)
+			}))(obj)).DiggingSnd).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -17888,7 +18032,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DugSnd).serialize(w)
+			}))(obj)).DugSnd).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -18361,7 +18505,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).AlphaUse).serialize(w)
+			}))(obj)).AlphaUse).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -18386,7 +18530,7 @@ func (obj *NodeDef) serialize(w io.Writer) {
 	}
 }
 
-func (obj *NodeDef) deserialize(r io.Reader) {
+func (obj *NodeDef) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Param0 Content
@@ -18450,7 +18594,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).Param0).deserialize(r)
+		}))(obj)).Param0).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -18465,25 +18609,25 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 		}
 		r := &io.LimitedReader{R: r, N: int64(n)}
 		{
-			var local248 uint8
-			local249 := uint8(13)
+			var local251 uint8
+			local252 := uint8(13)
 			{
-				p := &local248
+				p := &local251
 				*p = read8(r)
 			}
-			if local248 != local249 {
-				chk(fmt.Errorf("const %v: %v", "uint8(13)", local248))
+			if local251 != local252 {
+				chk(fmt.Errorf("const %v: %v", "uint8(13)", local251))
 			}
 		}
-		var local250 []uint8
-		var local251 uint16
+		var local253 []uint8
+		var local254 uint16
 		{
-			p := &local251
+			p := &local254
 			*p = read16(r)
 		}
-		(local250) = make([]uint8, local251)
+		(local253) = make([]uint8, local254)
 		{
-			_, err := io.ReadFull(r, (local250)[:])
+			_, err := io.ReadFull(r, (local253)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -18548,10 +18692,10 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).Name) = string(local250)
-		var local252 uint16
+		}))(obj)).Name) = string(local253)
+		var local255 uint16
 		{
-			p := &local252
+			p := &local255
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -18616,8 +18760,8 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).Groups) = make([]Group, local252)
-		for local253 := range (*(*(struct {
+		}))(obj)).Groups) = make([]Group, local255)
+		for local256 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -18743,7 +18887,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).Groups)[local253]).deserialize(r)
+				}))(obj)).Groups)[local256]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -18814,7 +18958,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).P1Type).deserialize(r)
+			}))(obj)).P1Type).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -18884,7 +19028,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).P2Type).deserialize(r)
+			}))(obj)).P2Type).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -18954,22 +19098,22 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DrawType).deserialize(r)
+			}))(obj)).DrawType).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.DrawType", err))
 		}
-		var local254 []uint8
-		var local255 uint16
+		var local257 []uint8
+		var local258 uint16
 		{
-			p := &local255
+			p := &local258
 			*p = read16(r)
 		}
-		(local254) = make([]uint8, local255)
+		(local257) = make([]uint8, local258)
 		{
-			_, err := io.ReadFull(r, (local254)[:])
+			_, err := io.ReadFull(r, (local257)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -19034,7 +19178,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).Mesh) = string(local254)
+		}))(obj)).Mesh) = string(local257)
 		{
 			p := &(*(*(struct {
 				Param0 Content
@@ -19102,17 +19246,17 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			*p = math.Float32frombits(read32(r))
 		}
 		{
-			var local256 uint8
-			local257 := uint8(6)
+			var local259 uint8
+			local260 := uint8(6)
 			{
-				p := &local256
+				p := &local259
 				*p = read8(r)
 			}
-			if local256 != local257 {
-				chk(fmt.Errorf("const %v: %v", "uint8(6)", local256))
+			if local259 != local260 {
+				chk(fmt.Errorf("const %v: %v", "uint8(6)", local259))
 			}
 		}
-		for local258 := range (*(*(struct {
+		for local261 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -19238,7 +19382,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).Tiles)[local258]).deserialize(r)
+				}))(obj)).Tiles)[local261]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -19246,7 +19390,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.TileDef", err))
 			}
 		}
-		for local259 := range (*(*(struct {
+		for local262 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -19372,7 +19516,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).OverlayTiles)[local259]).deserialize(r)
+				}))(obj)).OverlayTiles)[local262]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -19381,17 +19525,17 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			}
 		}
 		{
-			var local260 uint8
-			local261 := uint8(6)
+			var local263 uint8
+			local264 := uint8(6)
 			{
-				p := &local260
+				p := &local263
 				*p = read8(r)
 			}
-			if local260 != local261 {
-				chk(fmt.Errorf("const %v: %v", "uint8(6)", local260))
+			if local263 != local264 {
+				chk(fmt.Errorf("const %v: %v", "uint8(6)", local263))
 			}
 		}
-		for local262 := range (*(*(struct {
+		for local265 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -19517,7 +19661,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).SpecialTiles)[local262]).deserialize(r)
+				}))(obj)).SpecialTiles)[local265]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -19654,7 +19798,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).Palette).deserialize(r)
+			}))(obj)).Palette).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -19724,7 +19868,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).Waving).deserialize(r)
+			}))(obj)).Waving).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -19797,9 +19941,9 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			}))(obj)).ConnectSides
 			*p = read8(r)
 		}
-		var local263 uint16
+		var local266 uint16
 		{
-			p := &local263
+			p := &local266
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -19864,8 +20008,8 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).ConnectTo) = make([]Content, local263)
-		for local264 := range (*(*(struct {
+		}))(obj)).ConnectTo) = make([]Content, local266)
+		for local267 := range (*(*(struct {
 			Param0 Content
 
 			Name   string
@@ -19991,7 +20135,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 					MaxLvl uint8
 
 					AlphaUse
-				}))(obj)).ConnectTo)[local264]).deserialize(r)
+				}))(obj)).ConnectTo)[local267]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -20983,22 +21127,22 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).LiquidType).deserialize(r)
+			}))(obj)).LiquidType).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.LiquidType", err))
 		}
-		var local265 []uint8
-		var local266 uint16
+		var local268 []uint8
+		var local269 uint16
 		{
-			p := &local266
+			p := &local269
 			*p = read16(r)
 		}
-		(local265) = make([]uint8, local266)
+		(local268) = make([]uint8, local269)
 		{
-			_, err := io.ReadFull(r, (local265)[:])
+			_, err := io.ReadFull(r, (local268)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -21063,16 +21207,16 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).FlowingAlt) = string(local265)
-		var local267 []uint8
-		var local268 uint16
+		}))(obj)).FlowingAlt) = string(local268)
+		var local270 []uint8
+		var local271 uint16
 		{
-			p := &local268
+			p := &local271
 			*p = read16(r)
 		}
-		(local267) = make([]uint8, local268)
+		(local270) = make([]uint8, local271)
 		{
-			_, err := io.ReadFull(r, (local267)[:])
+			_, err := io.ReadFull(r, (local270)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -21137,7 +21281,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).SrcAlt) = string(local267)
+		}))(obj)).SrcAlt) = string(local270)
 		{
 			p := &(*(*(struct {
 				Param0 Content
@@ -21545,7 +21689,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DrawBox).deserialize(r)
+			}))(obj)).DrawBox).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -21615,7 +21759,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).ColBox).deserialize(r)
+			}))(obj)).ColBox).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -21685,7 +21829,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).SelBox).deserialize(r)
+			}))(obj)).SelBox).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -21755,7 +21899,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).FootstepSnd).deserialize(r)
+			}))(obj)).FootstepSnd).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -21825,7 +21969,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DiggingSnd).deserialize(r)
+			}))(obj)).DiggingSnd).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -21895,7 +22039,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).DugSnd).deserialize(r)
+			}))(obj)).DugSnd).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -22048,15 +22192,15 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				chk(fmt.Errorf("invalid bool: %d", n))
 			}
 		}
-		var local269 []uint8
-		var local270 uint16
+		var local272 []uint8
+		var local273 uint16
 		{
-			p := &local270
+			p := &local273
 			*p = read16(r)
 		}
-		(local269) = make([]uint8, local270)
+		(local272) = make([]uint8, local273)
 		{
-			_, err := io.ReadFull(r, (local269)[:])
+			_, err := io.ReadFull(r, (local272)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -22121,7 +22265,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 			MaxLvl uint8
 
 			AlphaUse
-		}))(obj)).DigPredict) = string(local269)
+		}))(obj)).DigPredict) = string(local272)
 		{
 			p := &(*(*(struct {
 				Param0 Content
@@ -22251,7 +22395,7 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 				MaxLvl uint8
 
 				AlphaUse
-			}))(obj)).AlphaUse).deserialize(r)
+			}))(obj)).AlphaUse).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -22264,57 +22408,57 @@ func (obj *NodeDef) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *PointedNode) serialize(w io.Writer) {
-	for local271 := range (*(*(struct {
+func (obj *PointedNode) serialize(w io.Writer, ver uint16) {
+	for local274 := range (*(*(struct {
 		Under, Above [3]int16
 	}))(obj)).Under {
 		{
 			x := ((*(*(struct {
 				Under, Above [3]int16
-			}))(obj)).Under)[local271]
+			}))(obj)).Under)[local274]
 			write16(w, uint16(x))
 		}
 	}
-	for local272 := range (*(*(struct {
+	for local275 := range (*(*(struct {
 		Under, Above [3]int16
 	}))(obj)).Above {
 		{
 			x := ((*(*(struct {
 				Under, Above [3]int16
-			}))(obj)).Above)[local272]
+			}))(obj)).Above)[local275]
 			write16(w, uint16(x))
 		}
 	}
 }
 
-func (obj *PointedNode) deserialize(r io.Reader) {
-	for local273 := range (*(*(struct {
+func (obj *PointedNode) deserialize(r io.Reader, ver uint16) {
+	for local276 := range (*(*(struct {
 		Under, Above [3]int16
 	}))(obj)).Under {
 		{
 			p := &((*(*(struct {
 				Under, Above [3]int16
-			}))(obj)).Under)[local273]
+			}))(obj)).Under)[local276]
 			*p = int16(read16(r))
 		}
 	}
-	for local274 := range (*(*(struct {
+	for local277 := range (*(*(struct {
 		Under, Above [3]int16
 	}))(obj)).Above {
 		{
 			p := &((*(*(struct {
 				Under, Above [3]int16
-			}))(obj)).Above)[local274]
+			}))(obj)).Above)[local277]
 			*p = int16(read16(r))
 		}
 	}
 }
 
-func (obj *PointedAO) serialize(w io.Writer) {
+func (obj *PointedAO) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22323,11 +22467,11 @@ func (obj *PointedAO) serialize(w io.Writer) {
 	}
 }
 
-func (obj *PointedAO) deserialize(r io.Reader) {
+func (obj *PointedAO) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22336,22 +22480,22 @@ func (obj *PointedAO) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *CompressionModes) serialize(w io.Writer) {
+func (obj *CompressionModes) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *CompressionModes) deserialize(r io.Reader) {
+func (obj *CompressionModes) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *PlayerPos) serialize(w io.Writer) {
-	for local275 := range (*(*(struct {
+func (obj *PlayerPos) serialize(w io.Writer, ver uint16) {
+	for local278 := range (*(*(struct {
 		Pos100, Vel100   [3]int32
 		Pitch100, Yaw100 int32
 		Keys             Keys
@@ -22365,11 +22509,11 @@ func (obj *PlayerPos) serialize(w io.Writer) {
 				Keys             Keys
 				FOV80            uint8
 				WantedRange      uint8 // in MapBlks.
-			}))(obj)).Pos100)[local275]
+			}))(obj)).Pos100)[local278]
 			write32(w, uint32(x))
 		}
 	}
-	for local276 := range (*(*(struct {
+	for local279 := range (*(*(struct {
 		Pos100, Vel100   [3]int32
 		Pitch100, Yaw100 int32
 		Keys             Keys
@@ -22383,7 +22527,7 @@ func (obj *PlayerPos) serialize(w io.Writer) {
 				Keys             Keys
 				FOV80            uint8
 				WantedRange      uint8 // in MapBlks.
-			}))(obj)).Vel100)[local276]
+			}))(obj)).Vel100)[local279]
 			write32(w, uint32(x))
 		}
 	}
@@ -22414,7 +22558,7 @@ func (obj *PlayerPos) serialize(w io.Writer) {
 			Keys             Keys
 			FOV80            uint8
 			WantedRange      uint8 // in MapBlks.
-		}))(obj)).Keys).serialize(w)
+		}))(obj)).Keys).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22443,8 +22587,8 @@ func (obj *PlayerPos) serialize(w io.Writer) {
 	}
 }
 
-func (obj *PlayerPos) deserialize(r io.Reader) {
-	for local277 := range (*(*(struct {
+func (obj *PlayerPos) deserialize(r io.Reader, ver uint16) {
+	for local280 := range (*(*(struct {
 		Pos100, Vel100   [3]int32
 		Pitch100, Yaw100 int32
 		Keys             Keys
@@ -22458,11 +22602,11 @@ func (obj *PlayerPos) deserialize(r io.Reader) {
 				Keys             Keys
 				FOV80            uint8
 				WantedRange      uint8 // in MapBlks.
-			}))(obj)).Pos100)[local277]
+			}))(obj)).Pos100)[local280]
 			*p = int32(read32(r))
 		}
 	}
-	for local278 := range (*(*(struct {
+	for local281 := range (*(*(struct {
 		Pos100, Vel100   [3]int32
 		Pitch100, Yaw100 int32
 		Keys             Keys
@@ -22476,7 +22620,7 @@ func (obj *PlayerPos) deserialize(r io.Reader) {
 				Keys             Keys
 				FOV80            uint8
 				WantedRange      uint8 // in MapBlks.
-			}))(obj)).Vel100)[local278]
+			}))(obj)).Vel100)[local281]
 			*p = int32(read32(r))
 		}
 	}
@@ -22507,7 +22651,7 @@ func (obj *PlayerPos) deserialize(r io.Reader) {
 			Keys             Keys
 			FOV80            uint8
 			WantedRange      uint8 // in MapBlks.
-		}))(obj)).Keys).deserialize(r)
+		}))(obj)).Keys).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22536,35 +22680,35 @@ func (obj *PlayerPos) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *Interaction) serialize(w io.Writer) {
+func (obj *Interaction) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *Interaction) deserialize(r io.Reader) {
+func (obj *Interaction) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *SoundID) serialize(w io.Writer) {
+func (obj *SoundID) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(int32))(obj)
 		write32(w, uint32(x))
 	}
 }
 
-func (obj *SoundID) deserialize(r io.Reader) {
+func (obj *SoundID) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(int32))(obj)
 		*p = int32(read32(r))
 	}
 }
 
-func (obj *Field) serialize(w io.Writer) {
+func (obj *Field) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Name string
 
@@ -22619,16 +22763,16 @@ func (obj *Field) serialize(w io.Writer) {
 	}
 }
 
-func (obj *Field) deserialize(r io.Reader) {
-	var local279 []uint8
-	var local280 uint16
+func (obj *Field) deserialize(r io.Reader, ver uint16) {
+	var local282 []uint8
+	var local283 uint16
 	{
-		p := &local280
+		p := &local283
 		*p = read16(r)
 	}
-	(local279) = make([]uint8, local280)
+	(local282) = make([]uint8, local283)
 	{
-		_, err := io.ReadFull(r, (local279)[:])
+		_, err := io.ReadFull(r, (local282)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -22636,16 +22780,16 @@ func (obj *Field) deserialize(r io.Reader) {
 
 		//mt:len32
 		Value string
-	}))(obj)).Name) = string(local279)
-	var local281 []uint8
-	var local282 uint32
+	}))(obj)).Name) = string(local282)
+	var local284 []uint8
+	var local285 uint32
 	{
-		p := &local282
+		p := &local285
 		*p = read32(r)
 	}
-	(local281) = make([]uint8, local282)
+	(local284) = make([]uint8, local285)
 	{
-		_, err := io.ReadFull(r, (local281)[:])
+		_, err := io.ReadFull(r, (local284)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -22653,26 +22797,26 @@ func (obj *Field) deserialize(r io.Reader) {
 
 		//mt:len32
 		Value string
-	}))(obj)).Value) = string(local281)
+	}))(obj)).Value) = string(local284)
 }
 
-func (obj *AuthMethods) serialize(w io.Writer) {
+func (obj *AuthMethods) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint32))(obj)
 		write32(w, uint32(x))
 	}
 }
 
-func (obj *AuthMethods) deserialize(r io.Reader) {
+func (obj *AuthMethods) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint32))(obj)
 		*p = read32(r)
 	}
 }
 
-func (obj *Pos) serialize(w io.Writer) {
+func (obj *Pos) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
-		(*(*(Vec))(obj)).serialize(w)
+		(*(*(Vec))(obj)).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22681,9 +22825,9 @@ func (obj *Pos) serialize(w io.Writer) {
 	}
 }
 
-func (obj *Pos) deserialize(r io.Reader) {
+func (obj *Pos) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
-		(*(*(Vec))(obj)).deserialize(r)
+		(*(*(Vec))(obj)).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22692,21 +22836,21 @@ func (obj *Pos) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *KickReason) serialize(w io.Writer) {
+func (obj *KickReason) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *KickReason) deserialize(r io.Reader) {
+func (obj *KickReason) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *MapBlk) serialize(w io.Writer) {
+func (obj *MapBlk) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Flags   MapBlkFlags
@@ -22718,7 +22862,7 @@ func (obj *MapBlk) serialize(w io.Writer) {
 			Param2 [4096]uint8
 
 			NodeMetas map[uint16]*NodeMeta
-		}))(obj)).Flags).serialize(w)
+		}))(obj)).Flags).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22736,7 +22880,7 @@ func (obj *MapBlk) serialize(w io.Writer) {
 			Param2 [4096]uint8
 
 			NodeMetas map[uint16]*NodeMeta
-		}))(obj)).LitFrom).serialize(w)
+		}))(obj)).LitFrom).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22744,22 +22888,22 @@ func (obj *MapBlk) serialize(w io.Writer) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.LitFromBlks", err))
 	}
 	{
-		local283 := uint8(2) // Size of param0 in bytes.
+		local286 := uint8(2) // Size of param0 in bytes.
 		{
-			x := local283
+			x := local286
 			write8(w, uint8(x))
 		}
 	}
 	{
-		local284 := uint8(1 + 1) // Size of param1 and param2 combined, in bytes.
+		local287 := uint8(1 + 1) // Size of param1 and param2 combined, in bytes.
 		{
-			x := local284
+			x := local287
 			write8(w, uint8(x))
 		}
 	}
 	{
 		w := zlib.NewWriter(w)
-		for local285 := range (*(*(struct {
+		for local288 := range (*(*(struct {
 			Flags   MapBlkFlags
 			LitFrom LitFromBlks
 
@@ -22781,7 +22925,7 @@ func (obj *MapBlk) serialize(w io.Writer) {
 					Param2 [4096]uint8
 
 					NodeMetas map[uint16]*NodeMeta
-				}))(obj)).Param0)[local285]).serialize(w)
+				}))(obj)).Param0)[local288]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -22833,49 +22977,20 @@ func (obj *MapBlk) serialize(w io.Writer) {
 		}))(obj)).NodeMetas
 		{
 			w := zlib.NewWriter(w)
-			if x == nil {
-				write8(w, 0)
-			} else {
-				write8(w, 2)
-				// len(map[uint16]...) always < math.MaxUint16
-				write16(w, uint16(len(x)))
-				keys := make([]uint16, 0, len(x))
-				for key := range x {
-					keys = append(keys, key)
-				}
-				sort.Slice(keys, func(i, j int) bool {
-					i2pos := func(i int) [3]int16 {
-						return Blkpos2Pos([3]int16{}, keys[i])
-					}
-					p, q := i2pos(i), i2pos(j)
-					for i := range p {
-						switch {
-						case p[i] < q[i]:
-							return true
-						case p[i] > q[i]:
-							return false
-						}
-					}
-					return false
-				})
-				for _, key := range keys {
-					write16(w, key)
-					chk(serialize(w, x[key]))
-				}
-			}
+			writeNodeMetas(w, x, ver)
 			chk(w.Close())
 		}
 	}
 	{
-		local286 := uint8(2) // version
+		local289 := uint8(2) // version
 		{
-			x := local286
+			x := local289
 			write8(w, uint8(x))
 		}
 	}
 }
 
-func (obj *MapBlk) deserialize(r io.Reader) {
+func (obj *MapBlk) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Flags   MapBlkFlags
@@ -22887,7 +23002,7 @@ func (obj *MapBlk) deserialize(r io.Reader) {
 			Param2 [4096]uint8
 
 			NodeMetas map[uint16]*NodeMeta
-		}))(obj)).Flags).deserialize(r)
+		}))(obj)).Flags).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22905,7 +23020,7 @@ func (obj *MapBlk) deserialize(r io.Reader) {
 			Param2 [4096]uint8
 
 			NodeMetas map[uint16]*NodeMeta
-		}))(obj)).LitFrom).deserialize(r)
+		}))(obj)).LitFrom).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -22913,31 +23028,31 @@ func (obj *MapBlk) deserialize(r io.Reader) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.LitFromBlks", err))
 	}
 	{
-		var local287 uint8
-		local288 := uint8(2) // Size of param0 in bytes.
+		var local290 uint8
+		local291 := uint8(2) // Size of param0 in bytes.
 		{
-			p := &local287
+			p := &local290
 			*p = read8(r)
 		}
-		if local287 != local288 {
-			chk(fmt.Errorf("const %v: %v", "uint8(2)     // Size of param0 in bytes.", local287))
+		if local290 != local291 {
+			chk(fmt.Errorf("const %v: %v", "uint8(2)     // Size of param0 in bytes.", local290))
 		}
 	}
 	{
-		var local289 uint8
-		local290 := uint8(1 + 1) // Size of param1 and param2 combined, in bytes.
+		var local292 uint8
+		local293 := uint8(1 + 1) // Size of param1 and param2 combined, in bytes.
 		{
-			p := &local289
+			p := &local292
 			*p = read8(r)
 		}
-		if local289 != local290 {
-			chk(fmt.Errorf("const %v: %v", "uint8(1 + 1) // Size of param1 and param2 combined, in bytes.", local289))
+		if local292 != local293 {
+			chk(fmt.Errorf("const %v: %v", "uint8(1 + 1) // Size of param1 and param2 combined, in bytes.", local292))
 		}
 	}
 	{
 		r, err := zlib.NewReader(byteReader{r})
 		chk(err)
-		for local291 := range (*(*(struct {
+		for local294 := range (*(*(struct {
 			Flags   MapBlkFlags
 			LitFrom LitFromBlks
 
@@ -22959,7 +23074,7 @@ func (obj *MapBlk) deserialize(r io.Reader) {
 					Param2 [4096]uint8
 
 					NodeMetas map[uint16]*NodeMeta
-				}))(obj)).Param0)[local291]).deserialize(r)
+				}))(obj)).Param0)[local294]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -23012,43 +23127,29 @@ func (obj *MapBlk) deserialize(r io.Reader) {
 		{
 			r, err := zlib.NewReader(byteReader{r})
 			chk(err)
-			switch ver := read8(r); ver {
-			case 0:
-				*p = nil
-			case 2:
-				n := read16(r)
-				*p = make(map[uint16]*NodeMeta, n)
-				for ; n > 0; n-- {
-					pos := read16(r)
-					nm := new(NodeMeta)
-					chk(deserialize(r, nm))
-					(*p)[pos] = nm
-				}
-			default:
-				chk(fmt.Errorf("unsupported nodemetas version: %d", ver))
-			}
+			*p = readNodeMetas(r, ver)
 			chk(r.Close())
 		}
 	}
 	{
-		var local292 uint8
-		local293 := uint8(2) // version
+		var local295 uint8
+		local296 := uint8(2) // version
 		{
-			p := &local292
+			p := &local295
 			*p = read8(r)
 		}
-		if local292 != local293 {
-			chk(fmt.Errorf("const %v: %v", "uint8(2) // version", local292))
+		if local295 != local296 {
+			chk(fmt.Errorf("const %v: %v", "uint8(2) // version", local295))
 		}
 	}
 }
 
-func (obj *Node) serialize(w io.Writer) {
+func (obj *Node) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Param0         Content
 			Param1, Param2 uint8
-		}))(obj)).Param0).serialize(w)
+		}))(obj)).Param0).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -23071,12 +23172,12 @@ func (obj *Node) serialize(w io.Writer) {
 	}
 }
 
-func (obj *Node) deserialize(r io.Reader) {
+func (obj *Node) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Param0         Content
 			Param1, Param2 uint8
-		}))(obj)).Param0).deserialize(r)
+		}))(obj)).Param0).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -23099,74 +23200,74 @@ func (obj *Node) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *CSMRestrictionFlags) serialize(w io.Writer) {
+func (obj *CSMRestrictionFlags) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint64))(obj)
 		write64(w, uint64(x))
 	}
 }
 
-func (obj *CSMRestrictionFlags) deserialize(r io.Reader) {
+func (obj *CSMRestrictionFlags) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint64))(obj)
 		*p = read64(r)
 	}
 }
 
-func (obj *Vec) serialize(w io.Writer) {
-	for local294 := range *(*([3]float32))(obj) {
+func (obj *Vec) serialize(w io.Writer, ver uint16) {
+	for local297 := range *(*([3]float32))(obj) {
 		{
-			x := (*(*([3]float32))(obj))[local294]
+			x := (*(*([3]float32))(obj))[local297]
 			write32(w, math.Float32bits(x))
 		}
 	}
 }
 
-func (obj *Vec) deserialize(r io.Reader) {
-	for local295 := range *(*([3]float32))(obj) {
+func (obj *Vec) deserialize(r io.Reader, ver uint16) {
+	for local298 := range *(*([3]float32))(obj) {
 		{
-			p := &(*(*([3]float32))(obj))[local295]
+			p := &(*(*([3]float32))(obj))[local298]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
 }
 
-func (obj *ChatMsgType) serialize(w io.Writer) {
+func (obj *ChatMsgType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *ChatMsgType) deserialize(r io.Reader) {
+func (obj *ChatMsgType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *AOID) serialize(w io.Writer) {
+func (obj *AOID) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *AOID) deserialize(r io.Reader) {
+func (obj *AOID) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *AOAdd) serialize(w io.Writer) {
+func (obj *AOAdd) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
 			//mt:const genericCAO
 			//mt:lenhdr 32
 			InitData AOInitData
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -23174,9 +23275,9 @@ func (obj *AOAdd) serialize(w io.Writer) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AOID", err))
 	}
 	{
-		local296 := genericCAO
+		local299 := genericCAO
 		if err := pcall(func() {
-			(local296).serialize(w)
+			(local299).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -23193,7 +23294,7 @@ func (obj *AOAdd) serialize(w io.Writer) {
 				//mt:const genericCAO
 				//mt:lenhdr 32
 				InitData AOInitData
-			}))(obj)).InitData).serialize(w)
+			}))(obj)).InitData).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -23218,14 +23319,14 @@ func (obj *AOAdd) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOAdd) deserialize(r io.Reader) {
+func (obj *AOAdd) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
 			//mt:const genericCAO
 			//mt:lenhdr 32
 			InitData AOInitData
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -23233,18 +23334,18 @@ func (obj *AOAdd) deserialize(r io.Reader) {
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AOID", err))
 	}
 	{
-		var local297 aoType
-		local298 := genericCAO
+		var local300 aoType
+		local301 := genericCAO
 		if err := pcall(func() {
-			(local297).deserialize(r)
+			(local300).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.aoType", err))
 		}
-		if local297 != local298 {
-			chk(fmt.Errorf("const %v: %v", "genericCAO", local297))
+		if local300 != local301 {
+			chk(fmt.Errorf("const %v: %v", "genericCAO", local300))
 		}
 	}
 	{
@@ -23260,7 +23361,7 @@ func (obj *AOAdd) deserialize(r io.Reader) {
 				//mt:const genericCAO
 				//mt:lenhdr 32
 				InitData AOInitData
-			}))(obj)).InitData).deserialize(r)
+			}))(obj)).InitData).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -23273,13 +23374,13 @@ func (obj *AOAdd) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *IDAOMsg) serialize(w io.Writer) {
+func (obj *IDAOMsg) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
 			//mt:lenhdr 16
 			Msg AOMsg
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -23295,7 +23396,7 @@ func (obj *IDAOMsg) serialize(w io.Writer) {
 				//mt:lenhdr 16
 				Msg AOMsg
 			}))(obj)).Msg
-			writeAOMsg(w, x)
+			writeAOMsg(w, x, ver)
 		}
 		{
 			buf := w
@@ -23315,13 +23416,13 @@ func (obj *IDAOMsg) serialize(w io.Writer) {
 	}
 }
 
-func (obj *IDAOMsg) deserialize(r io.Reader) {
+func (obj *IDAOMsg) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ID AOID
 			//mt:lenhdr 16
 			Msg AOMsg
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -23343,7 +23444,7 @@ func (obj *IDAOMsg) deserialize(r io.Reader) {
 			}))(obj)).Msg
 			{
 				var err error
-				*p, err = readAOMsg(r)
+				*p, err = readAOMsg(r, ver)
 				chk(err)
 			}
 		}
@@ -23353,14 +23454,14 @@ func (obj *IDAOMsg) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ItemDef) serialize(w io.Writer) {
+func (obj *ItemDef) serialize(w io.Writer, ver uint16) {
 	{
 		ow := w
 		w := new(bytes.Buffer)
 		{
-			local299 := uint8(6)
+			local302 := uint8(6)
 			{
-				x := local299
+				x := local302
 				write8(w, uint8(x))
 			}
 		}
@@ -23397,7 +23498,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).Type).serialize(w)
+			}))(obj)).Type).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -23651,7 +23752,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).InvImg).serialize(w)
+			}))(obj)).InvImg).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -23691,14 +23792,14 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).WieldImg).serialize(w)
+			}))(obj)).WieldImg).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Texture", err))
 		}
-		for local300 := range (*(*(struct {
+		for local303 := range (*(*(struct {
 			Type ItemType
 
 			Name, Desc string
@@ -23764,7 +23865,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 					InvOverlay, WieldOverlay Texture
 
 					ShortDesc string
-				}))(obj)).WieldScale)[local300]
+				}))(obj)).WieldScale)[local303]
 				write32(w, math.Float32bits(x))
 			}
 		}
@@ -23917,7 +24018,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).ToolCaps).serialize(w)
+			}))(obj)).ToolCaps).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -23995,7 +24096,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 			}))(obj)).Groups)))
 			write16(w, uint16(x))
 		}
-		for local301 := range (*(*(struct {
+		for local304 := range (*(*(struct {
 			Type ItemType
 
 			Name, Desc string
@@ -24061,7 +24162,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 					InvOverlay, WieldOverlay Texture
 
 					ShortDesc string
-				}))(obj)).Groups)[local301]).serialize(w)
+				}))(obj)).Groups)[local304]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -24209,7 +24310,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).PlaceSnd).serialize(w)
+			}))(obj)).PlaceSnd).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -24249,7 +24350,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).PlaceFailSnd).serialize(w)
+			}))(obj)).PlaceFailSnd).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -24325,7 +24426,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).Palette).serialize(w)
+			}))(obj)).Palette).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -24401,7 +24502,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).InvOverlay).serialize(w)
+			}))(obj)).InvOverlay).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -24441,7 +24542,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).WieldOverlay).serialize(w)
+			}))(obj)).WieldOverlay).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -24573,7 +24674,7 @@ func (obj *ItemDef) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ItemDef) deserialize(r io.Reader) {
+func (obj *ItemDef) deserialize(r io.Reader, ver uint16) {
 	{
 		var n uint16
 		{
@@ -24582,14 +24683,14 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 		}
 		r := &io.LimitedReader{R: r, N: int64(n)}
 		{
-			var local302 uint8
-			local303 := uint8(6)
+			var local305 uint8
+			local306 := uint8(6)
 			{
-				p := &local302
+				p := &local305
 				*p = read8(r)
 			}
-			if local302 != local303 {
-				chk(fmt.Errorf("const %v: %v", "uint8(6)", local302))
+			if local305 != local306 {
+				chk(fmt.Errorf("const %v: %v", "uint8(6)", local305))
 			}
 		}
 		if err := pcall(func() {
@@ -24625,22 +24726,22 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).Type).deserialize(r)
+			}))(obj)).Type).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ItemType", err))
 		}
-		var local304 []uint8
-		var local305 uint16
+		var local307 []uint8
+		var local308 uint16
 		{
-			p := &local305
+			p := &local308
 			*p = read16(r)
 		}
-		(local304) = make([]uint8, local305)
+		(local307) = make([]uint8, local308)
 		{
-			_, err := io.ReadFull(r, (local304)[:])
+			_, err := io.ReadFull(r, (local307)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -24675,16 +24776,16 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 			InvOverlay, WieldOverlay Texture
 
 			ShortDesc string
-		}))(obj)).Name) = string(local304)
-		var local306 []uint8
-		var local307 uint16
+		}))(obj)).Name) = string(local307)
+		var local309 []uint8
+		var local310 uint16
 		{
-			p := &local307
+			p := &local310
 			*p = read16(r)
 		}
-		(local306) = make([]uint8, local307)
+		(local309) = make([]uint8, local310)
 		{
-			_, err := io.ReadFull(r, (local306)[:])
+			_, err := io.ReadFull(r, (local309)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -24719,7 +24820,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 			InvOverlay, WieldOverlay Texture
 
 			ShortDesc string
-		}))(obj)).Desc) = string(local306)
+		}))(obj)).Desc) = string(local309)
 		if err := pcall(func() {
 			((*(*(struct {
 				Type ItemType
@@ -24753,7 +24854,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).InvImg).deserialize(r)
+			}))(obj)).InvImg).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -24793,14 +24894,14 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).WieldImg).deserialize(r)
+			}))(obj)).WieldImg).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Texture", err))
 		}
-		for local308 := range (*(*(struct {
+		for local311 := range (*(*(struct {
 			Type ItemType
 
 			Name, Desc string
@@ -24866,7 +24967,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 					InvOverlay, WieldOverlay Texture
 
 					ShortDesc string
-				}))(obj)).WieldScale)[local308]
+				}))(obj)).WieldScale)[local311]
 				*p = math.Float32frombits(read32(r))
 			}
 		}
@@ -25025,16 +25126,16 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).ToolCaps).deserialize(r)
+			}))(obj)).ToolCaps).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ToolCaps", err))
 		}
-		var local309 uint16
+		var local312 uint16
 		{
-			p := &local309
+			p := &local312
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -25069,8 +25170,8 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 			InvOverlay, WieldOverlay Texture
 
 			ShortDesc string
-		}))(obj)).Groups) = make([]Group, local309)
-		for local310 := range (*(*(struct {
+		}))(obj)).Groups) = make([]Group, local312)
+		for local313 := range (*(*(struct {
 			Type ItemType
 
 			Name, Desc string
@@ -25136,7 +25237,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 					InvOverlay, WieldOverlay Texture
 
 					ShortDesc string
-				}))(obj)).Groups)[local310]).deserialize(r)
+				}))(obj)).Groups)[local313]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -25144,15 +25245,15 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Group", err))
 			}
 		}
-		var local311 []uint8
-		var local312 uint16
+		var local314 []uint8
+		var local315 uint16
 		{
-			p := &local312
+			p := &local315
 			*p = read16(r)
 		}
-		(local311) = make([]uint8, local312)
+		(local314) = make([]uint8, local315)
 		{
-			_, err := io.ReadFull(r, (local311)[:])
+			_, err := io.ReadFull(r, (local314)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -25187,7 +25288,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 			InvOverlay, WieldOverlay Texture
 
 			ShortDesc string
-		}))(obj)).PlacePredict) = string(local311)
+		}))(obj)).PlacePredict) = string(local314)
 		if err := pcall(func() {
 			((*(*(struct {
 				Type ItemType
@@ -25221,7 +25322,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).PlaceSnd).deserialize(r)
+			}))(obj)).PlaceSnd).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -25261,7 +25362,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).PlaceFailSnd).deserialize(r)
+			}))(obj)).PlaceFailSnd).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -25337,7 +25438,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).Palette).deserialize(r)
+			}))(obj)).Palette).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -25413,7 +25514,7 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).InvOverlay).deserialize(r)
+			}))(obj)).InvOverlay).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -25453,22 +25554,22 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 				InvOverlay, WieldOverlay Texture
 
 				ShortDesc string
-			}))(obj)).WieldOverlay).deserialize(r)
+			}))(obj)).WieldOverlay).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Texture", err))
 		}
-		var local313 []uint8
-		var local314 uint16
+		var local316 []uint8
+		var local317 uint16
 		{
-			p := &local314
+			p := &local317
 			*p = read16(r)
 		}
-		(local313) = make([]uint8, local314)
+		(local316) = make([]uint8, local317)
 		{
-			_, err := io.ReadFull(r, (local313)[:])
+			_, err := io.ReadFull(r, (local316)[:])
 			chk(err)
 		}
 		((*(*(struct {
@@ -25503,28 +25604,28 @@ func (obj *ItemDef) deserialize(r io.Reader) {
 			InvOverlay, WieldOverlay Texture
 
 			ShortDesc string
-		}))(obj)).ShortDesc) = string(local313)
+		}))(obj)).ShortDesc) = string(local316)
 		if r.N > 0 {
 			chk(fmt.Errorf("%d bytes of trailing data", r.N))
 		}
 	}
 }
 
-func (obj *SoundSrcType) serialize(w io.Writer) {
+func (obj *SoundSrcType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *SoundSrcType) deserialize(r io.Reader) {
+func (obj *SoundSrcType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *TileAnim) serialize(w io.Writer) {
+func (obj *TileAnim) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type AnimType
@@ -25538,7 +25639,7 @@ func (obj *TileAnim) serialize(w io.Writer) {
 			//mt:if %s.Type != NoAnim
 			Duration float32 // in seconds
 
-		}))(obj)).Type).serialize(w)
+		}))(obj)).Type).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -25603,7 +25704,7 @@ func (obj *TileAnim) serialize(w io.Writer) {
 		Duration float32 // in seconds
 
 	}))(obj)).Type == VerticalFrameAnim {
-		for local315 := range (*(*(struct {
+		for local318 := range (*(*(struct {
 			Type AnimType
 
 			//mt:if %s.Type == SpriteSheetAnim
@@ -25629,7 +25730,7 @@ func (obj *TileAnim) serialize(w io.Writer) {
 					//mt:if %s.Type != NoAnim
 					Duration float32 // in seconds
 
-				}))(obj)).NFrames)[local315]
+				}))(obj)).NFrames)[local318]
 				write16(w, uint16(x))
 			}
 		}
@@ -25666,7 +25767,7 @@ func (obj *TileAnim) serialize(w io.Writer) {
 	}
 }
 
-func (obj *TileAnim) deserialize(r io.Reader) {
+func (obj *TileAnim) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type AnimType
@@ -25680,7 +25781,7 @@ func (obj *TileAnim) deserialize(r io.Reader) {
 			//mt:if %s.Type != NoAnim
 			Duration float32 // in seconds
 
-		}))(obj)).Type).deserialize(r)
+		}))(obj)).Type).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -25745,7 +25846,7 @@ func (obj *TileAnim) deserialize(r io.Reader) {
 		Duration float32 // in seconds
 
 	}))(obj)).Type == VerticalFrameAnim {
-		for local316 := range (*(*(struct {
+		for local319 := range (*(*(struct {
 			Type AnimType
 
 			//mt:if %s.Type == SpriteSheetAnim
@@ -25771,7 +25872,7 @@ func (obj *TileAnim) deserialize(r io.Reader) {
 					//mt:if %s.Type != NoAnim
 					Duration float32 // in seconds
 
-				}))(obj)).NFrames)[local316]
+				}))(obj)).NFrames)[local319]
 				*p = read16(r)
 			}
 		}
@@ -25808,49 +25909,49 @@ func (obj *TileAnim) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *Content) serialize(w io.Writer) {
+func (obj *Content) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *Content) deserialize(r io.Reader) {
+func (obj *Content) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *ParticleSpawnerID) serialize(w io.Writer) {
+func (obj *ParticleSpawnerID) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint32))(obj)
 		write32(w, uint32(x))
 	}
 }
 
-func (obj *ParticleSpawnerID) deserialize(r io.Reader) {
+func (obj *ParticleSpawnerID) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint32))(obj)
 		*p = read32(r)
 	}
 }
 
-func (obj *HUDID) serialize(w io.Writer) {
+func (obj *HUDID) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint32))(obj)
 		write32(w, uint32(x))
 	}
 }
 
-func (obj *HUDID) deserialize(r io.Reader) {
+func (obj *HUDID) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint32))(obj)
 		*p = read32(r)
 	}
 }
 
-func (obj *HUD) serialize(w io.Writer) {
+func (obj *HUD) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type HUDType
@@ -25868,14 +25969,14 @@ func (obj *HUD) serialize(w io.Writer) {
 			Size     [2]int32
 			ZIndex   int16
 			Text2    string
-		}))(obj)).Type).serialize(w)
+		}))(obj)).Type).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.HUDType", err))
 	}
-	for local317 := range (*(*(struct {
+	for local320 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -25909,7 +26010,7 @@ func (obj *HUD) serialize(w io.Writer) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Pos)[local317]
+			}))(obj)).Pos)[local320]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -25972,7 +26073,7 @@ func (obj *HUD) serialize(w io.Writer) {
 		}))(obj)).Name))[:])
 		chk(err)
 	}
-	for local318 := range (*(*(struct {
+	for local321 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26006,7 +26107,7 @@ func (obj *HUD) serialize(w io.Writer) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Scale)[local318]
+			}))(obj)).Scale)[local321]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -26129,7 +26230,7 @@ func (obj *HUD) serialize(w io.Writer) {
 		}))(obj)).Dir
 		write32(w, uint32(x))
 	}
-	for local319 := range (*(*(struct {
+	for local322 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26163,11 +26264,11 @@ func (obj *HUD) serialize(w io.Writer) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Align)[local319]
+			}))(obj)).Align)[local322]
 			write32(w, math.Float32bits(x))
 		}
 	}
-	for local320 := range (*(*(struct {
+	for local323 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26201,7 +26302,7 @@ func (obj *HUD) serialize(w io.Writer) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Offset)[local320]
+			}))(obj)).Offset)[local323]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -26222,14 +26323,14 @@ func (obj *HUD) serialize(w io.Writer) {
 			Size     [2]int32
 			ZIndex   int16
 			Text2    string
-		}))(obj)).WorldPos).serialize(w)
+		}))(obj)).WorldPos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Pos", err))
 	}
-	for local321 := range (*(*(struct {
+	for local324 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26263,7 +26364,7 @@ func (obj *HUD) serialize(w io.Writer) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Size)[local321]
+			}))(obj)).Size)[local324]
 			write32(w, uint32(x))
 		}
 	}
@@ -26348,7 +26449,7 @@ func (obj *HUD) serialize(w io.Writer) {
 	}
 }
 
-func (obj *HUD) deserialize(r io.Reader) {
+func (obj *HUD) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Type HUDType
@@ -26366,14 +26467,14 @@ func (obj *HUD) deserialize(r io.Reader) {
 			Size     [2]int32
 			ZIndex   int16
 			Text2    string
-		}))(obj)).Type).deserialize(r)
+		}))(obj)).Type).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.HUDType", err))
 	}
-	for local322 := range (*(*(struct {
+	for local325 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26407,19 +26508,19 @@ func (obj *HUD) deserialize(r io.Reader) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Pos)[local322]
+			}))(obj)).Pos)[local325]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	var local323 []uint8
-	var local324 uint16
+	var local326 []uint8
+	var local327 uint16
 	{
-		p := &local324
+		p := &local327
 		*p = read16(r)
 	}
-	(local323) = make([]uint8, local324)
+	(local326) = make([]uint8, local327)
 	{
-		_, err := io.ReadFull(r, (local323)[:])
+		_, err := io.ReadFull(r, (local326)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -26438,8 +26539,8 @@ func (obj *HUD) deserialize(r io.Reader) {
 		Size     [2]int32
 		ZIndex   int16
 		Text2    string
-	}))(obj)).Name) = string(local323)
-	for local325 := range (*(*(struct {
+	}))(obj)).Name) = string(local326)
+	for local328 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26473,19 +26574,19 @@ func (obj *HUD) deserialize(r io.Reader) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Scale)[local325]
+			}))(obj)).Scale)[local328]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	var local326 []uint8
-	var local327 uint16
+	var local329 []uint8
+	var local330 uint16
 	{
-		p := &local327
+		p := &local330
 		*p = read16(r)
 	}
-	(local326) = make([]uint8, local327)
+	(local329) = make([]uint8, local330)
 	{
-		_, err := io.ReadFull(r, (local326)[:])
+		_, err := io.ReadFull(r, (local329)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -26504,7 +26605,7 @@ func (obj *HUD) deserialize(r io.Reader) {
 		Size     [2]int32
 		ZIndex   int16
 		Text2    string
-	}))(obj)).Text) = string(local326)
+	}))(obj)).Text) = string(local329)
 	{
 		p := &(*(*(struct {
 			Type HUDType
@@ -26565,7 +26666,7 @@ func (obj *HUD) deserialize(r io.Reader) {
 		}))(obj)).Dir
 		*p = read32(r)
 	}
-	for local328 := range (*(*(struct {
+	for local331 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26599,11 +26700,11 @@ func (obj *HUD) deserialize(r io.Reader) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Align)[local328]
+			}))(obj)).Align)[local331]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	for local329 := range (*(*(struct {
+	for local332 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26637,7 +26738,7 @@ func (obj *HUD) deserialize(r io.Reader) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Offset)[local329]
+			}))(obj)).Offset)[local332]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
@@ -26658,14 +26759,14 @@ func (obj *HUD) deserialize(r io.Reader) {
 			Size     [2]int32
 			ZIndex   int16
 			Text2    string
-		}))(obj)).WorldPos).deserialize(r)
+		}))(obj)).WorldPos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Pos", err))
 	}
-	for local330 := range (*(*(struct {
+	for local333 := range (*(*(struct {
 		Type HUDType
 
 		Pos      [2]float32
@@ -26699,7 +26800,7 @@ func (obj *HUD) deserialize(r io.Reader) {
 				Size     [2]int32
 				ZIndex   int16
 				Text2    string
-			}))(obj)).Size)[local330]
+			}))(obj)).Size)[local333]
 			*p = int32(read32(r))
 		}
 	}
@@ -26723,15 +26824,15 @@ func (obj *HUD) deserialize(r io.Reader) {
 		}))(obj)).ZIndex
 		*p = int16(read16(r))
 	}
-	var local331 []uint8
-	var local332 uint16
+	var local334 []uint8
+	var local335 uint16
 	{
-		p := &local332
+		p := &local335
 		*p = read16(r)
 	}
-	(local331) = make([]uint8, local332)
+	(local334) = make([]uint8, local335)
 	{
-		_, err := io.ReadFull(r, (local331)[:])
+		_, err := io.ReadFull(r, (local334)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -26750,52 +26851,52 @@ func (obj *HUD) deserialize(r io.Reader) {
 		Size     [2]int32
 		ZIndex   int16
 		Text2    string
-	}))(obj)).Text2) = string(local331)
+	}))(obj)).Text2) = string(local334)
 }
 
-func (obj *HUDField) serialize(w io.Writer) {
+func (obj *HUDField) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *HUDField) deserialize(r io.Reader) {
+func (obj *HUDField) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *HUDFlags) serialize(w io.Writer) {
+func (obj *HUDFlags) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint32))(obj)
 		write32(w, uint32(x))
 	}
 }
 
-func (obj *HUDFlags) deserialize(r io.Reader) {
+func (obj *HUDFlags) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint32))(obj)
 		*p = read32(r)
 	}
 }
 
-func (obj *HotbarParam) serialize(w io.Writer) {
+func (obj *HotbarParam) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *HotbarParam) deserialize(r io.Reader) {
+func (obj *HotbarParam) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *Texture) serialize(w io.Writer) {
+func (obj *Texture) serialize(w io.Writer, ver uint16) {
 	if len(([]byte(*(*(string))(obj)))) > math.MaxUint16 {
 		chk(ErrTooLong)
 	}
@@ -26809,54 +26910,54 @@ func (obj *Texture) serialize(w io.Writer) {
 	}
 }
 
-func (obj *Texture) deserialize(r io.Reader) {
-	var local333 []uint8
-	var local334 uint16
+func (obj *Texture) deserialize(r io.Reader, ver uint16) {
+	var local336 []uint8
+	var local337 uint16
 	{
-		p := &local334
+		p := &local337
 		*p = read16(r)
 	}
-	(local333) = make([]uint8, local334)
+	(local336) = make([]uint8, local337)
 	{
-		_, err := io.ReadFull(r, (local333)[:])
+		_, err := io.ReadFull(r, (local336)[:])
 		chk(err)
 	}
-	(*(*(string))(obj)) = string(local333)
+	(*(*(string))(obj)) = string(local336)
 }
 
-func (obj *PlayerListUpdateType) serialize(w io.Writer) {
+func (obj *PlayerListUpdateType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *PlayerListUpdateType) deserialize(r io.Reader) {
+func (obj *PlayerListUpdateType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *ModChanSig) serialize(w io.Writer) {
+func (obj *ModChanSig) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *ModChanSig) deserialize(r io.Reader) {
+func (obj *ModChanSig) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *AOProps) serialize(w io.Writer) {
+func (obj *AOProps) serialize(w io.Writer, ver uint16) {
 	{
-		local335 := uint8(4)
+		local338 := uint8(4)
 		{
-			x := local335
+			x := local338
 			write8(w, uint8(x))
 		}
 	}
@@ -27017,7 +27118,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 			Shaded           bool
 			ShowOnMinimap    bool
 			NametagBG        color.NRGBA
-		}))(obj)).ColBox).serialize(w)
+		}))(obj)).ColBox).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -27060,7 +27161,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 			Shaded           bool
 			ShowOnMinimap    bool
 			NametagBG        color.NRGBA
-		}))(obj)).SelBox).serialize(w)
+		}))(obj)).SelBox).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -27226,7 +27327,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 		}))(obj)).Visual))[:])
 		chk(err)
 	}
-	for local336 := range (*(*(struct {
+	for local339 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -27298,7 +27399,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).VisualSize)[local336]
+			}))(obj)).VisualSize)[local339]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -27379,7 +27480,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 		}))(obj)).Textures)))
 		write16(w, uint16(x))
 	}
-	for local337 := range (*(*(struct {
+	for local340 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -27451,7 +27552,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).Textures)[local337]).serialize(w)
+			}))(obj)).Textures)[local340]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -27459,7 +27560,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Texture", err))
 		}
 	}
-	for local338 := range (*(*(struct {
+	for local341 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -27531,11 +27632,11 @@ func (obj *AOProps) serialize(w io.Writer) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).SpriteSheetSize)[local338]
+			}))(obj)).SpriteSheetSize)[local341]
 			write16(w, uint16(x))
 		}
 	}
-	for local339 := range (*(*(struct {
+	for local342 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -27607,7 +27708,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).SpritePos)[local339]
+			}))(obj)).SpritePos)[local342]
 			write16(w, uint16(x))
 		}
 	}
@@ -27929,7 +28030,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 		}))(obj)).Colors)))
 		write16(w, uint16(x))
 	}
-	for local340 := range (*(*(struct {
+	for local343 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -28001,7 +28102,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).Colors)[local340]
+			}))(obj)).Colors)[local343]
 			w.Write([]byte{x.A, x.R, x.G, x.B})
 		}
 	}
@@ -28873,7 +28974,7 @@ func (obj *AOProps) serialize(w io.Writer) {
 			Shaded           bool
 			ShowOnMinimap    bool
 			NametagBG        color.NRGBA
-		}))(obj)).DmgTextureMod).serialize(w)
+		}))(obj)).DmgTextureMod).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -29007,16 +29108,16 @@ func (obj *AOProps) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOProps) deserialize(r io.Reader) {
+func (obj *AOProps) deserialize(r io.Reader, ver uint16) {
 	{
-		var local341 uint8
-		local342 := uint8(4)
+		var local344 uint8
+		local345 := uint8(4)
 		{
-			p := &local341
+			p := &local344
 			*p = read8(r)
 		}
-		if local341 != local342 {
-			chk(fmt.Errorf("const %v: %v", "uint8(4)", local341))
+		if local344 != local345 {
+			chk(fmt.Errorf("const %v: %v", "uint8(4)", local344))
 		}
 	}
 	{
@@ -29179,7 +29280,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 			Shaded           bool
 			ShowOnMinimap    bool
 			NametagBG        color.NRGBA
-		}))(obj)).ColBox).deserialize(r)
+		}))(obj)).ColBox).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -29222,7 +29323,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 			Shaded           bool
 			ShowOnMinimap    bool
 			NametagBG        color.NRGBA
-		}))(obj)).SelBox).deserialize(r)
+		}))(obj)).SelBox).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -29275,15 +29376,15 @@ func (obj *AOProps) deserialize(r io.Reader) {
 			chk(fmt.Errorf("invalid bool: %d", n))
 		}
 	}
-	var local343 []uint8
-	var local344 uint16
+	var local346 []uint8
+	var local347 uint16
 	{
-		p := &local344
+		p := &local347
 		*p = read16(r)
 	}
-	(local343) = make([]uint8, local344)
+	(local346) = make([]uint8, local347)
 	{
-		_, err := io.ReadFull(r, (local343)[:])
+		_, err := io.ReadFull(r, (local346)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -29321,8 +29422,8 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Visual) = string(local343)
-	for local345 := range (*(*(struct {
+	}))(obj)).Visual) = string(local346)
+	for local348 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -29394,13 +29495,13 @@ func (obj *AOProps) deserialize(r io.Reader) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).VisualSize)[local345]
+			}))(obj)).VisualSize)[local348]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
-	var local346 uint16
+	var local349 uint16
 	{
-		p := &local346
+		p := &local349
 		*p = read16(r)
 	}
 	((*(*(struct {
@@ -29438,8 +29539,8 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Textures) = make([]Texture, local346)
-	for local347 := range (*(*(struct {
+	}))(obj)).Textures) = make([]Texture, local349)
+	for local350 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -29511,7 +29612,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).Textures)[local347]).deserialize(r)
+			}))(obj)).Textures)[local350]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -29519,7 +29620,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Texture", err))
 		}
 	}
-	for local348 := range (*(*(struct {
+	for local351 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -29591,11 +29692,11 @@ func (obj *AOProps) deserialize(r io.Reader) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).SpriteSheetSize)[local348]
+			}))(obj)).SpriteSheetSize)[local351]
 			*p = int16(read16(r))
 		}
 	}
-	for local349 := range (*(*(struct {
+	for local352 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -29667,7 +29768,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).SpritePos)[local349]
+			}))(obj)).SpritePos)[local352]
 			*p = int16(read16(r))
 		}
 	}
@@ -29802,15 +29903,15 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		}))(obj)).RotateSpeed
 		*p = math.Float32frombits(read32(r))
 	}
-	var local350 []uint8
-	var local351 uint16
+	var local353 []uint8
+	var local354 uint16
 	{
-		p := &local351
+		p := &local354
 		*p = read16(r)
 	}
-	(local350) = make([]uint8, local351)
+	(local353) = make([]uint8, local354)
 	{
-		_, err := io.ReadFull(r, (local350)[:])
+		_, err := io.ReadFull(r, (local353)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -29848,10 +29949,10 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Mesh) = string(local350)
-	var local352 uint16
+	}))(obj)).Mesh) = string(local353)
+	var local355 uint16
 	{
-		p := &local352
+		p := &local355
 		*p = read16(r)
 	}
 	((*(*(struct {
@@ -29889,8 +29990,8 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Colors) = make([]color.NRGBA, local352)
-	for local353 := range (*(*(struct {
+	}))(obj)).Colors) = make([]color.NRGBA, local355)
+	for local356 := range (*(*(struct {
 		MaxHP            uint16 // Player only.
 		CollideWithNodes bool
 		Weight           float32 // deprecated
@@ -29962,7 +30063,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 				Shaded           bool
 				ShowOnMinimap    bool
 				NametagBG        color.NRGBA
-			}))(obj)).Colors)[local353]
+			}))(obj)).Colors)[local356]
 			*p = color.NRGBA{A: read8(r), R: read8(r), G: read8(r), B: read8(r)}
 		}
 	}
@@ -30182,15 +30283,15 @@ func (obj *AOProps) deserialize(r io.Reader) {
 			chk(fmt.Errorf("invalid bool: %d", n))
 		}
 	}
-	var local354 []uint8
-	var local355 uint16
+	var local357 []uint8
+	var local358 uint16
 	{
-		p := &local355
+		p := &local358
 		*p = read16(r)
 	}
-	(local354) = make([]uint8, local355)
+	(local357) = make([]uint8, local358)
 	{
-		_, err := io.ReadFull(r, (local354)[:])
+		_, err := io.ReadFull(r, (local357)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -30228,7 +30329,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Nametag) = string(local354)
+	}))(obj)).Nametag) = string(local357)
 	{
 		p := &(*(*(struct {
 			MaxHP            uint16 // Player only.
@@ -30307,15 +30408,15 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		}))(obj)).FaceRotateSpeed
 		*p = math.Float32frombits(read32(r))
 	}
-	var local356 []uint8
-	var local357 uint16
+	var local359 []uint8
+	var local360 uint16
 	{
-		p := &local357
+		p := &local360
 		*p = read16(r)
 	}
-	(local356) = make([]uint8, local357)
+	(local359) = make([]uint8, local360)
 	{
-		_, err := io.ReadFull(r, (local356)[:])
+		_, err := io.ReadFull(r, (local359)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -30353,16 +30454,16 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Infotext) = string(local356)
-	var local358 []uint8
-	var local359 uint16
+	}))(obj)).Infotext) = string(local359)
+	var local361 []uint8
+	var local362 uint16
 	{
-		p := &local359
+		p := &local362
 		*p = read16(r)
 	}
-	(local358) = make([]uint8, local359)
+	(local361) = make([]uint8, local362)
 	{
-		_, err := io.ReadFull(r, (local358)[:])
+		_, err := io.ReadFull(r, (local361)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -30400,7 +30501,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 		Shaded           bool
 		ShowOnMinimap    bool
 		NametagBG        color.NRGBA
-	}))(obj)).Itemstring) = string(local358)
+	}))(obj)).Itemstring) = string(local361)
 	{
 		p := &(*(*(struct {
 			MaxHP            uint16 // Player only.
@@ -30639,7 +30740,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 			Shaded           bool
 			ShowOnMinimap    bool
 			NametagBG        color.NRGBA
-		}))(obj)).DmgTextureMod).deserialize(r)
+		}))(obj)).DmgTextureMod).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -30779,7 +30880,7 @@ func (obj *AOProps) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOPos) serialize(w io.Writer) {
+func (obj *AOPos) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos
@@ -30789,7 +30890,7 @@ func (obj *AOPos) serialize(w io.Writer) {
 			Interpolate    bool
 			End            bool
 			UpdateInterval float32
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -30805,7 +30906,7 @@ func (obj *AOPos) serialize(w io.Writer) {
 			Interpolate    bool
 			End            bool
 			UpdateInterval float32
-		}))(obj)).Vel).serialize(w)
+		}))(obj)).Vel).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -30821,14 +30922,14 @@ func (obj *AOPos) serialize(w io.Writer) {
 			Interpolate    bool
 			End            bool
 			UpdateInterval float32
-		}))(obj)).Acc).serialize(w)
+		}))(obj)).Acc).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Vec", err))
 	}
-	for local360 := range (*(*(struct {
+	for local363 := range (*(*(struct {
 		Pos
 		Vel, Acc Vec
 		Rot      [3]float32
@@ -30846,7 +30947,7 @@ func (obj *AOPos) serialize(w io.Writer) {
 				Interpolate    bool
 				End            bool
 				UpdateInterval float32
-			}))(obj)).Rot)[local360]
+			}))(obj)).Rot)[local363]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -30896,7 +30997,7 @@ func (obj *AOPos) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOPos) deserialize(r io.Reader) {
+func (obj *AOPos) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos
@@ -30906,7 +31007,7 @@ func (obj *AOPos) deserialize(r io.Reader) {
 			Interpolate    bool
 			End            bool
 			UpdateInterval float32
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -30922,7 +31023,7 @@ func (obj *AOPos) deserialize(r io.Reader) {
 			Interpolate    bool
 			End            bool
 			UpdateInterval float32
-		}))(obj)).Vel).deserialize(r)
+		}))(obj)).Vel).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -30938,14 +31039,14 @@ func (obj *AOPos) deserialize(r io.Reader) {
 			Interpolate    bool
 			End            bool
 			UpdateInterval float32
-		}))(obj)).Acc).deserialize(r)
+		}))(obj)).Acc).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Vec", err))
 	}
-	for local361 := range (*(*(struct {
+	for local364 := range (*(*(struct {
 		Pos
 		Vel, Acc Vec
 		Rot      [3]float32
@@ -30963,7 +31064,7 @@ func (obj *AOPos) deserialize(r io.Reader) {
 				Interpolate    bool
 				End            bool
 				UpdateInterval float32
-			}))(obj)).Rot)[local361]
+			}))(obj)).Rot)[local364]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
@@ -31019,8 +31120,8 @@ func (obj *AOPos) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOSprite) serialize(w io.Writer) {
-	for local362 := range (*(*(struct {
+func (obj *AOSprite) serialize(w io.Writer, ver uint16) {
+	for local365 := range (*(*(struct {
 		Frame0          [2]int16
 		Frames          uint16
 		FrameDuration   float32
@@ -31032,7 +31133,7 @@ func (obj *AOSprite) serialize(w io.Writer) {
 				Frames          uint16
 				FrameDuration   float32
 				ViewAngleFrames bool
-			}))(obj)).Frame0)[local362]
+			}))(obj)).Frame0)[local365]
 			write16(w, uint16(x))
 		}
 	}
@@ -31069,8 +31170,8 @@ func (obj *AOSprite) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOSprite) deserialize(r io.Reader) {
-	for local363 := range (*(*(struct {
+func (obj *AOSprite) deserialize(r io.Reader, ver uint16) {
+	for local366 := range (*(*(struct {
 		Frame0          [2]int16
 		Frames          uint16
 		FrameDuration   float32
@@ -31082,7 +31183,7 @@ func (obj *AOSprite) deserialize(r io.Reader) {
 				Frames          uint16
 				FrameDuration   float32
 				ViewAngleFrames bool
-			}))(obj)).Frame0)[local363]
+			}))(obj)).Frame0)[local366]
 			*p = int16(read16(r))
 		}
 	}
@@ -31122,7 +31223,7 @@ func (obj *AOSprite) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *Group) serialize(w io.Writer) {
+func (obj *Group) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Name   string
 		Rating int16
@@ -31152,22 +31253,22 @@ func (obj *Group) serialize(w io.Writer) {
 	}
 }
 
-func (obj *Group) deserialize(r io.Reader) {
-	var local364 []uint8
-	var local365 uint16
+func (obj *Group) deserialize(r io.Reader, ver uint16) {
+	var local367 []uint8
+	var local368 uint16
 	{
-		p := &local365
+		p := &local368
 		*p = read16(r)
 	}
-	(local364) = make([]uint8, local365)
+	(local367) = make([]uint8, local368)
 	{
-		_, err := io.ReadFull(r, (local364)[:])
+		_, err := io.ReadFull(r, (local367)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Name   string
 		Rating int16
-	}))(obj)).Name) = string(local364)
+	}))(obj)).Name) = string(local367)
 	{
 		p := &(*(*(struct {
 			Name   string
@@ -31177,8 +31278,8 @@ func (obj *Group) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOAnim) serialize(w io.Writer) {
-	for local366 := range (*(*(struct {
+func (obj *AOAnim) serialize(w io.Writer, ver uint16) {
+	for local369 := range (*(*(struct {
 		Frames [2]int32
 		Speed  float32
 		Blend  float32
@@ -31190,7 +31291,7 @@ func (obj *AOAnim) serialize(w io.Writer) {
 				Speed  float32
 				Blend  float32
 				NoLoop bool
-			}))(obj)).Frames)[local366]
+			}))(obj)).Frames)[local369]
 			write32(w, uint32(x))
 		}
 	}
@@ -31227,8 +31328,8 @@ func (obj *AOAnim) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOAnim) deserialize(r io.Reader) {
-	for local367 := range (*(*(struct {
+func (obj *AOAnim) deserialize(r io.Reader, ver uint16) {
+	for local370 := range (*(*(struct {
 		Frames [2]int32
 		Speed  float32
 		Blend  float32
@@ -31240,7 +31341,7 @@ func (obj *AOAnim) deserialize(r io.Reader) {
 				Speed  float32
 				Blend  float32
 				NoLoop bool
-			}))(obj)).Frames)[local367]
+			}))(obj)).Frames)[local370]
 			*p = int32(read32(r))
 		}
 	}
@@ -31280,19 +31381,19 @@ func (obj *AOAnim) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOBonePos) serialize(w io.Writer) {
+func (obj *AOBonePos) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos Vec
 			Rot [3]float32
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Vec", err))
 	}
-	for local368 := range (*(*(struct {
+	for local371 := range (*(*(struct {
 		Pos Vec
 		Rot [3]float32
 	}))(obj)).Rot {
@@ -31300,25 +31401,25 @@ func (obj *AOBonePos) serialize(w io.Writer) {
 			x := ((*(*(struct {
 				Pos Vec
 				Rot [3]float32
-			}))(obj)).Rot)[local368]
+			}))(obj)).Rot)[local371]
 			write32(w, math.Float32bits(x))
 		}
 	}
 }
 
-func (obj *AOBonePos) deserialize(r io.Reader) {
+func (obj *AOBonePos) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Pos Vec
 			Rot [3]float32
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Vec", err))
 	}
-	for local369 := range (*(*(struct {
+	for local372 := range (*(*(struct {
 		Pos Vec
 		Rot [3]float32
 	}))(obj)).Rot {
@@ -31326,13 +31427,13 @@ func (obj *AOBonePos) deserialize(r io.Reader) {
 			p := &((*(*(struct {
 				Pos Vec
 				Rot [3]float32
-			}))(obj)).Rot)[local369]
+			}))(obj)).Rot)[local372]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
 }
 
-func (obj *AOAttach) serialize(w io.Writer) {
+func (obj *AOAttach) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ParentID     AOID
@@ -31340,7 +31441,7 @@ func (obj *AOAttach) serialize(w io.Writer) {
 			Pos          Vec
 			Rot          [3]float32
 			ForceVisible bool
-		}))(obj)).ParentID).serialize(w)
+		}))(obj)).ParentID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -31383,14 +31484,14 @@ func (obj *AOAttach) serialize(w io.Writer) {
 			Pos          Vec
 			Rot          [3]float32
 			ForceVisible bool
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Vec", err))
 	}
-	for local370 := range (*(*(struct {
+	for local373 := range (*(*(struct {
 		ParentID     AOID
 		Bone         string
 		Pos          Vec
@@ -31404,7 +31505,7 @@ func (obj *AOAttach) serialize(w io.Writer) {
 				Pos          Vec
 				Rot          [3]float32
 				ForceVisible bool
-			}))(obj)).Rot)[local370]
+			}))(obj)).Rot)[local373]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -31424,7 +31525,7 @@ func (obj *AOAttach) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOAttach) deserialize(r io.Reader) {
+func (obj *AOAttach) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			ParentID     AOID
@@ -31432,22 +31533,22 @@ func (obj *AOAttach) deserialize(r io.Reader) {
 			Pos          Vec
 			Rot          [3]float32
 			ForceVisible bool
-		}))(obj)).ParentID).deserialize(r)
+		}))(obj)).ParentID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.AOID", err))
 	}
-	var local371 []uint8
-	var local372 uint16
+	var local374 []uint8
+	var local375 uint16
 	{
-		p := &local372
+		p := &local375
 		*p = read16(r)
 	}
-	(local371) = make([]uint8, local372)
+	(local374) = make([]uint8, local375)
 	{
-		_, err := io.ReadFull(r, (local371)[:])
+		_, err := io.ReadFull(r, (local374)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -31456,7 +31557,7 @@ func (obj *AOAttach) deserialize(r io.Reader) {
 		Pos          Vec
 		Rot          [3]float32
 		ForceVisible bool
-	}))(obj)).Bone) = string(local371)
+	}))(obj)).Bone) = string(local374)
 	if err := pcall(func() {
 		((*(*(struct {
 			ParentID     AOID
@@ -31464,14 +31565,14 @@ func (obj *AOAttach) deserialize(r io.Reader) {
 			Pos          Vec
 			Rot          [3]float32
 			ForceVisible bool
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Vec", err))
 	}
-	for local373 := range (*(*(struct {
+	for local376 := range (*(*(struct {
 		ParentID     AOID
 		Bone         string
 		Pos          Vec
@@ -31485,7 +31586,7 @@ func (obj *AOAttach) deserialize(r io.Reader) {
 				Pos          Vec
 				Rot          [3]float32
 				ForceVisible bool
-			}))(obj)).Rot)[local373]
+			}))(obj)).Rot)[local376]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
@@ -31508,7 +31609,7 @@ func (obj *AOAttach) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AOPhysOverride) serialize(w io.Writer) {
+func (obj *AOPhysOverride) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Walk, Jump, Gravity float32
@@ -31577,7 +31678,7 @@ func (obj *AOPhysOverride) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOPhysOverride) deserialize(r io.Reader) {
+func (obj *AOPhysOverride) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Walk, Jump, Gravity float32
@@ -31655,26 +31756,26 @@ func (obj *AOPhysOverride) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *aoType) serialize(w io.Writer) {
+func (obj *aoType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *aoType) deserialize(r io.Reader) {
+func (obj *aoType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *NodeMetaField) serialize(w io.Writer) {
+func (obj *NodeMetaField) serialize(w io.Writer, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Field
 			Private bool
-		}))(obj)).Field).serialize(w)
+		}))(obj)).Field).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -31694,12 +31795,12 @@ func (obj *NodeMetaField) serialize(w io.Writer) {
 	}
 }
 
-func (obj *NodeMetaField) deserialize(r io.Reader) {
+func (obj *NodeMetaField) deserialize(r io.Reader, ver uint16) {
 	if err := pcall(func() {
 		((*(*(struct {
 			Field
 			Private bool
-		}))(obj)).Field).deserialize(r)
+		}))(obj)).Field).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -31722,67 +31823,67 @@ func (obj *NodeMetaField) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *MinimapType) serialize(w io.Writer) {
+func (obj *MinimapType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *MinimapType) deserialize(r io.Reader) {
+func (obj *MinimapType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *Param1Type) serialize(w io.Writer) {
+func (obj *Param1Type) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *Param1Type) deserialize(r io.Reader) {
+func (obj *Param1Type) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *Param2Type) serialize(w io.Writer) {
+func (obj *Param2Type) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *Param2Type) deserialize(r io.Reader) {
+func (obj *Param2Type) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *DrawType) serialize(w io.Writer) {
+func (obj *DrawType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *DrawType) deserialize(r io.Reader) {
+func (obj *DrawType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *TileDef) serialize(w io.Writer) {
+func (obj *TileDef) serialize(w io.Writer, ver uint16) {
 	{
-		local374 := uint8(6)
+		local377 := uint8(6)
 		{
-			x := local374
+			x := local377
 			write8(w, uint8(x))
 		}
 	}
@@ -31800,7 +31901,7 @@ func (obj *TileDef) serialize(w io.Writer) {
 
 			//mt:if %s.Flags&TileAlign != 0
 			Align AlignType
-		}))(obj)).Texture).serialize(w)
+		}))(obj)).Texture).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -31821,7 +31922,7 @@ func (obj *TileDef) serialize(w io.Writer) {
 
 			//mt:if %s.Flags&TileAlign != 0
 			Align AlignType
-		}))(obj)).Anim).serialize(w)
+		}))(obj)).Anim).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -31842,7 +31943,7 @@ func (obj *TileDef) serialize(w io.Writer) {
 
 			//mt:if %s.Flags&TileAlign != 0
 			Align AlignType
-		}))(obj)).Flags).serialize(w)
+		}))(obj)).Flags).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -31975,7 +32076,7 @@ func (obj *TileDef) serialize(w io.Writer) {
 
 				//mt:if %s.Flags&TileAlign != 0
 				Align AlignType
-			}))(obj)).Align).serialize(w)
+			}))(obj)).Align).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -31985,16 +32086,16 @@ func (obj *TileDef) serialize(w io.Writer) {
 	}
 }
 
-func (obj *TileDef) deserialize(r io.Reader) {
+func (obj *TileDef) deserialize(r io.Reader, ver uint16) {
 	{
-		var local375 uint8
-		local376 := uint8(6)
+		var local378 uint8
+		local379 := uint8(6)
 		{
-			p := &local375
+			p := &local378
 			*p = read8(r)
 		}
-		if local375 != local376 {
-			chk(fmt.Errorf("const %v: %v", "uint8(6)", local375))
+		if local378 != local379 {
+			chk(fmt.Errorf("const %v: %v", "uint8(6)", local378))
 		}
 	}
 	if err := pcall(func() {
@@ -32011,7 +32112,7 @@ func (obj *TileDef) deserialize(r io.Reader) {
 
 			//mt:if %s.Flags&TileAlign != 0
 			Align AlignType
-		}))(obj)).Texture).deserialize(r)
+		}))(obj)).Texture).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -32032,7 +32133,7 @@ func (obj *TileDef) deserialize(r io.Reader) {
 
 			//mt:if %s.Flags&TileAlign != 0
 			Align AlignType
-		}))(obj)).Anim).deserialize(r)
+		}))(obj)).Anim).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -32053,7 +32154,7 @@ func (obj *TileDef) deserialize(r io.Reader) {
 
 			//mt:if %s.Flags&TileAlign != 0
 			Align AlignType
-		}))(obj)).Flags).deserialize(r)
+		}))(obj)).Flags).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -32186,7 +32287,7 @@ func (obj *TileDef) deserialize(r io.Reader) {
 
 				//mt:if %s.Flags&TileAlign != 0
 				Align AlignType
-			}))(obj)).Align).deserialize(r)
+			}))(obj)).Align).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32196,39 +32297,39 @@ func (obj *TileDef) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *WaveType) serialize(w io.Writer) {
+func (obj *WaveType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *WaveType) deserialize(r io.Reader) {
+func (obj *WaveType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *LiquidType) serialize(w io.Writer) {
+func (obj *LiquidType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *LiquidType) deserialize(r io.Reader) {
+func (obj *LiquidType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *NodeBox) serialize(w io.Writer) {
+func (obj *NodeBox) serialize(w io.Writer, ver uint16) {
 	{
-		local377 := uint8(6)
+		local380 := uint8(6)
 		{
-			x := local377
+			x := local380
 			write8(w, uint8(x))
 		}
 	}
@@ -32245,7 +32346,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 			//mt:if %s.Type == ConnectedBox
 			ConnDirs, DiscoDirs  DirBoxes
 			DiscoAll, DiscoSides []Box
-		}))(obj)).Type).serialize(w)
+		}))(obj)).Type).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -32293,7 +32394,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).WallTop).serialize(w)
+			}))(obj)).WallTop).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32313,7 +32414,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).WallBot).serialize(w)
+			}))(obj)).WallBot).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32333,7 +32434,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).WallSides).serialize(w)
+			}))(obj)).WallSides).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32385,7 +32486,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 			}))(obj)).Fixed)))
 			write16(w, uint16(x))
 		}
-		for local378 := range (*(*(struct {
+		for local381 := range (*(*(struct {
 			Type NodeBoxType
 
 			//mt:if %s.Type == MountedBox
@@ -32411,7 +32512,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 					//mt:if %s.Type == ConnectedBox
 					ConnDirs, DiscoDirs  DirBoxes
 					DiscoAll, DiscoSides []Box
-				}))(obj)).Fixed)[local378]).serialize(w)
+				}))(obj)).Fixed)[local381]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -32446,7 +32547,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).ConnDirs).serialize(w)
+			}))(obj)).ConnDirs).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32466,7 +32567,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).DiscoDirs).serialize(w)
+			}))(obj)).DiscoDirs).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32504,7 +32605,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 			}))(obj)).DiscoAll)))
 			write16(w, uint16(x))
 		}
-		for local379 := range (*(*(struct {
+		for local382 := range (*(*(struct {
 			Type NodeBoxType
 
 			//mt:if %s.Type == MountedBox
@@ -32530,7 +32631,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 					//mt:if %s.Type == ConnectedBox
 					ConnDirs, DiscoDirs  DirBoxes
 					DiscoAll, DiscoSides []Box
-				}))(obj)).DiscoAll)[local379]).serialize(w)
+				}))(obj)).DiscoAll)[local382]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -32569,7 +32670,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 			}))(obj)).DiscoSides)))
 			write16(w, uint16(x))
 		}
-		for local380 := range (*(*(struct {
+		for local383 := range (*(*(struct {
 			Type NodeBoxType
 
 			//mt:if %s.Type == MountedBox
@@ -32595,7 +32696,7 @@ func (obj *NodeBox) serialize(w io.Writer) {
 					//mt:if %s.Type == ConnectedBox
 					ConnDirs, DiscoDirs  DirBoxes
 					DiscoAll, DiscoSides []Box
-				}))(obj)).DiscoSides)[local380]).serialize(w)
+				}))(obj)).DiscoSides)[local383]).serialize(w, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -32606,16 +32707,16 @@ func (obj *NodeBox) serialize(w io.Writer) {
 	}
 }
 
-func (obj *NodeBox) deserialize(r io.Reader) {
+func (obj *NodeBox) deserialize(r io.Reader, ver uint16) {
 	{
-		var local381 uint8
-		local382 := uint8(6)
+		var local384 uint8
+		local385 := uint8(6)
 		{
-			p := &local381
+			p := &local384
 			*p = read8(r)
 		}
-		if local381 != local382 {
-			chk(fmt.Errorf("const %v: %v", "uint8(6)", local381))
+		if local384 != local385 {
+			chk(fmt.Errorf("const %v: %v", "uint8(6)", local384))
 		}
 	}
 	if err := pcall(func() {
@@ -32631,7 +32732,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 			//mt:if %s.Type == ConnectedBox
 			ConnDirs, DiscoDirs  DirBoxes
 			DiscoAll, DiscoSides []Box
-		}))(obj)).Type).deserialize(r)
+		}))(obj)).Type).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -32679,7 +32780,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).WallTop).deserialize(r)
+			}))(obj)).WallTop).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32699,7 +32800,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).WallBot).deserialize(r)
+			}))(obj)).WallBot).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32719,7 +32820,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).WallSides).deserialize(r)
+			}))(obj)).WallSides).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32740,9 +32841,9 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 		ConnDirs, DiscoDirs  DirBoxes
 		DiscoAll, DiscoSides []Box
 	}))(obj)).Type; t == FixedBox || t == LeveledBox || t == ConnectedBox {
-		var local383 uint16
+		var local386 uint16
 		{
-			p := &local383
+			p := &local386
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -32757,8 +32858,8 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 			//mt:if %s.Type == ConnectedBox
 			ConnDirs, DiscoDirs  DirBoxes
 			DiscoAll, DiscoSides []Box
-		}))(obj)).Fixed) = make([]Box, local383)
-		for local384 := range (*(*(struct {
+		}))(obj)).Fixed) = make([]Box, local386)
+		for local387 := range (*(*(struct {
 			Type NodeBoxType
 
 			//mt:if %s.Type == MountedBox
@@ -32784,7 +32885,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 					//mt:if %s.Type == ConnectedBox
 					ConnDirs, DiscoDirs  DirBoxes
 					DiscoAll, DiscoSides []Box
-				}))(obj)).Fixed)[local384]).deserialize(r)
+				}))(obj)).Fixed)[local387]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -32819,7 +32920,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).ConnDirs).deserialize(r)
+			}))(obj)).ConnDirs).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -32839,16 +32940,16 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 				//mt:if %s.Type == ConnectedBox
 				ConnDirs, DiscoDirs  DirBoxes
 				DiscoAll, DiscoSides []Box
-			}))(obj)).DiscoDirs).deserialize(r)
+			}))(obj)).DiscoDirs).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
 			}
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.DirBoxes", err))
 		}
-		var local385 uint16
+		var local388 uint16
 		{
-			p := &local385
+			p := &local388
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -32863,8 +32964,8 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 			//mt:if %s.Type == ConnectedBox
 			ConnDirs, DiscoDirs  DirBoxes
 			DiscoAll, DiscoSides []Box
-		}))(obj)).DiscoAll) = make([]Box, local385)
-		for local386 := range (*(*(struct {
+		}))(obj)).DiscoAll) = make([]Box, local388)
+		for local389 := range (*(*(struct {
 			Type NodeBoxType
 
 			//mt:if %s.Type == MountedBox
@@ -32890,7 +32991,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 					//mt:if %s.Type == ConnectedBox
 					ConnDirs, DiscoDirs  DirBoxes
 					DiscoAll, DiscoSides []Box
-				}))(obj)).DiscoAll)[local386]).deserialize(r)
+				}))(obj)).DiscoAll)[local389]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -32898,9 +32999,9 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 				chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Box", err))
 			}
 		}
-		var local387 uint16
+		var local390 uint16
 		{
-			p := &local387
+			p := &local390
 			*p = read16(r)
 		}
 		((*(*(struct {
@@ -32915,8 +33016,8 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 			//mt:if %s.Type == ConnectedBox
 			ConnDirs, DiscoDirs  DirBoxes
 			DiscoAll, DiscoSides []Box
-		}))(obj)).DiscoSides) = make([]Box, local387)
-		for local388 := range (*(*(struct {
+		}))(obj)).DiscoSides) = make([]Box, local390)
+		for local391 := range (*(*(struct {
 			Type NodeBoxType
 
 			//mt:if %s.Type == MountedBox
@@ -32942,7 +33043,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 					//mt:if %s.Type == ConnectedBox
 					ConnDirs, DiscoDirs  DirBoxes
 					DiscoAll, DiscoSides []Box
-				}))(obj)).DiscoSides)[local388]).deserialize(r)
+				}))(obj)).DiscoSides)[local391]).deserialize(r, ver)
 			}); err != nil {
 				if err == io.EOF {
 					chk(io.EOF)
@@ -32953,7 +33054,7 @@ func (obj *NodeBox) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *SoundDef) serialize(w io.Writer) {
+func (obj *SoundDef) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Name              string
 		Gain, Pitch, Fade float32
@@ -32997,22 +33098,22 @@ func (obj *SoundDef) serialize(w io.Writer) {
 	}
 }
 
-func (obj *SoundDef) deserialize(r io.Reader) {
-	var local389 []uint8
-	var local390 uint16
+func (obj *SoundDef) deserialize(r io.Reader, ver uint16) {
+	var local392 []uint8
+	var local393 uint16
 	{
-		p := &local390
+		p := &local393
 		*p = read16(r)
 	}
-	(local389) = make([]uint8, local390)
+	(local392) = make([]uint8, local393)
 	{
-		_, err := io.ReadFull(r, (local389)[:])
+		_, err := io.ReadFull(r, (local392)[:])
 		chk(err)
 	}
 	((*(*(struct {
 		Name              string
 		Gain, Pitch, Fade float32
-	}))(obj)).Name) = string(local389)
+	}))(obj)).Name) = string(local392)
 	{
 		p := &(*(*(struct {
 			Name              string
@@ -33036,67 +33137,67 @@ func (obj *SoundDef) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AlphaUse) serialize(w io.Writer) {
+func (obj *AlphaUse) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *AlphaUse) deserialize(r io.Reader) {
+func (obj *AlphaUse) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *Keys) serialize(w io.Writer) {
+func (obj *Keys) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint32))(obj)
 		write32(w, uint32(x))
 	}
 }
 
-func (obj *Keys) deserialize(r io.Reader) {
+func (obj *Keys) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint32))(obj)
 		*p = read32(r)
 	}
 }
 
-func (obj *MapBlkFlags) serialize(w io.Writer) {
+func (obj *MapBlkFlags) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *MapBlkFlags) deserialize(r io.Reader) {
+func (obj *MapBlkFlags) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *LitFromBlks) serialize(w io.Writer) {
+func (obj *LitFromBlks) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *LitFromBlks) deserialize(r io.Reader) {
+func (obj *LitFromBlks) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *AOInitData) serialize(w io.Writer) {
+func (obj *AOInitData) serialize(w io.Writer, ver uint16) {
 	{
-		local391 := uint8(1)
+		local394 := uint8(1)
 		{
-			x := local391
+			x := local394
 			write8(w, uint8(x))
 		}
 	}
@@ -33195,7 +33296,7 @@ func (obj *AOInitData) serialize(w io.Writer) {
 
 			// See (de)serialize.fmt.
 			Msgs []AOMsg
-		}))(obj)).ID).serialize(w)
+		}))(obj)).ID).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -33218,14 +33319,14 @@ func (obj *AOInitData) serialize(w io.Writer) {
 
 			// See (de)serialize.fmt.
 			Msgs []AOMsg
-		}))(obj)).Pos).serialize(w)
+		}))(obj)).Pos).serialize(w, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Pos", err))
 	}
-	for local392 := range (*(*(struct {
+	for local395 := range (*(*(struct {
 
 		// For players.
 		Name     string
@@ -33257,7 +33358,7 @@ func (obj *AOInitData) serialize(w io.Writer) {
 
 				// See (de)serialize.fmt.
 				Msgs []AOMsg
-			}))(obj)).Rot)[local392]
+			}))(obj)).Rot)[local395]
 			write32(w, math.Float32bits(x))
 		}
 	}
@@ -33304,7 +33405,7 @@ func (obj *AOInitData) serialize(w io.Writer) {
 			write8(w, uint8(len(x)))
 			for _, msg := range x {
 				var b bytes.Buffer
-				chk(writeAOMsg(&b, msg))
+				chk(writeAOMsg(&b, msg, ver))
 				if b.Len() > math.MaxUint32 {
 					chk(ErrTooLong)
 				}
@@ -33316,27 +33417,27 @@ func (obj *AOInitData) serialize(w io.Writer) {
 	}
 }
 
-func (obj *AOInitData) deserialize(r io.Reader) {
+func (obj *AOInitData) deserialize(r io.Reader, ver uint16) {
 	{
-		var local393 uint8
-		local394 := uint8(1)
+		var local396 uint8
+		local397 := uint8(1)
 		{
-			p := &local393
+			p := &local396
 			*p = read8(r)
 		}
-		if local393 != local394 {
-			chk(fmt.Errorf("const %v: %v", "uint8(1)", local393))
+		if local396 != local397 {
+			chk(fmt.Errorf("const %v: %v", "uint8(1)", local396))
 		}
 	}
-	var local395 []uint8
-	var local396 uint16
+	var local398 []uint8
+	var local399 uint16
 	{
-		p := &local396
+		p := &local399
 		*p = read16(r)
 	}
-	(local395) = make([]uint8, local396)
+	(local398) = make([]uint8, local399)
 	{
-		_, err := io.ReadFull(r, (local395)[:])
+		_, err := io.ReadFull(r, (local398)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -33354,7 +33455,7 @@ func (obj *AOInitData) deserialize(r io.Reader) {
 
 		// See (de)serialize.fmt.
 		Msgs []AOMsg
-	}))(obj)).Name) = string(local395)
+	}))(obj)).Name) = string(local398)
 	{
 		p := &(*(*(struct {
 
@@ -33397,7 +33498,7 @@ func (obj *AOInitData) deserialize(r io.Reader) {
 
 			// See (de)serialize.fmt.
 			Msgs []AOMsg
-		}))(obj)).ID).deserialize(r)
+		}))(obj)).ID).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
@@ -33420,14 +33521,14 @@ func (obj *AOInitData) deserialize(r io.Reader) {
 
 			// See (de)serialize.fmt.
 			Msgs []AOMsg
-		}))(obj)).Pos).deserialize(r)
+		}))(obj)).Pos).deserialize(r, ver)
 	}); err != nil {
 		if err == io.EOF {
 			chk(io.EOF)
 		}
 		chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Pos", err))
 	}
-	for local397 := range (*(*(struct {
+	for local400 := range (*(*(struct {
 
 		// For players.
 		Name     string
@@ -33459,7 +33560,7 @@ func (obj *AOInitData) deserialize(r io.Reader) {
 
 				// See (de)serialize.fmt.
 				Msgs []AOMsg
-			}))(obj)).Rot)[local397]
+			}))(obj)).Rot)[local400]
 			*p = math.Float32frombits(read32(r))
 		}
 	}
@@ -33503,7 +33604,7 @@ func (obj *AOInitData) deserialize(r io.Reader) {
 			*p = make([]AOMsg, read8(r))
 			for i := range *p {
 				r := &io.LimitedReader{R: r, N: int64(read32(r))}
-				msg, err := readAOMsg(r)
+				msg, err := readAOMsg(r, ver)
 				chk(err)
 				(*p)[i] = msg
 				if r.N > 0 {
@@ -33514,21 +33615,21 @@ func (obj *AOInitData) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ItemType) serialize(w io.Writer) {
+func (obj *ItemType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *ItemType) deserialize(r io.Reader) {
+func (obj *ItemType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *ToolCaps) serialize(w io.Writer) {
+func (obj *ToolCaps) serialize(w io.Writer, ver uint16) {
 	if _ = (*(*(struct {
 		//mt:if _ = %s; false
 		NonNil bool
@@ -33573,22 +33674,23 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 		ow := w
 		w := new(bytes.Buffer)
 		/*
-		   if r.N > 0 { (*(*(struct {
-		   	//mt:if _ = %s; false
-		   	NonNil	bool
+			if r.N > 0 { (*(*(struct {
+				//mt:if _ = %s; false
+				NonNil	bool
 
-		   	AttackCooldown	float32
-		   	MaxDropLvl	int16
+				AttackCooldown	float32
+				MaxDropLvl	int16
 
-		   	//mt:len32
-		   	GroupCaps	[]ToolGroupCap
+				//mt:len32
+				GroupCaps	[]ToolGroupCap
 
-		   	//mt:len32
-		   	DmgGroups	[]Group
+				//mt:len32
+				DmgGroups	[]Group
 
-		   	//mt:32tou16
-		   	PunchUses	int32
-		   }))(obj)).NonNil = true}; /**/{
+				//mt:32tou16
+				PunchUses	int32
+			}))(obj)).NonNil = true}; /*
+		*/{
 			if (*(*(struct {
 				//mt:if _ = %s; false
 				NonNil bool
@@ -33606,9 +33708,9 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 				PunchUses int32
 			}))(obj)).NonNil {
 				{
-					local398 := uint8(5)
+					local401 := uint8(5)
 					{
-						x := local398
+						x := local401
 						write8(w, uint8(x))
 					}
 				}
@@ -33687,7 +33789,7 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 					}))(obj)).GroupCaps)))
 					write32(w, uint32(x))
 				}
-				for local399 := range (*(*(struct {
+				for local402 := range (*(*(struct {
 					//mt:if _ = %s; false
 					NonNil bool
 
@@ -33719,7 +33821,7 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 
 							//mt:32tou16
 							PunchUses int32
-						}))(obj)).GroupCaps)[local399]).serialize(w)
+						}))(obj)).GroupCaps)[local402]).serialize(w, ver)
 					}); err != nil {
 						if err == io.EOF {
 							chk(io.EOF)
@@ -33764,7 +33866,7 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 					}))(obj)).DmgGroups)))
 					write32(w, uint32(x))
 				}
-				for local400 := range (*(*(struct {
+				for local403 := range (*(*(struct {
 					//mt:if _ = %s; false
 					NonNil bool
 
@@ -33796,7 +33898,7 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 
 							//mt:32tou16
 							PunchUses int32
-						}))(obj)).DmgGroups)[local400]).serialize(w)
+						}))(obj)).DmgGroups)[local403]).serialize(w, ver)
 					}); err != nil {
 						if err == io.EOF {
 							chk(io.EOF)
@@ -33843,7 +33945,7 @@ func (obj *ToolCaps) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToolCaps) deserialize(r io.Reader) {
+func (obj *ToolCaps) deserialize(r io.Reader, ver uint16) {
 	if _ = (*(*(struct {
 		//mt:if _ = %s; false
 		NonNil bool
@@ -33930,14 +34032,14 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 				PunchUses int32
 			}))(obj)).NonNil {
 				{
-					var local401 uint8
-					local402 := uint8(5)
+					var local404 uint8
+					local405 := uint8(5)
 					{
-						p := &local401
+						p := &local404
 						*p = read8(r)
 					}
-					if local401 != local402 {
-						chk(fmt.Errorf("const %v: %v", "uint8(5)", local401))
+					if local404 != local405 {
+						chk(fmt.Errorf("const %v: %v", "uint8(5)", local404))
 					}
 				}
 				{
@@ -33978,9 +34080,9 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 					}))(obj)).MaxDropLvl
 					*p = int16(read16(r))
 				}
-				var local403 uint32
+				var local406 uint32
 				{
-					p := &local403
+					p := &local406
 					*p = read32(r)
 				}
 				((*(*(struct {
@@ -33998,8 +34100,8 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 
 					//mt:32tou16
 					PunchUses int32
-				}))(obj)).GroupCaps) = make([]ToolGroupCap, local403)
-				for local404 := range (*(*(struct {
+				}))(obj)).GroupCaps) = make([]ToolGroupCap, local406)
+				for local407 := range (*(*(struct {
 					//mt:if _ = %s; false
 					NonNil bool
 
@@ -34031,7 +34133,7 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 
 							//mt:32tou16
 							PunchUses int32
-						}))(obj)).GroupCaps)[local404]).deserialize(r)
+						}))(obj)).GroupCaps)[local407]).deserialize(r, ver)
 					}); err != nil {
 						if err == io.EOF {
 							chk(io.EOF)
@@ -34039,9 +34141,9 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 						chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.ToolGroupCap", err))
 					}
 				}
-				var local405 uint32
+				var local408 uint32
 				{
-					p := &local405
+					p := &local408
 					*p = read32(r)
 				}
 				((*(*(struct {
@@ -34059,8 +34161,8 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 
 					//mt:32tou16
 					PunchUses int32
-				}))(obj)).DmgGroups) = make([]Group, local405)
-				for local406 := range (*(*(struct {
+				}))(obj)).DmgGroups) = make([]Group, local408)
+				for local409 := range (*(*(struct {
 					//mt:if _ = %s; false
 					NonNil bool
 
@@ -34092,7 +34194,7 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 
 							//mt:32tou16
 							PunchUses int32
-						}))(obj)).DmgGroups)[local406]).deserialize(r)
+						}))(obj)).DmgGroups)[local409]).deserialize(r, ver)
 					}); err != nil {
 						if err == io.EOF {
 							chk(io.EOF)
@@ -34100,9 +34202,9 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 						chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Group", err))
 					}
 				}
-				var local407 uint16
+				var local410 uint16
 				{
-					p := &local407
+					p := &local410
 					*p = read16(r)
 				}
 				(*(*(struct {
@@ -34120,7 +34222,7 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 
 					//mt:32tou16
 					PunchUses int32
-				}))(obj)).PunchUses = int32(local407)
+				}))(obj)).PunchUses = int32(local410)
 			}
 		}
 		if r.N > 0 {
@@ -34129,38 +34231,38 @@ func (obj *ToolCaps) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *AnimType) serialize(w io.Writer) {
+func (obj *AnimType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *AnimType) deserialize(r io.Reader) {
+func (obj *AnimType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *HUDType) serialize(w io.Writer) {
+func (obj *HUDType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *HUDType) deserialize(r io.Reader) {
+func (obj *HUDType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *Box) serialize(w io.Writer) {
-	for local408 := range *(*([2]Vec))(obj) {
+func (obj *Box) serialize(w io.Writer, ver uint16) {
+	for local411 := range *(*([2]Vec))(obj) {
 		if err := pcall(func() {
-			((*(*([2]Vec))(obj))[local408]).serialize(w)
+			((*(*([2]Vec))(obj))[local411]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34170,10 +34272,10 @@ func (obj *Box) serialize(w io.Writer) {
 	}
 }
 
-func (obj *Box) deserialize(r io.Reader) {
-	for local409 := range *(*([2]Vec))(obj) {
+func (obj *Box) deserialize(r io.Reader, ver uint16) {
+	for local412 := range *(*([2]Vec))(obj) {
 		if err := pcall(func() {
-			((*(*([2]Vec))(obj))[local409]).deserialize(r)
+			((*(*([2]Vec))(obj))[local412]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34183,49 +34285,49 @@ func (obj *Box) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *TileFlags) serialize(w io.Writer) {
+func (obj *TileFlags) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint16))(obj)
 		write16(w, uint16(x))
 	}
 }
 
-func (obj *TileFlags) deserialize(r io.Reader) {
+func (obj *TileFlags) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint16))(obj)
 		*p = read16(r)
 	}
 }
 
-func (obj *AlignType) serialize(w io.Writer) {
+func (obj *AlignType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *AlignType) deserialize(r io.Reader) {
+func (obj *AlignType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *NodeBoxType) serialize(w io.Writer) {
+func (obj *NodeBoxType) serialize(w io.Writer, ver uint16) {
 	{
 		x := *(*(uint8))(obj)
 		write8(w, uint8(x))
 	}
 }
 
-func (obj *NodeBoxType) deserialize(r io.Reader) {
+func (obj *NodeBoxType) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &*(*(uint8))(obj)
 		*p = read8(r)
 	}
 }
 
-func (obj *DirBoxes) serialize(w io.Writer) {
+func (obj *DirBoxes) serialize(w io.Writer, ver uint16) {
 	if len(((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
@@ -34239,7 +34341,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 		}))(obj)).Top)))
 		write16(w, uint16(x))
 	}
-	for local410 := range (*(*(struct {
+	for local413 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Top {
@@ -34247,7 +34349,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Top)[local410]).serialize(w)
+			}))(obj)).Top)[local413]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34268,7 +34370,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 		}))(obj)).Bot)))
 		write16(w, uint16(x))
 	}
-	for local411 := range (*(*(struct {
+	for local414 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Bot {
@@ -34276,7 +34378,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Bot)[local411]).serialize(w)
+			}))(obj)).Bot)[local414]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34297,7 +34399,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 		}))(obj)).Front)))
 		write16(w, uint16(x))
 	}
-	for local412 := range (*(*(struct {
+	for local415 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Front {
@@ -34305,7 +34407,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Front)[local412]).serialize(w)
+			}))(obj)).Front)[local415]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34326,7 +34428,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 		}))(obj)).Left)))
 		write16(w, uint16(x))
 	}
-	for local413 := range (*(*(struct {
+	for local416 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Left {
@@ -34334,7 +34436,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Left)[local413]).serialize(w)
+			}))(obj)).Left)[local416]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34355,7 +34457,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 		}))(obj)).Back)))
 		write16(w, uint16(x))
 	}
-	for local414 := range (*(*(struct {
+	for local417 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Back {
@@ -34363,7 +34465,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Back)[local414]).serialize(w)
+			}))(obj)).Back)[local417]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34384,7 +34486,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 		}))(obj)).Right)))
 		write16(w, uint16(x))
 	}
-	for local415 := range (*(*(struct {
+	for local418 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Right {
@@ -34392,7 +34494,7 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Right)[local415]).serialize(w)
+			}))(obj)).Right)[local418]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34402,17 +34504,17 @@ func (obj *DirBoxes) serialize(w io.Writer) {
 	}
 }
 
-func (obj *DirBoxes) deserialize(r io.Reader) {
-	var local416 uint16
+func (obj *DirBoxes) deserialize(r io.Reader, ver uint16) {
+	var local419 uint16
 	{
-		p := &local416
+		p := &local419
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
-	}))(obj)).Top) = make([]Box, local416)
-	for local417 := range (*(*(struct {
+	}))(obj)).Top) = make([]Box, local419)
+	for local420 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Top {
@@ -34420,7 +34522,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Top)[local417]).deserialize(r)
+			}))(obj)).Top)[local420]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34428,16 +34530,16 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Box", err))
 		}
 	}
-	var local418 uint16
+	var local421 uint16
 	{
-		p := &local418
+		p := &local421
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
-	}))(obj)).Bot) = make([]Box, local418)
-	for local419 := range (*(*(struct {
+	}))(obj)).Bot) = make([]Box, local421)
+	for local422 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Bot {
@@ -34445,7 +34547,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Bot)[local419]).deserialize(r)
+			}))(obj)).Bot)[local422]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34453,16 +34555,16 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Box", err))
 		}
 	}
-	var local420 uint16
+	var local423 uint16
 	{
-		p := &local420
+		p := &local423
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
-	}))(obj)).Front) = make([]Box, local420)
-	for local421 := range (*(*(struct {
+	}))(obj)).Front) = make([]Box, local423)
+	for local424 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Front {
@@ -34470,7 +34572,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Front)[local421]).deserialize(r)
+			}))(obj)).Front)[local424]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34478,16 +34580,16 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Box", err))
 		}
 	}
-	var local422 uint16
+	var local425 uint16
 	{
-		p := &local422
+		p := &local425
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
-	}))(obj)).Left) = make([]Box, local422)
-	for local423 := range (*(*(struct {
+	}))(obj)).Left) = make([]Box, local425)
+	for local426 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Left {
@@ -34495,7 +34597,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Left)[local423]).deserialize(r)
+			}))(obj)).Left)[local426]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34503,16 +34605,16 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Box", err))
 		}
 	}
-	var local424 uint16
+	var local427 uint16
 	{
-		p := &local424
+		p := &local427
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
-	}))(obj)).Back) = make([]Box, local424)
-	for local425 := range (*(*(struct {
+	}))(obj)).Back) = make([]Box, local427)
+	for local428 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Back {
@@ -34520,7 +34622,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Back)[local425]).deserialize(r)
+			}))(obj)).Back)[local428]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34528,16 +34630,16 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			chk(fmt.Errorf("%s: %w", "github.com/anon55555/mt.Box", err))
 		}
 	}
-	var local426 uint16
+	var local429 uint16
 	{
-		p := &local426
+		p := &local429
 		*p = read16(r)
 	}
 	((*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
-	}))(obj)).Right) = make([]Box, local426)
-	for local427 := range (*(*(struct {
+	}))(obj)).Right) = make([]Box, local429)
+	for local430 := range (*(*(struct {
 		Top, Bot                 []Box
 		Front, Left, Back, Right []Box
 	}))(obj)).Right {
@@ -34545,7 +34647,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 			(((*(*(struct {
 				Top, Bot                 []Box
 				Front, Left, Back, Right []Box
-			}))(obj)).Right)[local427]).deserialize(r)
+			}))(obj)).Right)[local430]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34555,7 +34657,7 @@ func (obj *DirBoxes) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *ToolGroupCap) serialize(w io.Writer) {
+func (obj *ToolGroupCap) serialize(w io.Writer, ver uint16) {
 	if len(([]byte((*(*(struct {
 		Name string
 
@@ -34652,7 +34754,7 @@ func (obj *ToolGroupCap) serialize(w io.Writer) {
 		}))(obj)).Times)))
 		write32(w, uint32(x))
 	}
-	for local428 := range (*(*(struct {
+	for local431 := range (*(*(struct {
 		Name string
 
 		//mt:32to16
@@ -34674,7 +34776,7 @@ func (obj *ToolGroupCap) serialize(w io.Writer) {
 
 				//mt:len32
 				Times []DigTime
-			}))(obj)).Times)[local428]).serialize(w)
+			}))(obj)).Times)[local431]).serialize(w, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34684,16 +34786,16 @@ func (obj *ToolGroupCap) serialize(w io.Writer) {
 	}
 }
 
-func (obj *ToolGroupCap) deserialize(r io.Reader) {
-	var local429 []uint8
-	var local430 uint16
+func (obj *ToolGroupCap) deserialize(r io.Reader, ver uint16) {
+	var local432 []uint8
+	var local433 uint16
 	{
-		p := &local430
+		p := &local433
 		*p = read16(r)
 	}
-	(local429) = make([]uint8, local430)
+	(local432) = make([]uint8, local433)
 	{
-		_, err := io.ReadFull(r, (local429)[:])
+		_, err := io.ReadFull(r, (local432)[:])
 		chk(err)
 	}
 	((*(*(struct {
@@ -34706,10 +34808,10 @@ func (obj *ToolGroupCap) deserialize(r io.Reader) {
 
 		//mt:len32
 		Times []DigTime
-	}))(obj)).Name) = string(local429)
-	var local431 int16
+	}))(obj)).Name) = string(local432)
+	var local434 int16
 	{
-		p := &local431
+		p := &local434
 		*p = int16(read16(r))
 	}
 	(*(*(struct {
@@ -34722,7 +34824,7 @@ func (obj *ToolGroupCap) deserialize(r io.Reader) {
 
 		//mt:len32
 		Times []DigTime
-	}))(obj)).Uses = int32(local431)
+	}))(obj)).Uses = int32(local434)
 	{
 		p := &(*(*(struct {
 			Name string
@@ -34737,9 +34839,9 @@ func (obj *ToolGroupCap) deserialize(r io.Reader) {
 		}))(obj)).MaxLvl
 		*p = int16(read16(r))
 	}
-	var local432 uint32
+	var local435 uint32
 	{
-		p := &local432
+		p := &local435
 		*p = read32(r)
 	}
 	((*(*(struct {
@@ -34752,8 +34854,8 @@ func (obj *ToolGroupCap) deserialize(r io.Reader) {
 
 		//mt:len32
 		Times []DigTime
-	}))(obj)).Times) = make([]DigTime, local432)
-	for local433 := range (*(*(struct {
+	}))(obj)).Times) = make([]DigTime, local435)
+	for local436 := range (*(*(struct {
 		Name string
 
 		//mt:32to16
@@ -34775,7 +34877,7 @@ func (obj *ToolGroupCap) deserialize(r io.Reader) {
 
 				//mt:len32
 				Times []DigTime
-			}))(obj)).Times)[local433]).deserialize(r)
+			}))(obj)).Times)[local436]).deserialize(r, ver)
 		}); err != nil {
 			if err == io.EOF {
 				chk(io.EOF)
@@ -34785,7 +34887,7 @@ func (obj *ToolGroupCap) deserialize(r io.Reader) {
 	}
 }
 
-func (obj *DigTime) serialize(w io.Writer) {
+func (obj *DigTime) serialize(w io.Writer, ver uint16) {
 	{
 		x := (*(*(struct {
 			Rating int16
@@ -34802,7 +34904,7 @@ func (obj *DigTime) serialize(w io.Writer) {
 	}
 }
 
-func (obj *DigTime) deserialize(r io.Reader) {
+func (obj *DigTime) deserialize(r io.Reader, ver uint16) {
 	{
 		p := &(*(*(struct {
 			Rating int16
@@ -0,0 +1,42 @@
+package server
+
+import "sync"
+
+// A CredStore stores the passwords of players.
+// Passwords are stored like in auth.txt,
+// i.e. as encoded SRP verifiers or legacy passwords.
+type CredStore interface {
+	// Passwd returns the password of the player name.
+	// ok is false if the player does not exist yet.
+	Passwd(name string) (passwd string, ok bool, err error)
+
+	// SetPasswd sets the password of the player name,
+	// creating the player if it does not exist yet.
+	SetPasswd(name, passwd string) error
+}
+
+// A MapCreds is a CredStore that keeps passwords in memory.
+// The zero value is an empty MapCreds.
+type MapCreds struct {
+	mu      sync.Mutex
+	passwds map[string]string
+}
+
+func (mc *MapCreds) Passwd(name string) (string, bool, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	passwd, ok := mc.passwds[name]
+	return passwd, ok, nil
+}
+
+func (mc *MapCreds) SetPasswd(name, passwd string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.passwds == nil {
+		mc.passwds = make(map[string]string)
+	}
+	mc.passwds[name] = passwd
+	return nil
+}
@@ -0,0 +1,448 @@
+// Package server implements the server side of clients joining.
+package server
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anon55555/mt"
+	"github.com/anon55555/mt/auth"
+	"github.com/anon55555/mt/rudp"
+)
+
+// DefaultTimeout is the default time limit for joining.
+const DefaultTimeout = 10 * time.Second
+
+// MaxNameLen is the maximum length of player names.
+const MaxNameLen = 20
+
+// NameChars are the characters allowed in player names.
+const NameChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
+
+// A KickError is returned by Join if it kicked the client.
+type KickError struct {
+	mt.ToCltKick
+}
+
+func (e *KickError) Error() string { return "kicked client: " + e.ToCltKick.String() }
+
+func kick(reason mt.KickReason) error {
+	return &KickError{mt.ToCltKick{Reason: reason}}
+}
+
+// A Server takes clients through joining.
+// Its fields must not be changed after it is first used.
+type Server struct {
+	// Creds stores the passwords of players.
+	Creds CredStore
+
+	// If NoEmptyPasswd is true, new players are kicked
+	// if they do not set a password.
+	NoEmptyPasswd bool
+
+	// AcceptAuth is sent after a client authenticated.
+	// If its SudoAuthMethods are zero, mt.SRP is used.
+	AcceptAuth mt.ToCltAcceptAuth
+
+	ItemDefs []mt.ItemDef
+	Aliases  []struct{ Alias, Orig string }
+	NodeDefs []mt.NodeDef
+
+	// Media maps the names of media files to their contents.
+	// MediaURL is the remote media server announced to clients.
+	Media    map[string][]byte
+	MediaURL string
+
+	Movement mt.ToCltMovement
+	CSM      mt.ToCltCSMRestrictionFlags
+
+	// Privs returns the privileges of the player name.
+	// If Privs is nil, players have no privileges.
+	Privs func(name string) []string
+
+	// Timeout limits the time joining takes.
+	// If it is zero, DefaultTimeout is used.
+	Timeout time.Duration
+
+	// JoinErr, if not nil, is called by Serve
+	// for each client that failed to join.
+	JoinErr func(p mt.Peer, err error)
+
+	announceOnce sync.Once
+	announce     mt.ToCltAnnounceMedia
+
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+// A Session is a Peer that has joined.
+type Session struct {
+	mt.Peer
+
+	Name string
+
+	SerializeVer uint8
+
+	// Lang is the language from ToSrvInit2.
+	Lang string
+
+	// Version is the client's ToSrvCltReady.
+	Version mt.ToSrvCltReady
+
+	mu      sync.Mutex
+	pending []mt.Pkt
+}
+
+// Serve accepts clients from l and calls handle in a new goroutine
+// for each one that joined. It returns when l is closed.
+func (srv *Server) Serve(l mt.Listener, handle func(*Session)) error {
+	for {
+		p, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			continue
+		}
+
+		go func() {
+			s, err := srv.Join(context.Background(), p)
+			if err != nil {
+				if srv.JoinErr != nil {
+					srv.JoinErr(p, err)
+				}
+				return
+			}
+			handle(s)
+		}()
+	}
+}
+
+// Join takes p through joining.
+// If joining fails, p is closed, after sending a ToCltKick if appropriate.
+func (srv *Server) Join(ctx context.Context, p mt.Peer) (*Session, error) {
+	timeout := srv.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := &Session{Peer: p}
+	if err := srv.join(ctx, s); err != nil {
+		var k *KickError
+		if errors.As(err, &k) {
+			if ack, err := p.SendCmd(&k.ToCltKick); err == nil {
+				select {
+				case <-ack:
+				case <-ctx.Done():
+				}
+			}
+		}
+
+		p.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (srv *Server) join(ctx context.Context, s *Session) error {
+	var init *mt.ToSrvInit
+	if err := s.await(ctx, func(cmd mt.Cmd) (bool, error) {
+		init, _ = cmd.(*mt.ToSrvInit)
+		return init != nil, nil
+	}); err != nil {
+		return err
+	}
+
+	// Use the highest protocol version both sides support.
+	ver := init.MaxProtoVer
+	if ver > mt.MaxProtoVer {
+		ver = mt.MaxProtoVer
+	}
+	if init.SerializeVer < mt.SerializeVer ||
+		ver < init.MinProtoVer || ver < mt.MinProtoVer {
+		return kick(mt.UnsupportedVer)
+	}
+	s.SerializeVer = mt.SerializeVer
+
+	if err := checkName(init.PlayerName); err != nil {
+		return err
+	}
+	s.Name = init.PlayerName
+
+	if !srv.reserve(s.Name) {
+		return kick(mt.AlreadyConnected)
+	}
+	go func() {
+		<-s.Closed()
+		srv.release(s.Name)
+	}()
+
+	if err := srv.auth(ctx, s, ver); err != nil {
+		return err
+	}
+
+	accept := srv.AcceptAuth
+	if accept.SudoAuthMethods == 0 {
+		accept.SudoAuthMethods = mt.SRP
+	}
+	if _, err := s.SendCmd(&accept); err != nil {
+		return err
+	}
+
+	var init2 *mt.ToSrvInit2
+	if err := s.await(ctx, func(cmd mt.Cmd) (bool, error) {
+		init2, _ = cmd.(*mt.ToSrvInit2)
+		return init2 != nil, nil
+	}); err != nil {
+		return err
+	}
+	s.Lang = init2.Lang
+
+	srv.announceOnce.Do(srv.initAnnounce)
+	for _, cmd := range []mt.Cmd{
+		&mt.ToCltItemDefs{Defs: srv.ItemDefs, Aliases: srv.Aliases},
+		&mt.ToCltNodeDefs{Defs: srv.NodeDefs},
+		&srv.announce,
+		&srv.Movement,
+		&srv.CSM,
+	} {
+		if _, err := s.SendCmd(cmd); err != nil {
+			return err
+		}
+	}
+
+	if err := s.await(ctx, func(cmd mt.Cmd) (bool, error) {
+		switch cmd := cmd.(type) {
+		case *mt.ToSrvReqMedia:
+			_, err := s.SendCmd(srv.media(cmd.Filenames))
+			return false, err
+		case *mt.ToSrvCltReady:
+			s.Version = *cmd
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return err
+	}
+
+	var privs []string
+	if srv.Privs != nil {
+		privs = srv.Privs(s.Name)
+	}
+	_, err := s.SendCmd(&mt.ToCltPrivs{Privs: privs})
+	return err
+}
+
+func checkName(name string) error {
+	if name == "" || len(name) > MaxNameLen {
+		return kick(mt.BadName)
+	}
+	for _, r := range name {
+		if !strings.ContainsRune(NameChars, r) {
+			return kick(mt.BadNameChars)
+		}
+	}
+
+	// Minetest reserves this name for singleplayer mode.
+	if strings.EqualFold(name, "singleplayer") {
+		return kick(mt.BadName)
+	}
+
+	return nil
+}
+
+// reserve reports whether no other client is using name
+// and reserves it if so.
+func (srv *Server) reserve(name string) bool {
+	name = strings.ToLower(name)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if srv.names[name] {
+		return false
+	}
+	if srv.names == nil {
+		srv.names = make(map[string]bool)
+	}
+	srv.names[name] = true
+	return true
+}
+
+func (srv *Server) release(name string) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	delete(srv.names, strings.ToLower(name))
+}
+
+func (srv *Server) auth(ctx context.Context, s *Session, ver uint16) error {
+	passwd, exists, err := srv.Creds.Passwd(s.Name)
+	if err != nil {
+		return err
+	}
+
+	var methods mt.AuthMethods
+	switch {
+	case !exists:
+		methods = mt.FirstSRP
+	case auth.IsVerifier(passwd):
+		methods = mt.SRP
+	default:
+		methods = mt.LegacyPasswd
+	}
+
+	if _, err := s.SendCmd(&mt.ToCltHello{
+		SerializeVer: s.SerializeVer,
+		ProtoVer:     ver,
+		AuthMethods:  methods,
+		Username:     s.Name,
+	}); err != nil {
+		return err
+	}
+
+	var srp *auth.Server
+	return s.await(ctx, func(cmd mt.Cmd) (bool, error) {
+		switch cmd := cmd.(type) {
+		case *mt.ToSrvFirstSRP:
+			if methods != mt.FirstSRP {
+				return false, kick(mt.UnexpectedData)
+			}
+			if cmd.EmptyPasswd && srv.NoEmptyPasswd {
+				return false, kick(mt.EmptyPasswd)
+			}
+			return true, srv.Creds.SetPasswd(s.Name, auth.EncodeVerifier(cmd.Salt, cmd.Verifier))
+		case *mt.ToSrvSRPBytesA:
+			if methods == mt.FirstSRP || srp != nil {
+				return false, kick(mt.UnexpectedData)
+			}
+
+			var saltB *mt.ToCltSRPBytesSaltB
+			var err error
+			if srp, saltB, err = auth.ServeSRP(s.Name, passwd, cmd); err != nil {
+				return false, kick(mt.UnexpectedData)
+			}
+			_, err = s.SendCmd(saltB)
+			return false, err
+		case *mt.ToSrvSRPBytesM:
+			if srp == nil {
+				return false, kick(mt.UnexpectedData)
+			}
+			if !srp.Verify(cmd.M) {
+				return false, kick(mt.WrongPasswd)
+			}
+			return true, nil
+		}
+		return false, nil
+	})
+}
+
+func (srv *Server) initAnnounce() {
+	srv.announce.URL = srv.MediaURL
+	for name, data := range srv.Media {
+		sum := sha1.Sum(data)
+		srv.announce.Files = append(srv.announce.Files, struct {
+			Name       string
+			Base64SHA1 string
+		}{name, base64.StdEncoding.EncodeToString(sum[:])})
+	}
+	sort.Slice(srv.announce.Files, func(i, j int) bool {
+		return srv.announce.Files[i].Name < srv.announce.Files[j].Name
+	})
+}
+
+// media returns the ToCltMedia containing the known files of names.
+func (srv *Server) media(names []string) *mt.ToCltMedia {
+	cmd := &mt.ToCltMedia{N: 1}
+	for _, name := range names {
+		if data, ok := srv.Media[name]; ok {
+			cmd.Files = append(cmd.Files, struct {
+				Name string
+				Data []byte
+			}{name, data})
+		}
+	}
+	return cmd
+}
+
+// await handles commands until done returns true or an error.
+func (s *Session) await(ctx context.Context, done func(mt.Cmd) (bool, error)) error {
+	for {
+		cmd, err := s.next(ctx)
+		if err != nil {
+			return err
+		}
+		if ok, err := done(cmd); ok || err != nil {
+			return err
+		}
+	}
+}
+
+// next returns the next command relevant to joining.
+// Other packets are queued for Recv.
+func (s *Session) next(ctx context.Context) (mt.Cmd, error) {
+	for {
+		pkt, err := s.Peer.RecvContext(ctx)
+		if err != nil {
+			var trailing rudp.TrailingDataError
+			if pkt.Cmd == nil || !errors.As(err, &trailing) {
+				if errors.Is(err, net.ErrClosed) {
+					if why := s.WhyClosed(); why != nil {
+						return nil, why
+					}
+				}
+				return nil, err
+			}
+		}
+
+		switch cmd := pkt.Cmd.(type) {
+		case *mt.ToSrvNil:
+			continue
+		case *mt.ToSrvInit:
+			// ToSrvInit is resent until ToCltHello arrives.
+			if s.Name != "" {
+				continue
+			}
+			return cmd, nil
+		case *mt.ToSrvFirstSRP,
+			*mt.ToSrvSRPBytesA,
+			*mt.ToSrvSRPBytesM,
+			*mt.ToSrvInit2,
+			*mt.ToSrvReqMedia,
+			*mt.ToSrvCltReady:
+			return cmd, nil
+		}
+
+		s.mu.Lock()
+		s.pending = append(s.pending, pkt)
+		s.mu.Unlock()
+	}
+}
+
+func (s *Session) Recv() (mt.Pkt, error) {
+	return s.RecvContext(context.Background())
+}
+
+// RecvContext is like mt.Peer.RecvContext but first returns
+// the packets that were received while joining.
+func (s *Session) RecvContext(ctx context.Context) (mt.Pkt, error) {
+	s.mu.Lock()
+	if len(s.pending) > 0 {
+		pkt := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+		return pkt, nil
+	}
+	s.mu.Unlock()
+
+	return s.Peer.RecvContext(ctx)
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anon55555/mt"
+	"github.com/anon55555/mt/auth"
+	"github.com/anon55555/mt/client"
+	"github.com/anon55555/mt/rudp"
+)
+
+func serve(t *testing.T, srv *Server) (dial func() mt.Peer) {
+	rl, rdial := rudp.Pipe(rudp.Config{}, rudp.Config{}, rudp.PipeConfig{})
+	l := mt.Listener{Listener: rl}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.Serve(l, func(s *Session) {})
+	}()
+	t.Cleanup(func() {
+		l.Close()
+		<-done
+	})
+
+	return func() mt.Peer { return mt.NewPeer(rdial()) }
+}
+
+func TestJoin(t *testing.T) {
+	srv := &Server{
+		Creds:      new(MapCreds),
+		AcceptAuth: mt.ToCltAcceptAuth{MapSeed: 42},
+		ItemDefs:   []mt.ItemDef{{Name: "default:stone"}},
+		NodeDefs:   []mt.NodeDef{{Param0: 1, Name: "default:stone"}},
+		Media:      map[string][]byte{"a.png": []byte("a"), "b.png": []byte("b")},
+		Movement:   mt.ToCltMovement{Gravity: 9.81},
+		Privs:      func(string) []string { return []string{"interact"} },
+	}
+	dial := serve(t, srv)
+
+	join := func(name, pass string, opts *client.Opts) (*client.Session, error) {
+		return client.Join(context.Background(), dial(), name, pass, opts)
+	}
+
+	// New player.
+	s, err := join("Alice", "secret", &client.Opts{
+		ReqMedia: func(*mt.ToCltAnnounceMedia) []string { return []string{"b.png"} },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.AcceptAuth.MapSeed != 42 || s.NodeDef(1) == nil || s.ItemDef("default:stone") == nil {
+		t.Error("missing ToCltAcceptAuth or definitions")
+	}
+	if len(s.AnnouncedMedia.Files) != 2 || !bytes.Equal(s.Media["b.png"], []byte("b")) || len(s.Media) != 1 {
+		t.Errorf("media: got %v, %q", s.AnnouncedMedia, s.Media)
+	}
+	if s.Movement.Gravity != 9.81 || !s.Privs["interact"] {
+		t.Errorf("got %v, %v", s.Movement, s.Privs)
+	}
+	if s.ProtoVer() != mt.MaxProtoVer {
+		t.Errorf("ProtoVer: got %d, want %d", s.ProtoVer(), mt.MaxProtoVer)
+	}
+
+	if _, err := join("alice", "secret", nil); !errors.Is(err, client.ErrAlreadyConnected) {
+		t.Errorf("second join: got %v, want ErrAlreadyConnected", err)
+	}
+
+	s.CloseGracefully(context.Background())
+	<-s.Closed()
+
+	// The name is released when the server notices the disconnect.
+	for i := 0; ; i++ {
+		s, err = join("Alice", "secret", nil)
+		if !errors.Is(err, client.ErrAlreadyConnected) || i == 10 {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	if _, err := join("Alice", "wrong", nil); !errors.Is(err, client.ErrWrongPasswd) {
+		t.Errorf("wrong password: got %v, want ErrWrongPasswd", err)
+	}
+}
+
+func TestJoinLegacy(t *testing.T) {
+	creds := new(MapCreds)
+	creds.SetPasswd("bob", auth.LegacyPasswd("bob", "secret"))
+	dial := serve(t, &Server{Creds: creds})
+
+	s, err := client.Join(context.Background(), dial(), "bob", "secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+}
+
+func TestJoinBadName(t *testing.T) {
+	dial := serve(t, &Server{Creds: new(MapCreds)})
+
+	for _, tc := range []struct {
+		name string
+		want error
+	}{
+		{"", client.ErrBadName},
+		{"singleplayer", client.ErrBadName},
+		{"abcdefghijklmnopqrstu", client.ErrBadName},
+		{"bad name", client.ErrBadNameChars},
+	} {
+		_, err := client.Join(context.Background(), dial(), tc.name, "", nil)
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%q: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestJoinUnsupportedVer(t *testing.T) {
+	dial := serve(t, &Server{Creds: new(MapCreds)})
+
+	p := dial()
+	defer p.Close()
+
+	p.SendCmd(&mt.ToSrvNil{})
+	p.SendCmd(&mt.ToSrvInit{
+		SerializeVer: mt.SerializeVer,
+		MinProtoVer:  mt.MaxProtoVer + 1,
+		MaxProtoVer:  mt.MaxProtoVer + 1,
+		PlayerName:   "alice",
+	})
+
+	pkt, err := p.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kick, ok := pkt.Cmd.(*mt.ToCltKick); !ok || kick.Reason != mt.UnsupportedVer {
+		t.Errorf("got %#v, want UnsupportedVer kick", pkt.Cmd)
+	}
+}
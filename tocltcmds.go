@@ -177,14 +177,23 @@ type ToCltAddPlayerVel struct {
 }
 
 // ToCltMediaPush is sent when a media file is dynamically added.
+// Since protocol version 40, the client requests the file
+// using ToSrvReqMedia if it does not have it
+// and then replies with ToSrvHaveMedia.
 type ToCltMediaPush struct {
 	//mt:const uint16(sha1.Size)
 	SHA1        [sha1.Size]byte
 	Filename    string
 	ShouldCache bool
 
+	//mt:if ver >= 40
+	CallbackToken uint32
+	//mt:end
+
+	//mt:if ver < 40
 	//mt:len32
 	Data []byte
+	//mt:end
 }
 
 // ToCltChatMsg tells the client that is has received a chat message.
@@ -744,7 +753,7 @@ type ToCltMinimapModes struct {
 
 var _ serializer = (*ToCltMinimapModes)(nil)
 
-func (cmd *ToCltMinimapModes) serialize(w io.Writer) {
+func (cmd *ToCltMinimapModes) serialize(w io.Writer, ver uint16) {
 	buf := make([]byte, 4)
 	if len(cmd.Modes) > math.MaxUint16 {
 		chk(ErrTooLong)
@@ -754,20 +763,20 @@ func (cmd *ToCltMinimapModes) serialize(w io.Writer) {
 	_, err := w.Write(buf)
 	chk(err)
 	for i := range cmd.Modes {
-		chk(serialize(w, &cmd.Modes[i]))
+		chk(serialize(w, &cmd.Modes[i], ver))
 	}
 }
 
 var _ deserializer = (*ToCltMinimapModes)(nil)
 
-func (cmd *ToCltMinimapModes) deserialize(r io.Reader) {
+func (cmd *ToCltMinimapModes) deserialize(r io.Reader, ver uint16) {
 	buf := make([]byte, 4)
 	_, err := io.ReadFull(r, buf)
 	chk(err)
 	cmd.Modes = make([]MinimapMode, be.Uint16(buf[0:2]))
 	cmd.Current = be.Uint16(buf[2:4])
 	for i := range cmd.Modes {
-		chk(deserialize(r, &cmd.Modes[i]))
+		chk(deserialize(r, &cmd.Modes[i], ver))
 	}
 }
 
@@ -135,6 +135,14 @@ type ToSrvReqMedia struct {
 	Filenames []string
 }
 
+// ToSrvHaveMedia tells the server that the client has the media files
+// pushed with ToCltMediaPush using the callback tokens.
+// It was added in protocol version 40.
+type ToSrvHaveMedia struct {
+	//mt:len8
+	Tokens []uint32
+}
+
 type ToSrvCltReady struct {
 	// Version information.
 	Major, Minor, Patch uint8
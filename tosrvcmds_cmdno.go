@@ -22,6 +22,7 @@ func (*ToSrvNodeMetaFields) toSrvCmdNo() uint16 { return 59 }
 func (*ToSrvInvFields) toSrvCmdNo() uint16      { return 60 }
 func (*ToSrvReqMedia) toSrvCmdNo() uint16       { return 64 }
 func (*ToSrvCltReady) toSrvCmdNo() uint16       { return 67 }
+func (*ToSrvHaveMedia) toSrvCmdNo() uint16      { return 68 }
 func (*ToSrvFirstSRP) toSrvCmdNo() uint16       { return 80 }
 func (*ToSrvSRPBytesA) toSrvCmdNo() uint16      { return 81 }
 func (*ToSrvSRPBytesM) toSrvCmdNo() uint16      { return 82 }
@@ -47,7 +48,13 @@ var newToSrvCmd = map[uint16]func() Cmd{
 	60: func() Cmd { return new(ToSrvInvFields) },
 	64: func() Cmd { return new(ToSrvReqMedia) },
 	67: func() Cmd { return new(ToSrvCltReady) },
+	68: func() Cmd { return new(ToSrvHaveMedia) },
 	80: func() Cmd { return new(ToSrvFirstSRP) },
 	81: func() Cmd { return new(ToSrvSRPBytesA) },
 	82: func() Cmd { return new(ToSrvSRPBytesM) },
 }
+
+// toSrvCmdMinVer maps cmd numbers to the protocol versions that added them.
+var toSrvCmdMinVer = map[uint16]uint16{
+	68: 40,
+}
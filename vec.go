@@ -18,3 +18,11 @@ func (v Vec) Sub(w Vec) Vec {
 	}
 	return v
 }
+
+// Mul returns v scaled by s.
+func (v Vec) Mul(s float32) Vec {
+	for i := range v {
+		v[i] *= s
+	}
+	return v
+}
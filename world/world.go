@@ -0,0 +1,276 @@
+// Package world implements a client-side cache of the map
+// built from the Cmds a server sends.
+package world
+
+import (
+	"math"
+	"sync"
+
+	"github.com/anon55555/mt"
+)
+
+// EvictMargin is how many MapBlks beyond PlayerPos.WantedRange
+// a MapBlk may be before Evict unloads it.
+// It keeps MapBlks at the edge of the range from being
+// unloaded and sent again as the player moves back and forth.
+const EvictMargin = 2
+
+// A World holds the MapBlks a client has received
+// and applies the changes the server sends to them.
+// It is safe for concurrent use.
+// The zero value is an empty World.
+type World struct {
+	// OnEvent, if not nil, is called with every change to the World
+	// after it has been made, in the order the changes were made,
+	// even if they were made by concurrent calls.
+	// It must not call methods of the World because they may be blocked
+	// until OnEvent returns.
+	// It must not be changed once the World is used.
+	OnEvent func(Event)
+
+	mu   sync.RWMutex
+	blks map[[3]int16]*mt.MapBlk
+
+	emitMu sync.Mutex
+}
+
+// An Event describes a change to a World.
+type Event interface {
+	event()
+}
+
+// A BlkLoaded Event means a MapBlk has been received,
+// possibly replacing the one at the same position.
+type BlkLoaded struct {
+	Blkpos [3]int16
+}
+
+// A BlkUnloaded Event means a MapBlk has been evicted.
+type BlkUnloaded struct {
+	Blkpos [3]int16
+}
+
+// A NodeChanged Event means a node has been added or removed.
+type NodeChanged struct {
+	Pos      [3]int16
+	Old, New mt.Node
+}
+
+// A MetaChanged Event means the NodeMeta of a node has changed.
+// Meta is nil if it has been removed.
+type MetaChanged struct {
+	Pos  [3]int16
+	Meta *mt.NodeMeta
+}
+
+func (BlkLoaded) event()   {}
+func (BlkUnloaded) event() {}
+func (NodeChanged) event() {}
+func (MetaChanged) event() {}
+
+// Apply applies cmd to w and returns the Cmd acknowledging it, if any.
+// Only ToCltBlkData is acknowledged, by ToSrvGotBlks.
+// Cmds other than ToCltBlkData, ToCltAddNode, ToCltRemoveNode
+// and ToCltNodeMetasChanged are ignored,
+// as are changes to MapBlks that are not loaded.
+func (w *World) Apply(cmd mt.Cmd) (ack mt.Cmd) {
+	var evs []Event
+
+	w.mu.Lock()
+	switch cmd := cmd.(type) {
+	case *mt.ToCltBlkData:
+		blk := cmd.Blk
+		if blk.NodeMetas != nil {
+			blk.NodeMetas = make(map[uint16]*mt.NodeMeta, len(cmd.Blk.NodeMetas))
+			for i, meta := range cmd.Blk.NodeMetas {
+				blk.NodeMetas[i] = meta
+			}
+		}
+
+		if w.blks == nil {
+			w.blks = make(map[[3]int16]*mt.MapBlk)
+		}
+		w.blks[cmd.Blkpos] = &blk
+
+		evs = append(evs, BlkLoaded{cmd.Blkpos})
+		ack = &mt.ToSrvGotBlks{Blks: [][3]int16{cmd.Blkpos}}
+	case *mt.ToCltAddNode:
+		evs = w.setNode(evs, cmd.Pos, cmd.Node, cmd.KeepMeta)
+	case *mt.ToCltRemoveNode:
+		evs = w.setNode(evs, cmd.Pos, mt.Node{Param0: mt.Air}, false)
+	case *mt.ToCltNodeMetasChanged:
+		for pos, meta := range cmd.Changed {
+			if isEmpty(meta) {
+				meta = nil
+			}
+			evs = w.setMeta(evs, pos, meta)
+		}
+	}
+	w.unlockEmit(evs)
+	return
+}
+
+func (w *World) setNode(evs []Event, pos [3]int16, n mt.Node, keepMeta bool) []Event {
+	blkpos, i := mt.Pos2Blkpos(pos)
+	blk, ok := w.blks[blkpos]
+	if !ok {
+		return evs
+	}
+
+	old := mt.Node{Param0: blk.Param0[i], Param1: blk.Param1[i], Param2: blk.Param2[i]}
+	blk.Param0[i], blk.Param1[i], blk.Param2[i] = n.Param0, n.Param1, n.Param2
+	evs = append(evs, NodeChanged{pos, old, n})
+
+	if !keepMeta {
+		evs = w.setMeta(evs, pos, nil)
+	}
+	return evs
+}
+
+func (w *World) setMeta(evs []Event, pos [3]int16, meta *mt.NodeMeta) []Event {
+	blkpos, i := mt.Pos2Blkpos(pos)
+	blk, ok := w.blks[blkpos]
+	if !ok {
+		return evs
+	}
+
+	if meta == nil {
+		if _, ok := blk.NodeMetas[i]; !ok {
+			return evs
+		}
+		delete(blk.NodeMetas, i)
+	} else {
+		if blk.NodeMetas == nil {
+			blk.NodeMetas = make(map[uint16]*mt.NodeMeta)
+		}
+		blk.NodeMetas[i] = meta
+	}
+
+	return append(evs, MetaChanged{pos, meta})
+}
+
+// isEmpty reports whether meta has no fields and no inventory,
+// which is how the server removes NodeMetas.
+func isEmpty(meta *mt.NodeMeta) bool {
+	return meta == nil || len(meta.Fields) == 0 && len(meta.Inv) == 0
+}
+
+// unlockEmit unlocks w.mu and calls OnEvent with evs.
+// emitMu is locked before w.mu is unlocked
+// so that the next changes' events are delivered after evs.
+func (w *World) unlockEmit(evs []Event) {
+	if w.OnEvent == nil {
+		w.mu.Unlock()
+		return
+	}
+
+	w.emitMu.Lock()
+	defer w.emitMu.Unlock()
+	w.mu.Unlock()
+
+	for _, ev := range evs {
+		w.OnEvent(ev)
+	}
+}
+
+// Evict unloads the MapBlks that are more than
+// pp.WantedRange + EvictMargin MapBlks away from the player at pp.
+// It returns the ToSrvDeletedBlks telling the server about it,
+// which are split up because each holds at most 255 Blkposes.
+func (w *World) Evict(pp mt.PlayerPos) []*mt.ToSrvDeletedBlks {
+	center, _ := mt.Pos2Blkpos(pp.Pos().Int())
+	r := float64(pp.WantedRange) + EvictMargin
+
+	var (
+		evs  []Event
+		acks []*mt.ToSrvDeletedBlks
+		ack  *mt.ToSrvDeletedBlks
+	)
+
+	w.mu.Lock()
+	for blkpos := range w.blks {
+		if dist(blkpos, center) <= r {
+			continue
+		}
+
+		delete(w.blks, blkpos)
+		evs = append(evs, BlkUnloaded{blkpos})
+
+		if ack == nil || len(ack.Blks) == math.MaxUint8 {
+			ack = new(mt.ToSrvDeletedBlks)
+			acks = append(acks, ack)
+		}
+		ack.Blks = append(ack.Blks, blkpos)
+	}
+	w.unlockEmit(evs)
+	return acks
+}
+
+func dist(p, q [3]int16) float64 {
+	var sum float64
+	for i := range p {
+		d := float64(p[i]) - float64(q[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// Node returns the node at pos.
+// ok is false if its MapBlk is not loaded.
+func (w *World) Node(pos [3]int16) (n mt.Node, ok bool) {
+	blkpos, i := mt.Pos2Blkpos(pos)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	blk, ok := w.blks[blkpos]
+	if !ok {
+		return mt.Node{}, false
+	}
+	return mt.Node{Param0: blk.Param0[i], Param1: blk.Param1[i], Param2: blk.Param2[i]}, true
+}
+
+// Meta returns the NodeMeta of the node at pos, or nil if it has none.
+// It must not be modified.
+func (w *World) Meta(pos [3]int16) *mt.NodeMeta {
+	blkpos, i := mt.Pos2Blkpos(pos)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if blk, ok := w.blks[blkpos]; ok {
+		return blk.NodeMetas[i]
+	}
+	return nil
+}
+
+// Blk returns a copy of the MapBlk at blkpos.
+// ok is false if it is not loaded.
+// The NodeMetas in the copy are shared and must not be modified.
+func (w *World) Blk(blkpos [3]int16) (blk mt.MapBlk, ok bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	b, ok := w.blks[blkpos]
+	if !ok {
+		return mt.MapBlk{}, false
+	}
+
+	blk = *b
+	if b.NodeMetas != nil {
+		blk.NodeMetas = make(map[uint16]*mt.NodeMeta, len(b.NodeMetas))
+		for i, meta := range b.NodeMetas {
+			blk.NodeMetas[i] = meta
+		}
+	}
+	return blk, true
+}
+
+// Loaded reports whether the MapBlk at blkpos is loaded.
+func (w *World) Loaded(blkpos [3]int16) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	_, ok := w.blks[blkpos]
+	return ok
+}
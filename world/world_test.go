@@ -0,0 +1,162 @@
+package world
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/anon55555/mt"
+)
+
+func TestApply(t *testing.T) {
+	var evs []Event
+	w := &World{OnEvent: func(ev Event) { evs = append(evs, ev) }}
+
+	pos := [3]int16{-1, 17, 2}
+	blkpos, i := mt.Pos2Blkpos(pos)
+	meta := &mt.NodeMeta{Fields: []mt.NodeMetaField{{Field: mt.Field{Name: "infotext", Value: "hi"}}}}
+
+	// Changes to unloaded MapBlks are ignored.
+	if ack := w.Apply(&mt.ToCltAddNode{Pos: pos, Node: mt.Node{Param0: 1}}); ack != nil {
+		t.Errorf("AddNode acknowledged: %v", ack)
+	}
+	if _, ok := w.Node(pos); ok {
+		t.Error("Node in unloaded MapBlk")
+	}
+
+	var blk mt.MapBlk
+	blk.Param0[i] = 2
+	blk.NodeMetas = map[uint16]*mt.NodeMeta{i: meta}
+	ack := w.Apply(&mt.ToCltBlkData{Blkpos: blkpos, Blk: blk})
+	if want := (&mt.ToSrvGotBlks{Blks: [][3]int16{blkpos}}); !reflect.DeepEqual(ack, want) {
+		t.Errorf("got %v, want %v", ack, want)
+	}
+	if !w.Loaded(blkpos) {
+		t.Fatal("MapBlk not loaded")
+	}
+	if n, _ := w.Node(pos); n.Param0 != 2 {
+		t.Errorf("Param0 is %d, want 2", n.Param0)
+	}
+	if w.Meta(pos) != meta {
+		t.Error("wrong NodeMeta")
+	}
+
+	w.Apply(&mt.ToCltAddNode{Pos: pos, Node: mt.Node{Param0: 3, Param2: 4}, KeepMeta: true})
+	w.Apply(&mt.ToCltRemoveNode{Pos: pos})
+	if n, _ := w.Node(pos); n != (mt.Node{Param0: mt.Air}) {
+		t.Errorf("got %v, want air", n)
+	}
+	if w.Meta(pos) != nil {
+		t.Error("NodeMeta not removed with node")
+	}
+
+	w.Apply(&mt.ToCltNodeMetasChanged{Changed: map[[3]int16]*mt.NodeMeta{pos: meta}})
+	w.Apply(&mt.ToCltNodeMetasChanged{Changed: map[[3]int16]*mt.NodeMeta{pos: {}}})
+	if w.Meta(pos) != nil {
+		t.Error("empty NodeMeta not removed")
+	}
+
+	// The received MapBlk must not be modified.
+	if blk.Param0[i] != 2 || blk.NodeMetas[i] != meta {
+		t.Error("ToCltBlkData modified")
+	}
+
+	want := []Event{
+		BlkLoaded{blkpos},
+		NodeChanged{pos, mt.Node{Param0: 2}, mt.Node{Param0: 3, Param2: 4}},
+		NodeChanged{pos, mt.Node{Param0: 3, Param2: 4}, mt.Node{Param0: mt.Air}},
+		MetaChanged{pos, nil},
+		MetaChanged{pos, meta},
+		MetaChanged{pos, nil},
+	}
+	if !reflect.DeepEqual(evs, want) {
+		t.Errorf("got events %v, want %v", evs, want)
+	}
+}
+
+func TestEvict(t *testing.T) {
+	var w World
+	for x := int16(-20); x <= 20; x++ {
+		w.Apply(&mt.ToCltBlkData{Blkpos: [3]int16{x, 0, 0}})
+	}
+	for z := int16(0); z < 300; z++ {
+		w.Apply(&mt.ToCltBlkData{Blkpos: [3]int16{0, 100, z}})
+	}
+
+	var pp mt.PlayerPos
+	pp.SetPos(mt.IntPos([3]int16{16 * 5, 0, 0}))
+	pp.WantedRange = 3
+
+	acks := w.Evict(pp)
+	if len(acks) != 2 {
+		t.Fatalf("got %d ToSrvDeletedBlks, want 2", len(acks))
+	}
+	n := 0
+	for _, ack := range acks {
+		if len(ack.Blks) > 255 {
+			t.Errorf("%d Blks in ToSrvDeletedBlks", len(ack.Blks))
+		}
+		for _, blkpos := range ack.Blks {
+			if w.Loaded(blkpos) {
+				t.Errorf("%v deleted but loaded", blkpos)
+			}
+		}
+		n += len(ack.Blks)
+	}
+	if want := 41 - (3+EvictMargin)*2 - 1 + 300; n != want {
+		t.Errorf("%d MapBlks deleted, want %d", n, want)
+	}
+
+	for x := int16(5 - 3 - EvictMargin); x <= 5+3+EvictMargin; x++ {
+		if !w.Loaded([3]int16{x, 0, 0}) {
+			t.Errorf("%v evicted", [3]int16{x, 0, 0})
+		}
+	}
+	if acks := w.Evict(pp); acks != nil {
+		t.Errorf("evicted again: %v", acks)
+	}
+}
+
+func TestEventOrder(t *testing.T) {
+	pos := [3]int16{1, 2, 3}
+	blkpos, _ := mt.Pos2Blkpos(pos)
+
+	var evs []NodeChanged
+	w := &World{OnEvent: func(ev Event) {
+		if ev, ok := ev.(NodeChanged); ok {
+			evs = append(evs, ev)
+		}
+	}}
+	w.Apply(&mt.ToCltBlkData{Blkpos: blkpos})
+
+	const goroutines, n = 8, 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				w.Apply(&mt.ToCltAddNode{
+					Pos:      pos,
+					Node:     mt.Node{Param0: mt.Content(1 + g*n + i)},
+					KeepMeta: true,
+				})
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if len(evs) != goroutines*n {
+		t.Fatalf("got %d events, want %d", len(evs), goroutines*n)
+	}
+	prev := mt.Node{}
+	for i, ev := range evs {
+		if ev.Old != prev {
+			t.Fatalf("event %d: Old is %v, want %v", i, ev.Old, prev)
+		}
+		prev = ev.New
+	}
+	if n, _ := w.Node(pos); n != prev {
+		t.Errorf("Node is %v, want %v from the last event", n, prev)
+	}
+}
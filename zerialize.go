@@ -15,20 +15,22 @@ var ErrTooLong = errors.New("len too long")
 
 var be = binary.BigEndian
 
+// The protocol version ver is passed on to nested (de)serializers
+// so that fields can depend on it, e.g. using "//mt:if ver >= 40".
 type serializer interface {
-	serialize(w io.Writer)
+	serialize(w io.Writer, ver uint16)
 }
 
-func serialize(w io.Writer, s interface{}) error {
-	return pcall(func() { s.(serializer).serialize(w) })
+func serialize(w io.Writer, s interface{}, ver uint16) error {
+	return pcall(func() { s.(serializer).serialize(w, ver) })
 }
 
 type deserializer interface {
-	deserialize(r io.Reader)
+	deserialize(r io.Reader, ver uint16)
 }
 
-func deserialize(r io.Reader, d interface{}) error {
-	return pcall(func() { d.(deserializer).deserialize(r) })
+func deserialize(r io.Reader, d interface{}, ver uint16) error {
+	return pcall(func() { d.(deserializer).deserialize(r, ver) })
 }
 
 type serializationError struct {
@@ -0,0 +1,141 @@
+package zstd
+
+import "math/bits"
+
+// A fwdBitReader reads bits starting at the least significant bit
+// of the first byte, as in FSE table descriptions.
+// Bits after the end of data read as 0.
+type fwdBitReader struct {
+	data []byte
+	pos  uint // in bits
+}
+
+func (br *fwdBitReader) peek(n uint) uint32 {
+	var v uint32
+	for i := uint(0); i < n; i++ {
+		pos := br.pos + i
+		if pos/8 < uint(len(br.data)) {
+			v |= uint32(br.data[pos/8]>>(pos%8)&1) << i
+		}
+	}
+	return v
+}
+
+func (br *fwdBitReader) read(n uint) uint32 {
+	v := br.peek(n)
+	br.pos += n
+	return v
+}
+
+// n returns the number of bytes read so far.
+func (br *fwdBitReader) n() int {
+	return int((br.pos + 7) / 8)
+}
+
+// A bitReader reads a bitstream backwards,
+// starting at the most significant bit of the last byte
+// after the padding that ends in the first set bit.
+// Huffman-coded and FSE-coded streams are read like this.
+//
+// It works like BIT_DStream_t of the reference implementation:
+// bits are read from a 64-bit container that is refilled by reload.
+type bitReader struct {
+	data      []byte
+	off       int // of container in data
+	container uint64
+	consumed  uint // bits of container
+}
+
+func (br *bitReader) init(data []byte) error {
+	if len(data) == 0 || data[len(data)-1] == 0 {
+		return ErrCorrupt
+	}
+
+	br.data = data
+	if len(data) >= 8 {
+		br.off = len(data) - 8
+		br.container = le.Uint64(data[br.off:])
+		br.consumed = 0
+	} else {
+		var buf [8]byte
+		copy(buf[:], data)
+		br.off = 0
+		br.container = le.Uint64(buf[:])
+		br.consumed = uint(8-len(data)) * 8
+	}
+	br.consumed += uint(bits.LeadingZeros8(data[len(data)-1])) + 1
+	return nil
+}
+
+// peek returns the next n bits, n < 64.
+// It returns garbage if the bitstream has overflowed.
+func (br *bitReader) peek(n uint) uint64 {
+	return br.container << (br.consumed & 63) >> 1 >> (63 - n)
+}
+
+func (br *bitReader) read(n uint) uint64 {
+	v := br.peek(n)
+	br.consumed += n
+	return v
+}
+
+// reload refills the container so that at least 56 bits
+// can be read unless the start of the data is reached.
+func (br *bitReader) reload() {
+	if br.overflow() {
+		return
+	}
+
+	switch {
+	case br.off >= 8:
+		br.off -= int(br.consumed / 8)
+		br.consumed %= 8
+	case br.off == 0:
+		return
+	default:
+		n := int(br.consumed / 8)
+		if n > br.off {
+			n = br.off
+		}
+		br.off -= n
+		br.consumed -= uint(n) * 8
+	}
+	br.container = le.Uint64(br.data[br.off:])
+}
+
+// overflow reports whether more bits were read than there are.
+func (br *bitReader) overflow() bool {
+	return br.consumed > 64
+}
+
+// done reports whether exactly all bits were read.
+func (br *bitReader) done() bool {
+	return br.off == 0 && br.consumed == 64
+}
+
+// A bitWriter writes a bitstream that a bitReader reads backwards.
+type bitWriter struct {
+	out       []byte
+	container uint64
+	n         uint // bits in container
+}
+
+// write writes the n low bits of v, n <= 32.
+func (bw *bitWriter) write(v uint64, n uint) {
+	bw.container |= v & (1<<n - 1) << bw.n
+	bw.n += n
+	for bw.n >= 8 {
+		bw.out = append(bw.out, byte(bw.container))
+		bw.container >>= 8
+		bw.n -= 8
+	}
+}
+
+// close writes the padding and returns the bitstream.
+func (bw *bitWriter) close() []byte {
+	bw.write(1, 1)
+	if bw.n > 0 {
+		bw.out = append(bw.out, byte(bw.container))
+	}
+	return bw.out
+}
@@ -0,0 +1,395 @@
+package zstd
+
+// Baselines and numbers of extra bits of the length codes.
+var (
+	llBase = [36]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	llBits = [36]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+
+	mlBase = [53]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	mlBits = [53]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+)
+
+const (
+	maxLLCode = len(llBase) - 1
+	maxMLCode = len(mlBase) - 1
+	maxOFCode = 31
+
+	maxLLLog = 9
+	maxMLLog = 9
+	maxOFLog = 8
+)
+
+// Predefined distributions.
+var (
+	predefLLNorm = []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}
+	predefMLNorm = []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}
+	predefOFNorm = []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}
+
+	predefLL = mustFSETable(predefLLNorm, 6)
+	predefML = mustFSETable(predefMLNorm, 6)
+	predefOF = mustFSETable(predefOFNorm, 5)
+)
+
+// blkDecoder holds what blocks of a frame inherit from previous ones.
+type blkDecoder struct {
+	huff                      *huffTable
+	llTable, ofTable, mlTable *fseTable
+	reps                      [3]uint32
+
+	lits []byte
+}
+
+func (d *blkDecoder) reset() {
+	*d = blkDecoder{
+		reps: [3]uint32{1, 4, 8},
+		lits: d.lits,
+	}
+}
+
+// decode appends the decompressed data of a compressed block to hist.
+// The decompressed data must not be larger than max.
+func (d *blkDecoder) decode(hist, blk []byte, max int) ([]byte, error) {
+	lits, n, err := d.readLits(blk)
+	if err != nil {
+		return hist, err
+	}
+
+	return d.execSeqs(hist, blk[n:], lits, len(hist)+max)
+}
+
+// readLits reads the literals section of blk
+// and returns the literals and the size of the section.
+func (d *blkDecoder) readLits(blk []byte) ([]byte, int, error) {
+	if len(blk) == 0 {
+		return nil, 0, ErrCorrupt
+	}
+
+	typ := blk[0] & 3
+	sizeFmt := blk[0] >> 2 & 3
+
+	if typ == 0 || typ == 1 { // Raw or RLE.
+		var size, n int
+		switch sizeFmt {
+		case 0, 2:
+			size, n = int(blk[0]>>3), 1
+		case 1:
+			if len(blk) < 2 {
+				return nil, 0, ErrCorrupt
+			}
+			size, n = int(blk[0]>>4)|int(blk[1])<<4, 2
+		case 3:
+			if len(blk) < 3 {
+				return nil, 0, ErrCorrupt
+			}
+			size, n = int(blk[0]>>4)|int(blk[1])<<4|int(blk[2])<<12, 3
+		}
+		if size > maxBlkSize {
+			return nil, 0, ErrCorrupt
+		}
+
+		if typ == 0 {
+			if n+size > len(blk) {
+				return nil, 0, ErrCorrupt
+			}
+			return blk[n : n+size], n + size, nil
+		}
+
+		if n >= len(blk) {
+			return nil, 0, ErrCorrupt
+		}
+		lits := d.litBuf(size)
+		for i := range lits {
+			lits[i] = blk[n]
+		}
+		return lits, n + 1, nil
+	}
+
+	// Huffman-coded.
+	var size, csize, n int
+	streams := 4
+	switch sizeFmt {
+	case 0, 1:
+		if len(blk) < 3 {
+			return nil, 0, ErrCorrupt
+		}
+		h := uint32(blk[0]) | uint32(blk[1])<<8 | uint32(blk[2])<<16
+		size, csize, n = int(h>>4&0x3ff), int(h>>14&0x3ff), 3
+		if sizeFmt == 0 {
+			streams = 1
+		}
+	case 2:
+		if len(blk) < 4 {
+			return nil, 0, ErrCorrupt
+		}
+		h := le.Uint32(blk)
+		size, csize, n = int(h>>4&0x3fff), int(h>>18), 4
+	case 3:
+		if len(blk) < 5 {
+			return nil, 0, ErrCorrupt
+		}
+		h := uint64(le.Uint32(blk)) | uint64(blk[4])<<32
+		size, csize, n = int(h>>4&0x3ffff), int(h>>22), 5
+	}
+	if size > maxBlkSize || n+csize > len(blk) {
+		return nil, 0, ErrCorrupt
+	}
+	data := blk[n : n+csize]
+
+	if typ == 2 {
+		t, tn, err := readHuffTable(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		d.huff = t
+		data = data[tn:]
+	} else if d.huff == nil { // Treeless.
+		return nil, 0, ErrCorrupt
+	}
+
+	lits := d.litBuf(size)
+	if streams == 1 {
+		if err := d.huff.decode(lits, data); err != nil {
+			return nil, 0, err
+		}
+		return lits, n + csize, nil
+	}
+
+	if len(data) < 6 {
+		return nil, 0, ErrCorrupt
+	}
+	var sizes [4]int
+	sizes[3] = len(data) - 6
+	for i := 0; i < 3; i++ {
+		sizes[i] = int(le.Uint16(data[2*i:]))
+		sizes[3] -= sizes[i]
+	}
+	if sizes[3] < 0 {
+		return nil, 0, ErrCorrupt
+	}
+	data = data[6:]
+
+	seg := (size + 3) / 4
+	if 3*seg > size {
+		return nil, 0, ErrCorrupt
+	}
+	dst := lits
+	for i, sz := range sizes {
+		m := seg
+		if i == 3 {
+			m = len(dst)
+		}
+		if err := d.huff.decode(dst[:m], data[:sz]); err != nil {
+			return nil, 0, err
+		}
+		dst, data = dst[m:], data[sz:]
+	}
+
+	return lits, n + csize, nil
+}
+
+func (d *blkDecoder) litBuf(size int) []byte {
+	if cap(d.lits) < size {
+		d.lits = make([]byte, size)
+	}
+	return d.lits[:size]
+}
+
+// execSeqs decodes the sequences section seqs
+// and appends the result of executing the sequences to hist,
+// which must not grow beyond end.
+func (d *blkDecoder) execSeqs(hist, seqs, lits []byte, end int) ([]byte, error) {
+	if len(seqs) == 0 {
+		return hist, ErrCorrupt
+	}
+
+	var nseqs, n int
+	switch b := int(seqs[0]); {
+	case b < 128:
+		nseqs, n = b, 1
+	case b < 255:
+		if len(seqs) < 2 {
+			return hist, ErrCorrupt
+		}
+		nseqs, n = (b-128)<<8|int(seqs[1]), 2
+	default:
+		if len(seqs) < 3 {
+			return hist, ErrCorrupt
+		}
+		nseqs, n = int(le.Uint16(seqs[1:]))+0x7f00, 3
+	}
+
+	if nseqs == 0 {
+		if len(hist)+len(lits) > end {
+			return hist, ErrCorrupt
+		}
+		return append(hist, lits...), nil
+	}
+
+	if n >= len(seqs) {
+		return hist, ErrCorrupt
+	}
+	modes := seqs[n]
+	n++
+	if modes&3 != 0 {
+		return hist, ErrCorrupt
+	}
+
+	for _, tbl := range []struct {
+		t      **fseTable
+		mode   uint8
+		predef *fseTable
+		maxSym int
+		maxLog uint
+	}{
+		{&d.llTable, modes >> 6, predefLL, maxLLCode, maxLLLog},
+		{&d.ofTable, modes >> 4 & 3, predefOF, maxOFCode, maxOFLog},
+		{&d.mlTable, modes >> 2 & 3, predefML, maxMLCode, maxMLLog},
+	} {
+		switch tbl.mode {
+		case 0: // Predefined.
+			*tbl.t = tbl.predef
+		case 1: // RLE.
+			if n >= len(seqs) || int(seqs[n]) > tbl.maxSym {
+				return hist, ErrCorrupt
+			}
+			*tbl.t = rleFSETable(seqs[n])
+			n++
+		case 2: // FSE compressed.
+			t, tn, err := readFSETable(seqs[n:], tbl.maxSym, tbl.maxLog)
+			if err != nil {
+				return hist, err
+			}
+			*tbl.t = t
+			n += tn
+		case 3: // Repeat.
+			if *tbl.t == nil {
+				return hist, ErrCorrupt
+			}
+		}
+	}
+
+	var br bitReader
+	if err := br.init(seqs[n:]); err != nil {
+		return hist, err
+	}
+
+	ll, of, ml := d.llTable, d.ofTable, d.mlTable
+	llState := uint16(br.read(uint(ll.log)))
+	ofState := uint16(br.read(uint(of.log)))
+	mlState := uint16(br.read(uint(ml.log)))
+	br.reload()
+
+	for i := 0; i < nseqs; i++ {
+		lle, ofe, mle := ll.entries[llState], of.entries[ofState], ml.entries[mlState]
+
+		ofCode := uint(ofe.sym)
+		ofVal := uint32(1)<<ofCode + uint32(br.read(ofCode))
+		br.reload()
+		mlen := mlBase[mle.sym] + uint32(br.read(uint(mlBits[mle.sym])))
+		br.reload()
+		llen := llBase[lle.sym] + uint32(br.read(uint(llBits[lle.sym])))
+		br.reload()
+
+		if i < nseqs-1 {
+			llState = lle.base + uint16(br.read(uint(lle.nbBits)))
+			mlState = mle.base + uint16(br.read(uint(mle.nbBits)))
+			ofState = ofe.base + uint16(br.read(uint(ofe.nbBits)))
+			br.reload()
+		}
+
+		if br.overflow() || llen > uint32(len(lits)) ||
+			uint64(len(hist))+uint64(llen)+uint64(mlen) > uint64(end) {
+			return hist, ErrCorrupt
+		}
+		hist = append(hist, lits[:llen]...)
+		lits = lits[llen:]
+
+		off, ok := d.offset(ofVal, llen)
+		if !ok || off > uint32(len(hist)) {
+			return hist, ErrCorrupt
+		}
+
+		// The match may overlap the data being appended.
+		start := len(hist) - int(off)
+		for m := int(mlen); m > 0; {
+			k := m
+			if k > int(off) {
+				k = int(off)
+			}
+			hist = append(hist, hist[start:start+k]...)
+			start += k
+			m -= k
+		}
+	}
+
+	if !br.done() || len(hist)+len(lits) > end {
+		return hist, ErrCorrupt
+	}
+
+	return append(hist, lits...), nil
+}
+
+// offset returns the offset of a sequence
+// and updates the repeated offsets.
+func (d *blkDecoder) offset(ofVal, llen uint32) (uint32, bool) {
+	if ofVal > 3 {
+		off := ofVal - 3
+		d.reps = [3]uint32{off, d.reps[0], d.reps[1]}
+		return off, true
+	}
+
+	i := ofVal - 1
+	if llen == 0 {
+		i++
+	}
+
+	var off uint32
+	switch i {
+	case 0:
+		return d.reps[0], true
+	case 3:
+		off = d.reps[0] - 1
+		if off == 0 {
+			return 0, false
+		}
+	default:
+		off = d.reps[i]
+	}
+
+	if i != 1 {
+		d.reps[2] = d.reps[1]
+	}
+	d.reps[1] = d.reps[0]
+	d.reps[0] = off
+	return off, true
+}
@@ -0,0 +1,143 @@
+package zstd
+
+import "math/bits"
+
+var (
+	predefLLEnc = newFSEEncoder(predefLLNorm, predefLL)
+	predefMLEnc = newFSEEncoder(predefMLNorm, predefML)
+	predefOFEnc = newFSEEncoder(predefOFNorm, predefOF)
+)
+
+// A seq is a sequence: llen literals followed by
+// a match of mlen bytes at offset off.
+type seq struct {
+	llen, mlen, off uint32
+}
+
+const (
+	minMatch = 4
+	hashLog  = 14
+)
+
+// compress returns the content of a compressed block
+// that decompresses to blk, or nil if there are no matches in blk.
+// Matches are only searched for within blk,
+// the literals are stored raw and the sequences
+// are encoded using the predefined distributions.
+func compress(blk []byte) []byte {
+	seqs, lits := findSeqs(blk)
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	var out []byte
+	switch n := len(lits); {
+	case n < 1<<5:
+		out = append(out, byte(n<<3))
+	case n < 1<<12:
+		out = append(out, byte(1<<2|n<<4), byte(n>>4))
+	default:
+		out = append(out, byte(3<<2|n<<4), byte(n>>4), byte(n>>12))
+	}
+	out = append(out, lits...)
+
+	switch n := len(seqs); {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7f00:
+		out = append(out, byte(n>>8+128), byte(n))
+	default:
+		out = append(out, 255, byte(n-0x7f00), byte((n-0x7f00)>>8))
+	}
+	out = append(out, 0) // Predefined modes.
+
+	return append(out, encodeSeqs(seqs)...)
+}
+
+// findSeqs greedily finds matches in blk
+// and returns the sequences and the literals between them.
+func findSeqs(blk []byte) (seqs []seq, lits []byte) {
+	hash := func(i int) uint32 {
+		return le.Uint32(blk[i:]) * 2654435761 >> (32 - hashLog)
+	}
+
+	var table [1 << hashLog]int32 // Positions + 1.
+	litStart := 0
+	for i := 0; i+minMatch <= len(blk); {
+		h := hash(i)
+		cand := int(table[h]) - 1
+		table[h] = int32(i + 1)
+		if cand < 0 || le.Uint32(blk[cand:]) != le.Uint32(blk[i:]) {
+			i++
+			continue
+		}
+
+		for i > litStart && cand > 0 && blk[i-1] == blk[cand-1] {
+			i--
+			cand--
+		}
+		n := minMatch
+		for i+n < len(blk) && blk[cand+n] == blk[i+n] {
+			n++
+		}
+
+		lits = append(lits, blk[litStart:i]...)
+		seqs = append(seqs, seq{uint32(i - litStart), uint32(n), uint32(i - cand)})
+
+		for j := i + 1; j < i+n && j+minMatch <= len(blk); j++ {
+			table[hash(j)] = int32(j + 1)
+		}
+		i += n
+		litStart = i
+	}
+
+	return seqs, append(lits, blk[litStart:]...)
+}
+
+// encodeSeqs returns the bitstream of seqs.
+// The decoder reads it backwards, so the last sequence is encoded first.
+func encodeSeqs(seqs []seq) []byte {
+	type codes struct{ ll, of, ml uint8 }
+	cs := make([]codes, len(seqs))
+	for i, s := range seqs {
+		cs[i] = codes{
+			ll: lenCode(llBase[:], s.llen),
+			of: uint8(bits.Len32(s.off+3) - 1),
+			ml: lenCode(mlBase[:], s.mlen),
+		}
+	}
+
+	var bw bitWriter
+	extra := func(i int) {
+		s, c := seqs[i], cs[i]
+		bw.write(uint64(s.llen-llBase[c.ll]), uint(llBits[c.ll]))
+		bw.write(uint64(s.mlen-mlBase[c.ml]), uint(mlBits[c.ml]))
+		bw.write(uint64(s.off+3), uint(c.of))
+	}
+
+	last := len(seqs) - 1
+	ll := predefLLEnc.init(cs[last].ll)
+	of := predefOFEnc.init(cs[last].of)
+	ml := predefMLEnc.init(cs[last].ml)
+	extra(last)
+	for i := last - 1; i >= 0; i-- {
+		of = predefOFEnc.encode(&bw, of, cs[i].of)
+		ml = predefMLEnc.encode(&bw, ml, cs[i].ml)
+		ll = predefLLEnc.encode(&bw, ll, cs[i].ll)
+		extra(i)
+	}
+	predefMLEnc.flush(&bw, ml)
+	predefOFEnc.flush(&bw, of)
+	predefLLEnc.flush(&bw, ll)
+
+	return bw.close()
+}
+
+// lenCode returns the code of a literal or match length l.
+func lenCode(base []uint32, l uint32) uint8 {
+	c := len(base) - 1
+	for base[c] > l {
+		c--
+	}
+	return uint8(c)
+}
@@ -0,0 +1,224 @@
+package zstd
+
+import "math/bits"
+
+const minFSELog = 5
+
+type fseEntry struct {
+	sym    uint8
+	nbBits uint8
+	base   uint16 // of the next state
+}
+
+// An fseTable is an FSE decoding table.
+type fseTable struct {
+	log     uint8 // accuracy log
+	entries []fseEntry
+}
+
+// readFSETable reads an FSE table description
+// and returns the table and the number of bytes read.
+func readFSETable(data []byte, maxSym int, maxLog uint) (*fseTable, int, error) {
+	br := fwdBitReader{data: data}
+
+	log := uint(br.read(4)) + minFSELog
+	if log > maxLog {
+		return nil, 0, ErrCorrupt
+	}
+
+	var norm []int16
+	remaining := int32(1<<log) + 1
+	threshold := int32(1 << log)
+	nbBits := log + 1
+	prev0 := false
+	for remaining > 1 && len(norm) <= maxSym {
+		if prev0 {
+			for {
+				rep := br.read(2)
+				for i := uint32(0); i < rep; i++ {
+					norm = append(norm, 0)
+				}
+				if rep != 3 {
+					break
+				}
+			}
+			if len(norm) > maxSym {
+				return nil, 0, ErrCorrupt
+			}
+		}
+
+		// Small values need a bit less.
+		max := 2*threshold - 1 - remaining
+		var count int32
+		if v := int32(br.peek(nbBits - 1)); v < max {
+			count = v
+			br.read(nbBits - 1)
+		} else {
+			count = int32(br.read(nbBits))
+			if count >= threshold {
+				count -= max
+			}
+		}
+		count-- // -1 means less than 1.
+
+		if count < 0 {
+			remaining--
+		} else {
+			remaining -= count
+		}
+		if remaining < 1 {
+			return nil, 0, ErrCorrupt
+		}
+		norm = append(norm, int16(count))
+		prev0 = count == 0
+
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+	}
+	if remaining != 1 || br.n() > len(data) {
+		return nil, 0, ErrCorrupt
+	}
+
+	t, err := newFSETable(norm, log)
+	return t, br.n(), err
+}
+
+// newFSETable builds the table of a normalized distribution.
+func newFSETable(norm []int16, log uint) (*fseTable, error) {
+	size := 1 << log
+	t := &fseTable{
+		log:     uint8(log),
+		entries: make([]fseEntry, size),
+	}
+
+	// Symbols with probability "less than 1" go to the end.
+	next := make([]int, len(norm))
+	high := size - 1
+	for s, c := range norm {
+		if c == -1 {
+			t.entries[high].sym = uint8(s)
+			high--
+			next[s] = 1
+		} else {
+			next[s] = int(c)
+		}
+	}
+
+	mask := size - 1
+	step := size>>1 + size>>3 + 3
+	pos := 0
+	for s, c := range norm {
+		for i := 0; i < int(c); i++ {
+			t.entries[pos].sym = uint8(s)
+			pos = (pos + step) & mask
+			for pos > high {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	if pos != 0 {
+		return nil, ErrCorrupt
+	}
+
+	for i := range t.entries {
+		e := &t.entries[i]
+		x := next[e.sym]
+		next[e.sym]++
+		nb := log + 1 - uint(bits.Len(uint(x)))
+		e.nbBits = uint8(nb)
+		e.base = uint16(x<<nb - size)
+	}
+
+	return t, nil
+}
+
+// rleFSETable returns a table that always decodes sym.
+func rleFSETable(sym uint8) *fseTable {
+	return &fseTable{entries: []fseEntry{{sym: sym}}}
+}
+
+func mustFSETable(norm []int16, log uint) *fseTable {
+	t, err := newFSETable(norm, log)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// An fseEncoder is an FSE encoding table.
+// It works like FSE_CTable of the reference implementation.
+// States are in [1<<log, 2<<log).
+type fseEncoder struct {
+	log    uint8
+	states []uint16
+	syms   []fseSymEnc
+}
+
+type fseSymEnc struct {
+	deltaNbBits    uint32
+	deltaFindState int32
+}
+
+// newFSEEncoder builds the encoding table
+// of the normalized distribution of the decoding table t.
+func newFSEEncoder(norm []int16, t *fseTable) *fseEncoder {
+	size := 1 << t.log
+	e := &fseEncoder{
+		log:    t.log,
+		states: make([]uint16, size),
+		syms:   make([]fseSymEnc, len(norm)),
+	}
+
+	cumul := make([]int, len(norm)+1)
+	for s, c := range norm {
+		if c == -1 {
+			c = 1
+		}
+		cumul[s+1] = cumul[s] + int(c)
+	}
+
+	next := append([]int(nil), cumul...)
+	for u, ent := range t.entries {
+		e.states[next[ent.sym]] = uint16(size + u)
+		next[ent.sym]++
+	}
+
+	for s, c := range norm {
+		switch c {
+		case 0:
+		case -1, 1:
+			e.syms[s] = fseSymEnc{uint32(t.log)<<16 - uint32(size), int32(cumul[s] - 1)}
+		default:
+			maxBitsOut := uint(t.log) + 1 - uint(bits.Len(uint(c-1)))
+			minStatePlus := uint32(c) << maxBitsOut
+			e.syms[s] = fseSymEnc{uint32(maxBitsOut)<<16 - minStatePlus, int32(cumul[s] - int(c))}
+		}
+	}
+
+	return e
+}
+
+// init returns the initial state for encoding sym,
+// which is the last symbol to be decoded.
+func (e *fseEncoder) init(sym uint8) uint32 {
+	tt := e.syms[sym]
+	nb := (tt.deltaNbBits + 1<<15) >> 16
+	v := nb<<16 - tt.deltaNbBits
+	return uint32(e.states[int32(v>>nb)+tt.deltaFindState])
+}
+
+// encode writes the bits that lead from the state for sym to state
+// and returns the state for sym.
+func (e *fseEncoder) encode(bw *bitWriter, state uint32, sym uint8) uint32 {
+	tt := e.syms[sym]
+	nb := (state + tt.deltaNbBits) >> 16
+	bw.write(uint64(state), uint(nb))
+	return uint32(e.states[int32(state>>nb)+tt.deltaFindState])
+}
+
+// flush writes state as the initial state of the decoder.
+func (e *fseEncoder) flush(bw *bitWriter, state uint32) {
+	bw.write(uint64(state), uint(e.log))
+}
@@ -0,0 +1,67 @@
+//go:build go1.18
+// +build go1.18
+
+package zstd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+// FuzzReader feeds arbitrary data into a Reader.
+// Decoding must not panic and must not read past the frame.
+func FuzzReader(f *testing.F) {
+	for _, name := range []string{"1.zst", "wlog10.zst"} {
+		data, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data[:4096])
+	}
+	f.Add(frame(2<<3, 'a', 'b', 1, 1<<6|1<<4|1<<2, 2, 2, 4, 0b101))
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		br := bytes.NewReader(in)
+		r, err := NewReader(br)
+		if err != nil {
+			return
+		}
+		n, err := io.Copy(io.Discard, r)
+		if err != nil {
+			return
+		}
+		if n > int64(len(in))*maxBlkSize {
+			t.Fatalf("decompressed %d bytes from %d", n, len(in))
+		}
+		if err := r.Close(); err != nil {
+			t.Fatal("Close after EOF:", err)
+		}
+	})
+}
+
+// FuzzWriter checks that data written by a Writer reads back unchanged.
+func FuzzWriter(f *testing.F) {
+	f.Add(testInput()[:4096])
+	f.Add([]byte("abcdabcdabcdabcd"))
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		var b bytes.Buffer
+		w := NewWriter(&b)
+		if _, err := w.Write(in); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := decompress(b.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, in) {
+			t.Fatal("wrong data")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package zstd
+
+import "math/bits"
+
+const maxHuffBits = 11
+
+type huffEntry struct {
+	sym    uint8
+	nbBits uint8
+}
+
+// A huffTable is a Huffman decoding table
+// indexed by the next maxBits bits.
+type huffTable struct {
+	maxBits uint8
+	entries []huffEntry
+}
+
+// readHuffTable reads a Huffman tree description
+// and returns the table and the number of bytes read.
+func readHuffTable(data []byte) (*huffTable, int, error) {
+	if len(data) == 0 {
+		return nil, 0, ErrCorrupt
+	}
+
+	var weights []uint8
+	n := 1
+	if hdr := int(data[0]); hdr < 128 {
+		n += hdr
+		if n > len(data) {
+			return nil, 0, ErrCorrupt
+		}
+
+		var err error
+		weights, err = readFSEWeights(data[1:n])
+		if err != nil {
+			return nil, 0, err
+		}
+	} else {
+		weights = make([]uint8, hdr-127)
+		n += (len(weights) + 1) / 2
+		if n > len(data) {
+			return nil, 0, ErrCorrupt
+		}
+
+		for i := range weights {
+			b := data[1+i/2]
+			if i%2 == 0 {
+				weights[i] = b >> 4
+			} else {
+				weights[i] = b & 0xf
+			}
+		}
+	}
+
+	// The weight of the last symbol is implied.
+	var total uint32
+	for _, w := range weights {
+		if w > maxHuffBits {
+			return nil, 0, ErrCorrupt
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return nil, 0, ErrCorrupt
+	}
+	maxBits := bits.Len32(total)
+	if maxBits > maxHuffBits {
+		return nil, 0, ErrCorrupt
+	}
+	rest := uint32(1)<<maxBits - total
+	if rest&(rest-1) != 0 {
+		return nil, 0, ErrCorrupt
+	}
+	weights = append(weights, uint8(bits.Len32(rest)))
+
+	// Codes are assigned in order of weight, then symbol.
+	t := &huffTable{
+		maxBits: uint8(maxBits),
+		entries: make([]huffEntry, 1<<maxBits),
+	}
+	pos := 0
+	for w := 1; w <= maxBits; w++ {
+		for s, sw := range weights {
+			if int(sw) != w {
+				continue
+			}
+			e := huffEntry{sym: uint8(s), nbBits: uint8(maxBits + 1 - w)}
+			for i := 0; i < 1<<(w-1); i++ {
+				t.entries[pos] = e
+				pos++
+			}
+		}
+	}
+
+	return t, n, nil
+}
+
+// readFSEWeights decodes FSE compressed Huffman weights.
+func readFSEWeights(data []byte) ([]uint8, error) {
+	t, n, err := readFSETable(data, 255, 6)
+	if err != nil {
+		return nil, err
+	}
+
+	var br bitReader
+	if err := br.init(data[n:]); err != nil {
+		return nil, err
+	}
+
+	// Two states are used alternately.
+	// When the bitstream overflows, the other state
+	// decodes the last weight without reading bits.
+	states := [2]uint16{
+		uint16(br.read(uint(t.log))),
+		uint16(br.read(uint(t.log))),
+	}
+	br.reload()
+
+	var weights []uint8
+	for i := 0; ; i ^= 1 {
+		if len(weights) > 255-2 {
+			return nil, ErrCorrupt
+		}
+
+		e := t.entries[states[i]]
+		weights = append(weights, e.sym)
+		states[i] = e.base + uint16(br.read(uint(e.nbBits)))
+		br.reload()
+
+		if br.overflow() {
+			weights = append(weights, t.entries[states[i^1]].sym)
+			return weights, nil
+		}
+	}
+}
+
+// decode decodes a Huffman-coded stream of len(dst) symbols.
+func (t *huffTable) decode(dst, src []byte) error {
+	var br bitReader
+	if err := br.init(src); err != nil {
+		return err
+	}
+
+	for i := range dst {
+		e := t.entries[br.peek(uint(t.maxBits))]
+		br.consumed += uint(e.nbBits)
+		dst[i] = e.sym
+		br.reload()
+	}
+
+	if !br.done() {
+		return ErrCorrupt
+	}
+	return nil
+}
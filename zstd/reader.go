@@ -0,0 +1,278 @@
+package zstd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A Reader decompresses a single zstd frame.
+// It does not read past the end of the frame,
+// so the underlying reader can be used to read what follows it.
+type Reader struct {
+	r   io.Reader
+	err error
+
+	checksum    bool
+	contentSize int64 // -1 if unknown.
+	windowSize  int
+	blkSize     int // maximum
+
+	d blkDecoder
+
+	// hist holds the decompressed data
+	// that has not been read yet or may still be referenced.
+	hist []byte
+	read int // of hist
+	size int64
+
+	digest xxh64
+	buf    []byte
+}
+
+// NewReader reads the header of the zstd frame from r
+// and returns a Reader that decompresses it.
+// Skippable frames before it are skipped.
+func NewReader(r io.Reader) (*Reader, error) {
+	z := &Reader{r: r}
+	if err := z.readHdr(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (z *Reader) readFull(buf []byte) error {
+	_, err := io.ReadFull(z.r, buf)
+	return err
+}
+
+func (z *Reader) readHdr() error {
+	buf := make([]byte, 8)
+	for {
+		if err := z.readFull(buf[:4]); err != nil {
+			return err
+		}
+		m := le.Uint32(buf)
+		if m == magic {
+			break
+		}
+		if m&^0xf != skippableMagic {
+			return ErrHeader
+		}
+
+		if err := z.readFull(buf[:4]); err != nil {
+			return noEOF(err)
+		}
+		n := int64(le.Uint32(buf))
+		if _, err := io.CopyN(io.Discard, z.r, n); err != nil {
+			return noEOF(err)
+		}
+	}
+
+	if err := z.readFull(buf[:1]); err != nil {
+		return noEOF(err)
+	}
+	desc := buf[0]
+	fcsFlag := desc >> 6
+	single := desc>>5&1 != 0
+	z.checksum = desc>>2&1 != 0
+	if desc>>3&1 != 0 { // Reserved.
+		return ErrHeader
+	}
+
+	if !single {
+		if err := z.readFull(buf[:1]); err != nil {
+			return noEOF(err)
+		}
+		log := 10 + uint(buf[0]>>3)
+		base := 1 << log
+		z.windowSize = base + base/8*int(buf[0]&7)
+	}
+
+	if n := [...]int{0, 1, 2, 4}[desc&3]; n > 0 {
+		if err := z.readFull(buf[:n]); err != nil {
+			return noEOF(err)
+		}
+		var id uint32
+		for i := n - 1; i >= 0; i-- {
+			id = id<<8 | uint32(buf[i])
+		}
+		if id != 0 {
+			return ErrDictionary
+		}
+	}
+
+	z.contentSize = -1
+	if n := [...]int{0, 2, 4, 8}[fcsFlag]; n > 0 || single {
+		if n == 0 {
+			n = 1
+		}
+		if err := z.readFull(buf[:n]); err != nil {
+			return noEOF(err)
+		}
+		var size uint64
+		for i := n - 1; i >= 0; i-- {
+			size = size<<8 | uint64(buf[i])
+		}
+		if n == 2 {
+			size += 256
+		}
+		if size > 1<<62 {
+			return ErrHeader
+		}
+		z.contentSize = int64(size)
+		if single {
+			z.windowSize = int(min64(size, maxWindowSize+1))
+		}
+	}
+
+	if z.windowSize > maxWindowSize {
+		return fmt.Errorf("zstd: window size too large: %d", z.windowSize)
+	}
+	z.blkSize = z.windowSize
+	if z.blkSize > maxBlkSize {
+		z.blkSize = maxBlkSize
+	}
+
+	z.d.reset()
+	z.digest.reset()
+	return nil
+}
+
+func (z *Reader) Read(p []byte) (int, error) {
+	for z.read == len(z.hist) {
+		if z.err != nil {
+			return 0, z.err
+		}
+		z.err = z.readBlk()
+	}
+
+	n := copy(p, z.hist[z.read:])
+	z.read += n
+	return n, nil
+}
+
+// Close reads the rest of the frame and verifies it.
+// It does not close the underlying reader.
+func (z *Reader) Close() error {
+	for z.err == nil {
+		z.read = len(z.hist)
+		z.err = z.readBlk()
+	}
+	z.read = len(z.hist)
+
+	if z.err == io.EOF {
+		return nil
+	}
+	return z.err
+}
+
+// readBlk decompresses the next block.
+// After the last block, it checks the frame and returns io.EOF.
+func (z *Reader) readBlk() error {
+	z.trim()
+
+	buf := make([]byte, 4)
+	if err := z.readFull(buf[:3]); err != nil {
+		return noEOF(err)
+	}
+	hdr := uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16
+	last := hdr&1 != 0
+	typ := blkType(hdr >> 1 & 3)
+	size := int(hdr >> 3)
+
+	start := len(z.hist)
+	switch typ {
+	case rawBlk:
+		if size > z.blkSize {
+			return ErrCorrupt
+		}
+		z.hist = append(z.hist, make([]byte, size)...)
+		if err := z.readFull(z.hist[start:]); err != nil {
+			return noEOF(err)
+		}
+	case rleBlk:
+		if size > z.blkSize {
+			return ErrCorrupt
+		}
+		if err := z.readFull(buf[:1]); err != nil {
+			return noEOF(err)
+		}
+		z.hist = append(z.hist, bytes.Repeat(buf[:1], size)...)
+	case compressedBlk:
+		if size > z.blkSize {
+			return ErrCorrupt
+		}
+		if cap(z.buf) < size {
+			z.buf = make([]byte, size)
+		}
+		blk := z.buf[:size]
+		if err := z.readFull(blk); err != nil {
+			return noEOF(err)
+		}
+
+		var err error
+		z.hist, err = z.d.decode(z.hist, blk, z.blkSize)
+		if err != nil {
+			return err
+		}
+	default:
+		return ErrCorrupt
+	}
+
+	z.size += int64(len(z.hist) - start)
+	if z.contentSize >= 0 && z.size > z.contentSize {
+		return ErrCorrupt
+	}
+	if z.checksum {
+		z.digest.Write(z.hist[start:])
+	}
+
+	if !last {
+		return nil
+	}
+
+	if z.contentSize >= 0 && z.size != z.contentSize {
+		return ErrCorrupt
+	}
+	if z.checksum {
+		if err := z.readFull(buf[:4]); err != nil {
+			return noEOF(err)
+		}
+		if le.Uint32(buf) != uint32(z.digest.Sum64()) {
+			return ErrChecksum
+		}
+	}
+	return io.EOF
+}
+
+// trim drops data from hist that has been read and is outside the window.
+func (z *Reader) trim() {
+	n := len(z.hist) - z.windowSize
+	if n > z.read {
+		n = z.read
+	}
+	if n < z.windowSize || n < maxBlkSize {
+		return
+	}
+
+	z.hist = z.hist[:copy(z.hist, z.hist[n:])]
+	z.read -= n
+}
+
+// noEOF converts io.EOF, which only happens at the start of a frame,
+// to io.ErrUnexpectedEOF.
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func min64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,140 @@
+package zstd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// A Writer writes a single zstd frame.
+// Data is buffered until a block is full or the Writer is closed.
+// Each block is written as an RLE or compressed block
+// or, if that would not make it smaller, as a raw block.
+type Writer struct {
+	w   io.Writer
+	err error
+
+	buf     []byte
+	started bool // Blocks have been written.
+	digest  xxh64
+}
+
+// NewWriter returns a Writer that writes a zstd frame to w.
+// The frame is complete after the Writer is closed.
+func NewWriter(w io.Writer) *Writer {
+	z := &Writer{w: w}
+	z.digest.reset()
+	return z
+}
+
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+
+	n := len(p)
+	for len(p) > 0 {
+		if len(z.buf) == maxBlkSize {
+			if z.err = z.writeBlk(false); z.err != nil {
+				return n - len(p), z.err
+			}
+		}
+
+		k := maxBlkSize - len(z.buf)
+		if k > len(p) {
+			k = len(p)
+		}
+		z.buf = append(z.buf, p[:k]...)
+		p = p[k:]
+	}
+
+	return n, nil
+}
+
+var errClosed = errors.New("zstd: Writer is closed")
+
+// Close writes the buffered data as the last block
+// and the content checksum.
+// It does not close the underlying writer.
+func (z *Writer) Close() error {
+	if z.err == errClosed {
+		return nil
+	}
+	if z.err != nil {
+		return z.err
+	}
+
+	if z.err = z.writeBlk(true); z.err != nil {
+		return z.err
+	}
+
+	buf := make([]byte, 4)
+	le.PutUint32(buf, uint32(z.digest.Sum64()))
+	if _, z.err = z.w.Write(buf); z.err != nil {
+		return z.err
+	}
+
+	z.err = errClosed
+	return nil
+}
+
+func (z *Writer) writeBlk(last bool) error {
+	if !z.started {
+		if err := z.writeHdr(last); err != nil {
+			return err
+		}
+		z.started = true
+	}
+
+	z.digest.Write(z.buf)
+
+	typ, data, size := rawBlk, z.buf, len(z.buf)
+	if len(z.buf) > 1 && bytes.Count(z.buf, z.buf[:1]) == len(z.buf) {
+		typ, data = rleBlk, z.buf[:1]
+	} else if c := compress(z.buf); c != nil && len(c) < len(z.buf) {
+		typ, data, size = compressedBlk, c, len(c)
+	}
+
+	hdr := uint32(size)<<3 | uint32(typ)<<1
+	if last {
+		hdr |= 1
+	}
+	if _, err := z.w.Write(append([]byte{byte(hdr), byte(hdr >> 8), byte(hdr >> 16)}, data...)); err != nil {
+		return err
+	}
+
+	z.buf = z.buf[:0]
+	return nil
+}
+
+// writeHdr writes the frame header.
+// If the frame only has one block, its size is known
+// and used as the window size.
+func (z *Writer) writeHdr(single bool) error {
+	hdr := make([]byte, 4, 4+1+4)
+	le.PutUint32(hdr, magic)
+
+	const checksumFlag = 1 << 2
+	if !single {
+		// Window size: 1<<(10+7).
+		return write(z.w, append(hdr, checksumFlag, 7<<3))
+	}
+
+	const singleFlag = 1 << 5
+	switch size := len(z.buf); {
+	case size < 256:
+		hdr = append(hdr, 0<<6|singleFlag|checksumFlag, byte(size))
+	case size < 256+1<<16:
+		hdr = append(hdr, 1<<6|singleFlag|checksumFlag, 0, 0)
+		le.PutUint16(hdr[5:], uint16(size-256))
+	default:
+		hdr = append(hdr, 2<<6|singleFlag|checksumFlag, 0, 0, 0, 0)
+		le.PutUint32(hdr[5:], uint32(size))
+	}
+	return write(z.w, hdr)
+}
+
+func write(w io.Writer, p []byte) error {
+	_, err := w.Write(p)
+	return err
+}
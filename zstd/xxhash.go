@@ -0,0 +1,101 @@
+package zstd
+
+import "math/bits"
+
+// xxh64 computes the XXH64 hash with seed 0,
+// whose lower 32 bits are the content checksum of a frame.
+type xxh64 struct {
+	v     [4]uint64
+	total uint64
+	mem   [32]byte
+	n     int // of mem
+}
+
+// The primes are variables since prime1+prime2 and -prime1
+// overflow as constants.
+var (
+	prime1 uint64 = 11400714785074694791
+	prime2 uint64 = 14029467366897019727
+	prime3 uint64 = 1609587929392839161
+	prime4 uint64 = 9650029242287828579
+	prime5 uint64 = 2870177450012600261
+)
+
+func (h *xxh64) reset() {
+	*h = xxh64{v: [4]uint64{prime1 + prime2, prime2, 0, -prime1}}
+}
+
+func xxhRound(acc, input uint64) uint64 {
+	return bits.RotateLeft64(acc+input*prime2, 31) * prime1
+}
+
+func xxhMerge(acc, v uint64) uint64 {
+	return (acc^xxhRound(0, v))*prime1 + prime4
+}
+
+func (h *xxh64) Write(p []byte) (int, error) {
+	n := len(p)
+	h.total += uint64(n)
+
+	if h.n > 0 {
+		k := copy(h.mem[h.n:], p)
+		h.n += k
+		p = p[k:]
+		if h.n < len(h.mem) {
+			return n, nil
+		}
+		h.stripe(h.mem[:])
+		h.n = 0
+	}
+
+	for ; len(p) >= len(h.mem); p = p[len(h.mem):] {
+		h.stripe(p)
+	}
+	h.n = copy(h.mem[:], p)
+
+	return n, nil
+}
+
+func (h *xxh64) stripe(p []byte) {
+	for i := range h.v {
+		h.v[i] = xxhRound(h.v[i], le.Uint64(p[8*i:]))
+	}
+}
+
+func (h *xxh64) Sum64() uint64 {
+	var x uint64
+	if h.total >= 32 {
+		x = bits.RotateLeft64(h.v[0], 1) +
+			bits.RotateLeft64(h.v[1], 7) +
+			bits.RotateLeft64(h.v[2], 12) +
+			bits.RotateLeft64(h.v[3], 18)
+		for _, v := range h.v {
+			x = xxhMerge(x, v)
+		}
+	} else {
+		x = h.v[2] + prime5
+	}
+	x += h.total
+
+	p := h.mem[:h.n]
+	for ; len(p) >= 8; p = p[8:] {
+		x ^= xxhRound(0, le.Uint64(p))
+		x = bits.RotateLeft64(x, 27)*prime1 + prime4
+	}
+	if len(p) >= 4 {
+		x ^= uint64(le.Uint32(p)) * prime1
+		x = bits.RotateLeft64(x, 23)*prime2 + prime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		x ^= uint64(b) * prime5
+		x = bits.RotateLeft64(x, 11) * prime1
+	}
+
+	x ^= x >> 33
+	x *= prime2
+	x ^= x >> 29
+	x *= prime3
+	x ^= x >> 32
+	return x
+}
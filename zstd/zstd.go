@@ -0,0 +1,46 @@
+/*
+Package zstd implements reading and writing of zstd compressed data
+as described in RFC 8878.
+
+The Reader supports all of the format except dictionaries.
+The Writer favors simplicity over compression ratio:
+it only finds matches within a block, does not Huffman-code literals
+and encodes sequences using the predefined distributions.
+*/
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var le = binary.LittleEndian
+
+var (
+	ErrChecksum   = errors.New("zstd: invalid checksum")
+	ErrCorrupt    = errors.New("zstd: corrupt input")
+	ErrDictionary = errors.New("zstd: dictionaries are not supported")
+	ErrHeader     = errors.New("zstd: invalid header")
+)
+
+const (
+	magic = 0xfd2fb528
+
+	// Skippable frames have any of the magic numbers
+	// from skippableMagic to skippableMagic|0xf.
+	skippableMagic = 0x184d2a50
+
+	maxBlkSize = 128 << 10
+
+	// maxWindowSize is the largest window size that a Reader allocates.
+	// It is the default limit of the reference implementation.
+	maxWindowSize = 1 << 27
+)
+
+type blkType uint8
+
+const (
+	rawBlk blkType = iota
+	rleBlk
+	compressedBlk
+)
@@ -0,0 +1,259 @@
+package zstd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// testInput returns the data compressed in testdata
+// using the reference implementation:
+//
+//	1.zst       zstd -1
+//	19.zst      zstd -19
+//	wlog10.zst  zstd -3 --no-check --zstd=wlog=10
+//
+// It contains text, random bytes, zeros and more text.
+func testInput() []byte {
+	rnd := rand.New(rand.NewSource(1))
+	words := strings.Fields(`the of and a to in is you that it he was for on are as
+		with his they at be this have from or one had by word but not what all
+		were we when your can said there use an each which she do how their if`)
+
+	var b bytes.Buffer
+	text := func(n int) {
+		for b.Len() < n {
+			b.WriteString(words[rnd.Intn(len(words))])
+			b.WriteByte(' ')
+		}
+	}
+
+	text(12000)
+	for i := 0; i < 3000; i++ {
+		b.WriteByte(byte(rnd.Intn(256)))
+	}
+	b.Write(make([]byte, 5000))
+	text(28000)
+
+	return b.Bytes()
+}
+
+func decompress(data []byte) ([]byte, error) {
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestReader(t *testing.T) {
+	want := testInput()
+	for _, name := range []string{"1.zst", "19.zst", "wlog10.zst"} {
+		data, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The Reader must not read past the frame.
+		br := bytes.NewReader(append(data, "next"...))
+		r, err := NewReader(br)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: wrong data", name)
+		}
+		if br.Len() != len("next") {
+			t.Errorf("%s: %d bytes left, want %d", name, br.Len(), len("next"))
+		}
+
+		// Close reads the rest of the frame.
+		br.Reset(append(data, "next"...))
+		r, err = NewReader(br)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Read(make([]byte, 100)); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Close(); err != nil {
+			t.Errorf("%s: Close: %v", name, err)
+		}
+		if br.Len() != len("next") {
+			t.Errorf("%s: %d bytes left after Close, want %d", name, br.Len(), len("next"))
+		}
+	}
+}
+
+// frame returns a frame with a window size of 1 KiB
+// that contains the compressed block blk.
+func frame(blk ...byte) []byte {
+	hdr := uint32(len(blk))<<3 | uint32(compressedBlk)<<1 | 1
+	f := []byte{0x28, 0xb5, 0x2f, 0xfd, 0, 0, byte(hdr), byte(hdr >> 8), byte(hdr >> 16)}
+	return append(f, blk...)
+}
+
+func TestReaderBlks(t *testing.T) {
+	// Huffman weights for 'a', implying the weight of 'b'.
+	weights := make([]byte, 1+49)
+	weights[0] = 127 + 98
+	weights[49] = 1
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			"skippable",
+			append([]byte{0x5a, 0x2a, 0x4d, 0x18, 2, 0, 0, 0, 'h', 'i'}, frame(2<<3, 'h', 'i', 0)...),
+			"hi",
+		},
+		{
+			"RLE lits",
+			frame(1|5<<3, 'x', 0),
+			"xxxxx",
+		},
+		{
+			"direct Huffman weights",
+			frame(append(append([]byte{0x42, 0xc0, 0x0c}, weights...), 0x16, 0)...),
+			"abba",
+		},
+		{
+			"RLE seqs",
+			// Literals "ab", then a match of length 7 at offset 2.
+			frame(2<<3, 'a', 'b', 1, 1<<6|1<<4|1<<2, 2, 2, 4, 0b101),
+			"ababababa",
+		},
+	} {
+		got, err := decompress(tc.data)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestReaderErrs(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	w.Write([]byte("hello"))
+	w.Close()
+	data := b.Bytes()
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, io.EOF},
+		{"magic", []byte("hello"), ErrHeader},
+		{"dict", []byte{0x28, 0xb5, 0x2f, 0xfd, 1<<5 | 1, 1, 5}, ErrDictionary},
+		{"truncated", data[:len(data)-1], io.ErrUnexpectedEOF},
+		{"checksum", append(data[:len(data)-1:len(data)-1], data[len(data)-1]^1), ErrChecksum},
+		{"offset", frame(1<<3, 'a', 1, 1<<6|1<<4|1<<2, 1, 3, 0, 0b1000), ErrCorrupt},
+	} {
+		if _, err := decompress(tc.data); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	in := testInput()
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		compress bool // The output must be less than half the size.
+	}{
+		{"empty", nil, false},
+		{"byte", []byte{1}, false},
+		{"small", in[:100], false},
+		{"RLE", make([]byte, 1000), true},
+		{"random", in[12000:15000], false},
+		{"match", bytes.Repeat([]byte("abcd"), 25), true},
+		{"blk", in[:maxBlkSize/4], true},
+		{"blks", bytes.Repeat(in, 5), true},
+	} {
+		var b bytes.Buffer
+		w := NewWriter(&b)
+		if _, err := w.Write(tc.data[:len(tc.data)/3]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(tc.data[len(tc.data)/3:]); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := decompress(b.Bytes())
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if !bytes.Equal(got, tc.data) {
+			t.Errorf("%s: wrong data", tc.name)
+		}
+		if tc.compress && b.Len() >= len(tc.data)/2 {
+			t.Errorf("%s: compressed %d bytes to %d", tc.name, len(tc.data), b.Len())
+		}
+	}
+}
+
+// TestWriterRef checks that the reference implementation
+// can decompress the output of a Writer.
+func TestWriterRef(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd command not found")
+	}
+
+	in := bytes.Repeat(testInput(), 5)
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	w.Write(in)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("zstd", "-d", "-c")
+	cmd.Stdin = &b
+	got, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, in) {
+		t.Error("wrong data")
+	}
+}
+
+func TestXXH64(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+	} {
+		var h xxh64
+		h.reset()
+		h.Write([]byte(tc.in))
+		if got := h.Sum64(); got != tc.want {
+			t.Errorf("%q: got %#x, want %#x", tc.in, got, tc.want)
+		}
+	}
+}